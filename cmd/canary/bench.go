@@ -0,0 +1,398 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/bench"
+	"go.devnw.com/canary/internal/config"
+	"go.devnw.com/canary/internal/policy"
+	"go.devnw.com/canary/internal/scanner"
+	"go.devnw.com/canary/internal/storage"
+	"go.devnw.com/canary/internal/token"
+)
+
+// CANARY: REQ=CBIN-167; FEATURE="BenchCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2026-10-17
+var benchCmd = &cobra.Command{
+	Use:   "bench <subcommand>",
+	Short: "Track benchmark results of BENCH= references across commits",
+	Long: `Record the benchmarks tokens name in BENCH= and catch regressions.
+
+Subcommands:
+  ingest    Store go test -bench results for the current commit
+  compare   Compare the current commit against a checkpoint or commit`,
+}
+
+var benchIngestCmd = &cobra.Command{
+	Use:   "ingest <file>...",
+	Short: "Store go test -bench results for the current commit",
+	Long: `Ingest reads go test -bench output, plain or -json, or a file in the Go
+benchmark format benchstat reads, and stores ns/op, B/op and allocs/op of
+every benchmark an indexed token names in BENCH=. Sub-benchmarks are stored
+under the BENCH= reference of their parent. Runs repeated with -count are
+averaged. Use "-" to read standard input.
+
+Results are recorded for the commit checked out (git rev-parse HEAD) unless
+--commit is given, replacing earlier results for that commit.
+
+Examples:
+  go test -run '^$' -bench . -benchmem ./... > bench.txt && canary bench ingest bench.txt
+  go test -run '^$' -bench . -count 5 ./... | canary bench ingest -`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbPath, _ := cmd.Flags().GetString("db")
+		commit, _ := cmd.Flags().GetString("commit")
+
+		var results []bench.Result
+		for _, path := range args {
+			var res []bench.Result
+			var err error
+			if path == "-" {
+				res, err = bench.Parse(cmd.InOrStdin())
+			} else {
+				res, err = bench.Read(path)
+			}
+			if err != nil {
+				return err
+			}
+			results = append(results, res...)
+		}
+
+		commit, err := resolveCommit(commit)
+		if err != nil {
+			return err
+		}
+
+		db, err := storage.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+
+		tokens, err := db.ListTokens(map[string]string{"include_hidden": "true"}, "", "", 0)
+		if err != nil {
+			return fmt.Errorf("query tokens: %w", err)
+		}
+
+		rows, unmatched := benchRows(tokens, results, commit, strings.Join(args, ","))
+		if err := db.RecordBenchResults(rows); err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Recorded %d benchmark results at commit %s\n", len(rows), shortHash(commit))
+		if len(unmatched) > 0 {
+			fmt.Fprintf(out, "%d benchmarks are not named by any BENCH= reference: %s\n", len(unmatched), strings.Join(unmatched, ", "))
+		}
+		return nil
+	},
+}
+
+var benchCompareCmd = &cobra.Command{
+	Use:   "compare <checkpoint|commit>",
+	Short: "Flag benchmark regressions against a checkpoint or commit",
+	Long: `Compare the benchmark results recorded for the current commit with those of
+a baseline, given as the name of a checkpoint or a commit (a prefix of the
+hash, or any revision git understands).
+
+A benchmark regresses when ns/op, or B/op and allocs/op when both runs used
+-benchmem, grew by more than verification.bench_regression percent from
+.canary/project.yaml, which can be overridden per aspect and per requirement,
+or --threshold when given. The default is 10%. The command exits 2 on any
+regression:
+
+  verification:
+    bench_regression: 10
+    requirements:
+      CBIN-105: {bench_regression: 25}
+
+Examples:
+  canary bench compare v1.0
+  canary bench compare main --commit HEAD
+  canary bench compare 3f2a9c1 --threshold 5 --format json`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbPath, _ := cmd.Flags().GetString("db")
+		commit, _ := cmd.Flags().GetString("commit")
+		format, _ := cmd.Flags().GetString("format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q (want text or json)", format)
+		}
+
+		db, err := storage.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+
+		baseCommit, err := resolveBenchBaseline(db, args[0])
+		if err != nil {
+			return err
+		}
+		if commit, err = resolveCommit(commit); err != nil {
+			return err
+		}
+		base, err := db.BenchResults("", baseCommit)
+		if err != nil {
+			return err
+		}
+		cur, err := db.BenchResults("", commit)
+		if err != nil {
+			return err
+		}
+		if len(cur) == 0 {
+			return fmt.Errorf("no benchmark results recorded for commit %s; run 'canary bench ingest' first", shortHash(commit))
+		}
+
+		tokens, err := db.ListTokens(map[string]string{"include_hidden": "true"}, "", "", 0)
+		if err != nil {
+			return fmt.Errorf("query tokens: %w", err)
+		}
+		aspects := map[[2]string]string{}
+		for _, t := range tokens {
+			aspects[[2]string{t.ReqID, t.Feature}] = t.Aspect
+		}
+
+		pol := &policy.Policy{}
+		if cfg, err := config.Load("."); err == nil {
+			pol = cfg.Policy()
+		}
+		threshold := func(req, feature string) (float64, string) {
+			if cmd.Flags().Changed("threshold") {
+				v, _ := cmd.Flags().GetFloat64("threshold")
+				return v, "--threshold"
+			}
+			rule := pol.For(req, aspects[[2]string{req, feature}])
+			if rule.BenchRegression <= 0 {
+				return bench.DefaultThreshold, "default"
+			}
+			return rule.BenchRegression, rule.Scope[policy.RuleBench]
+		}
+
+		cmp := compareBench(base, cur, threshold)
+		cmp.Base, cmp.Current = baseCommit, commit
+		out := cmd.OutOrStdout()
+		if format == "json" {
+			err = writeBenchJSON(out, cmp)
+		} else {
+			err = writeBenchText(out, cmp)
+		}
+		if err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+
+		if cmp.Regressions > 0 {
+			return &scanner.ExitError{Code: scanner.ExitVerifyFail, Err: fmt.Errorf("%d benchmark regressions", cmp.Regressions)}
+		}
+		return nil
+	},
+}
+
+func init() {
+	benchCmd.AddCommand(benchIngestCmd)
+	benchCmd.AddCommand(benchCompareCmd)
+	benchCmd.PersistentFlags().String("db", ".canary/canary.db", "path to database file")
+	benchCmd.PersistentFlags().String("commit", "", "commit the results belong to (default: HEAD)")
+
+	benchCompareCmd.Flags().Float64("threshold", 0, "allowed slowdown in percent (overrides project.yaml)")
+	benchCompareCmd.Flags().String("format", "text", "output format: text or json")
+}
+
+// resolveCommit expands rev to a full commit hash with git, defaulting to
+// HEAD. A rev git doesn't know is used as given.
+func resolveCommit(rev string) (string, error) {
+	name := rev
+	if name == "" {
+		name = "HEAD"
+	}
+	out, err := exec.Command("git", "rev-parse", "--verify", "--quiet", name+"^{commit}").Output()
+	if err == nil {
+		return strings.TrimSpace(string(out)), nil
+	}
+	if rev == "" {
+		return "", fmt.Errorf("cannot determine the current commit; pass --commit")
+	}
+	return rev, nil
+}
+
+// resolveBenchBaseline returns the commit ref names: the commit of the
+// checkpoint called ref, else the one recorded commit ref is a prefix of,
+// else what git resolves ref to.
+func resolveBenchBaseline(db *storage.DB, ref string) (string, error) {
+	checkpoints, err := db.GetCheckpoints()
+	if err != nil {
+		return "", fmt.Errorf("query checkpoints: %w", err)
+	}
+	for _, cp := range checkpoints {
+		if cp.Name == ref {
+			if cp.CommitHash == "" {
+				return "", fmt.Errorf("checkpoint %s has no commit", ref)
+			}
+			return cp.CommitHash, nil
+		}
+	}
+
+	commits, err := db.BenchCommits("", ref)
+	if err != nil {
+		return "", err
+	}
+	switch len(commits) {
+	case 1:
+		return commits[0], nil
+	case 0:
+	default:
+		return "", fmt.Errorf("%s is ambiguous: %d commits with benchmark results start with it", ref, len(commits))
+	}
+
+	// a branch, tag or other revision
+	if commit, err := resolveCommit(ref); err == nil && commit != ref {
+		if recorded, err := db.BenchCommits("", commit); err == nil && len(recorded) > 0 {
+			return recorded[0], nil
+		}
+	}
+	return "", fmt.Errorf("no benchmark results recorded for %s", ref)
+}
+
+// benchRows pairs results with the tokens whose BENCH= references name them,
+// returning the rows to store and the names of unreferenced benchmarks.
+func benchRows(tokens []*storage.Token, results []bench.Result, commit, source string) ([]storage.BenchResult, []string) {
+	var rows []storage.BenchResult
+	var unmatched []string
+	seen := map[[3]string]bool{}
+	for _, r := range results {
+		matched := false
+		for _, t := range tokens {
+			for _, ref := range token.SplitList(t.Bench) {
+				if !bench.Matches(ref, r.Name) {
+					continue
+				}
+				matched = true
+				k := [3]string{t.ReqID, t.Feature, r.Name}
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+				rows = append(rows, storage.BenchResult{
+					ReqID: t.ReqID, Feature: t.Feature, Bench: ref, Name: r.Name, Package: r.Package,
+					CommitHash: commit, Runs: r.Runs, NsPerOp: r.NsPerOp,
+					Mem: r.Mem, BytesPerOp: r.BytesPerOp, AllocsPerOp: r.AllocsPerOp, Source: source,
+				})
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, r.Name)
+		}
+	}
+	return rows, unmatched
+}
+
+// benchChange is one benchmark measured at both commits.
+type benchChange struct {
+	ReqID     string        `json:"req_id"`
+	Feature   string        `json:"feature"`
+	Name      string        `json:"name"`
+	Deltas    []bench.Delta `json:"deltas"`
+	Threshold float64       `json:"threshold"`
+	Scope     string        `json:"scope"` // where the threshold was set
+	Regressed bool          `json:"regressed"`
+}
+
+// benchComparison is the result of comparing two commits.
+type benchComparison struct {
+	Base        string        `json:"base"`
+	Current     string        `json:"current"`
+	Changes     []benchChange `json:"changes"`
+	Added       []string      `json:"added,omitempty"`   // only measured at the current commit
+	Removed     []string      `json:"removed,omitempty"` // only measured at the baseline
+	Regressions int           `json:"regressions"`
+}
+
+// compareBench compares the benchmarks of each token measured at both
+// commits, flagging those that grew by more than threshold percent.
+func compareBench(base, cur []storage.BenchResult, threshold func(req, feature string) (float64, string)) benchComparison {
+	key := func(r storage.BenchResult) [3]string { return [3]string{r.ReqID, r.Feature, r.Name} }
+	label := func(r storage.BenchResult) string { return fmt.Sprintf("%s %q %s", r.ReqID, r.Feature, r.Name) }
+	result := func(r storage.BenchResult) bench.Result {
+		return bench.Result{Name: r.Name, NsPerOp: r.NsPerOp, Mem: r.Mem, BytesPerOp: r.BytesPerOp, AllocsPerOp: r.AllocsPerOp}
+	}
+
+	byKey := map[[3]string]storage.BenchResult{}
+	for _, r := range base {
+		byKey[key(r)] = r
+	}
+	var cmp benchComparison
+	for _, r := range cur {
+		b, ok := byKey[key(r)]
+		if !ok {
+			cmp.Added = append(cmp.Added, label(r))
+			continue
+		}
+		delete(byKey, key(r))
+
+		c := benchChange{ReqID: r.ReqID, Feature: r.Feature, Name: r.Name, Deltas: bench.Compare(result(b), result(r))}
+		c.Threshold, c.Scope = threshold(r.ReqID, r.Feature)
+		for _, d := range c.Deltas {
+			if d.Percent > c.Threshold {
+				c.Regressed = true
+			}
+		}
+		if c.Regressed {
+			cmp.Regressions++
+		}
+		cmp.Changes = append(cmp.Changes, c)
+	}
+	for _, r := range base {
+		if _, ok := byKey[key(r)]; ok {
+			cmp.Removed = append(cmp.Removed, label(r))
+		}
+	}
+	return cmp
+}
+
+func writeBenchText(w io.Writer, cmp benchComparison) error {
+	fmt.Fprintf(w, "Benchmarks: %s -> %s\n\n", shortHash(cmp.Base), shortHash(cmp.Current))
+	for _, c := range cmp.Changes {
+		fmt.Fprintf(w, "%s %q %s\n", c.ReqID, c.Feature, c.Name)
+		for _, d := range c.Deltas {
+			mark := ""
+			if d.Percent > c.Threshold {
+				mark = fmt.Sprintf("  REGRESSION (over %.1f%%, %s)", c.Threshold, c.Scope)
+			}
+			fmt.Fprintf(w, "  %-10s %12.2f -> %12.2f  %+7.1f%%%s\n", d.Metric, d.Base, d.Current, d.Percent, mark)
+		}
+	}
+	for _, a := range cmp.Added {
+		fmt.Fprintf(w, "new: %s\n", a)
+	}
+	for _, r := range cmp.Removed {
+		fmt.Fprintf(w, "gone: %s\n", r)
+	}
+	fmt.Fprintln(w)
+	_, err := fmt.Fprintf(w, "%d benchmarks compared: %d regressions\n", len(cmp.Changes), cmp.Regressions)
+	return err
+}
+
+func writeBenchJSON(w io.Writer, cmp benchComparison) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cmp)
+}
+
+// shortHash abbreviates a commit hash for display.
+func shortHash(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"go.devnw.com/canary/internal/bench"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// TestCANARY_CBIN_167_CLI_BenchCompare verifies ingested benchmarks are
+// stored per BENCH= reference and regressions beyond each requirement's
+// threshold are flagged.
+func TestCANARY_CBIN_167_CLI_BenchCompare(t *testing.T) {
+	tokens := []*storage.Token{
+		{ReqID: "CBIN-100", Feature: "Parse", Aspect: "Engine", Bench: "BenchmarkParse"},
+		{ReqID: "CBIN-101", Feature: "Render", Aspect: "Engine", Bench: "pkg.BenchmarkRender, BenchmarkGone"},
+	}
+	results := []bench.Result{
+		{Name: "BenchmarkParse/small", Runs: 1, NsPerOp: 100},
+		{Name: "BenchmarkRender", Runs: 1, NsPerOp: 1000, Mem: true, BytesPerOp: 64, AllocsPerOp: 1},
+		{Name: "BenchmarkOther", Runs: 1, NsPerOp: 5},
+	}
+	rows, unmatched := benchRows(tokens, results, "c1", "bench.txt")
+	if len(rows) != 2 || rows[0].Bench != "BenchmarkParse" || rows[1].Bench != "pkg.BenchmarkRender" {
+		t.Fatalf("rows = %+v", rows)
+	}
+	if !reflect.DeepEqual(unmatched, []string{"BenchmarkOther"}) {
+		t.Errorf("unmatched = %v", unmatched)
+	}
+
+	base := []storage.BenchResult{
+		{ReqID: "CBIN-100", Feature: "Parse", Name: "BenchmarkParse/small", NsPerOp: 100},
+		{ReqID: "CBIN-101", Feature: "Render", Name: "BenchmarkRender", NsPerOp: 1000, Mem: true, BytesPerOp: 64, AllocsPerOp: 1},
+		{ReqID: "CBIN-101", Feature: "Render", Name: "BenchmarkGone", NsPerOp: 10},
+	}
+	cur := []storage.BenchResult{
+		{ReqID: "CBIN-100", Feature: "Parse", Name: "BenchmarkParse/small", NsPerOp: 115},
+		{ReqID: "CBIN-101", Feature: "Render", Name: "BenchmarkRender", NsPerOp: 1150, Mem: true, BytesPerOp: 64, AllocsPerOp: 1},
+		{ReqID: "CBIN-101", Feature: "Render", Name: "BenchmarkNew", NsPerOp: 10},
+	}
+	// CBIN-101 tolerates a 20% slowdown, everything else 10%
+	threshold := func(req, feature string) (float64, string) {
+		if req == "CBIN-101" {
+			return 20, "requirement CBIN-101"
+		}
+		return bench.DefaultThreshold, "default"
+	}
+	cmp := compareBench(base, cur, threshold)
+
+	var regressed []string
+	for _, c := range cmp.Changes {
+		if c.Regressed {
+			regressed = append(regressed, c.Name)
+		}
+	}
+	if cmp.Regressions != 1 || !reflect.DeepEqual(regressed, []string{"BenchmarkParse/small"}) {
+		t.Errorf("regressed = %v (%d)", regressed, cmp.Regressions)
+	}
+	if len(cmp.Changes[1].Deltas) != 3 {
+		t.Errorf("Render deltas = %+v, want ns/op, B/op and allocs/op", cmp.Changes[1].Deltas)
+	}
+	if !reflect.DeepEqual(cmp.Added, []string{`CBIN-101 "Render" BenchmarkNew`}) ||
+		!reflect.DeepEqual(cmp.Removed, []string{`CBIN-101 "Render" BenchmarkGone`}) {
+		t.Errorf("added %v, removed %v", cmp.Added, cmp.Removed)
+	}
+}
@@ -0,0 +1,587 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// CANARY: REQ=CBIN-173; FEATURE="CheckpointDiff"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_173_CLI_CheckpointDiff; OWNER=canary; UPDATED=2026-10-17
+var checkpointListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List checkpoints, newest first",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbPath, _ := cmd.Flags().GetString("db")
+		format, err := checkpointFormat(cmd, false)
+		if err != nil {
+			return err
+		}
+
+		db, err := storage.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+
+		checkpoints, err := db.GetCheckpoints()
+		if err != nil {
+			return fmt.Errorf("query checkpoints: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		if format == "json" {
+			refs := make([]checkpointRef, 0, len(checkpoints))
+			for _, cp := range checkpoints {
+				refs = append(refs, newCheckpointRef(cp))
+			}
+			return writeJSON(out, refs)
+		}
+
+		if len(checkpoints) == 0 {
+			fmt.Fprintln(out, "No checkpoints yet; create one with 'canary checkpoint <name>'")
+			return nil
+		}
+		fmt.Fprintf(out, "Checkpoints (%d):\n\n", len(checkpoints))
+		for _, cp := range checkpoints {
+			fmt.Fprintf(out, "%-20s %s  %-8s  %d tokens (%d STUB, %d IMPL, %d TESTED, %d BENCHED)\n",
+				cp.Name, historyTime(cp.CreatedAt), shortHash(cp.CommitHash),
+				cp.TotalTokens, cp.StubCount, cp.ImplCount, cp.TestedCount, cp.BenchedCount)
+			if cp.Description != "" {
+				fmt.Fprintf(out, "%-20s %s\n", "", cp.Description)
+			}
+		}
+		return nil
+	},
+}
+
+var checkpointShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the requirements and tokens a checkpoint captured",
+	Long: `Show lists the state of every requirement when the checkpoint was taken:
+its features with their aspect, status, owner and location.
+
+Examples:
+  canary checkpoint show v1.0
+  canary checkpoint show v1.0 --format markdown`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbPath, _ := cmd.Flags().GetString("db")
+		format, err := checkpointFormat(cmd, true)
+		if err != nil {
+			return err
+		}
+
+		db, err := storage.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+
+		cp, tokens, err := loadCheckpoint(db, args[0])
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		switch format {
+		case "json":
+			return writeJSON(out, struct {
+				checkpointRef
+				Tokens []*storage.Token
+			}{cp, tokens})
+		case "markdown":
+			return writeCheckpointMarkdown(out, cp, tokens)
+		default:
+			return writeCheckpointText(out, cp, tokens)
+		}
+	},
+}
+
+var checkpointDiffCmd = &cobra.Command{
+	Use:   "diff <a> [b|HEAD]",
+	Short: "Compare two checkpoints, or a checkpoint with the current state",
+	Long: `Diff compares the tokens captured by checkpoint a with those of checkpoint
+b, or with the indexed tokens now when b is HEAD or omitted.
+
+For every requirement that changed it shows:
+- The requirement's status: the least advanced status of its features
+- Features added and removed
+- Features whose status or owner changed
+
+Features are matched on requirement, feature name and aspect. Use
+--format markdown to paste the result into release notes.
+
+Examples:
+  canary checkpoint diff v1.0
+  canary checkpoint diff v1.0 v1.1 --format markdown
+  canary checkpoint diff v1.0 HEAD --format json`,
+	Args:          cobra.RangeArgs(1, 2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbPath, _ := cmd.Flags().GetString("db")
+		format, err := checkpointFormat(cmd, true)
+		if err != nil {
+			return err
+		}
+		to := "HEAD"
+		if len(args) > 1 {
+			to = args[1]
+		}
+
+		db, err := storage.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+
+		fromRef, fromTokens, err := loadCheckpoint(db, args[0])
+		if err != nil {
+			return err
+		}
+		toRef, toTokens, err := loadCheckpoint(db, to)
+		if err != nil {
+			return err
+		}
+
+		lc, err := projectLifecycle(".")
+		if err != nil {
+			return err
+		}
+		diff := diffCheckpoints(fromTokens, toTokens, lc)
+		diff.From, diff.To = fromRef, toRef
+
+		out := cmd.OutOrStdout()
+		switch format {
+		case "json":
+			return writeJSON(out, diff)
+		case "markdown":
+			return writeDiffMarkdown(out, diff)
+		default:
+			return writeDiffText(out, diff)
+		}
+	},
+}
+
+// checkpointFormat returns the --format flag after checking it is known
+func checkpointFormat(cmd *cobra.Command, markdown bool) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	switch {
+	case format == "text" || format == "json":
+		return format, nil
+	case format == "markdown" && markdown:
+		return format, nil
+	case markdown:
+		return "", fmt.Errorf("unknown format %q (want text, json or markdown)", format)
+	default:
+		return "", fmt.Errorf("unknown format %q (want text or json)", format)
+	}
+}
+
+// checkpointTokens returns the tokens a checkpoint captures: every indexed
+// token matching the project's requirement ID pattern
+func checkpointTokens(db *storage.DB) ([]*storage.Token, error) {
+	idPattern := ""
+	if cfg, _ := loadProjectConfig(); cfg != nil {
+		idPattern = cfg.Requirements.IDPattern
+	}
+
+	tokens, err := db.ListTokens(nil, idPattern, "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("get tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// checkpointRef identifies one side of a checkpoint diff
+type checkpointRef struct {
+	Name        string
+	Description string `json:",omitempty"`
+	CommitHash  string `json:",omitempty"`
+	CreatedAt   string `json:",omitempty"`
+	TotalTokens int
+}
+
+func newCheckpointRef(cp *storage.Checkpoint) checkpointRef {
+	return checkpointRef{
+		Name:        cp.Name,
+		Description: cp.Description,
+		CommitHash:  cp.CommitHash,
+		CreatedAt:   cp.CreatedAt,
+		TotalTokens: cp.TotalTokens,
+	}
+}
+
+// loadCheckpoint returns the checkpoint called name and its tokens, or the
+// current tokens for HEAD
+func loadCheckpoint(db *storage.DB, name string) (checkpointRef, []*storage.Token, error) {
+	if name == "HEAD" {
+		tokens, err := checkpointTokens(db)
+		if err != nil {
+			return checkpointRef{}, nil, err
+		}
+		commit, _ := gitHead()
+		return checkpointRef{Name: "HEAD", CommitHash: commit, TotalTokens: len(tokens)}, tokens, nil
+	}
+
+	cp, err := db.GetCheckpoint(name)
+	if err != nil {
+		return checkpointRef{}, nil, err
+	}
+	if cp == nil {
+		return checkpointRef{}, nil, fmt.Errorf("no checkpoint named %q; see 'canary checkpoint list'", name)
+	}
+	tokens, err := cp.Tokens()
+	if err != nil {
+		return checkpointRef{}, nil, err
+	}
+	return newCheckpointRef(cp), tokens, nil
+}
+
+// featureState is one feature of a requirement in a checkpoint
+type featureState struct {
+	Feature string
+	Aspect  string
+	Status  string
+	Owner   string `json:",omitempty"`
+}
+
+// featureChange is a status or owner change of one feature
+type featureChange struct {
+	Feature string
+	Aspect  string
+	From    string
+	To      string
+}
+
+// requirementDiff is how one requirement changed between two checkpoints
+type requirementDiff struct {
+	ReqID         string
+	FromStatus    string          `json:",omitempty"` // empty when the requirement is new
+	ToStatus      string          `json:",omitempty"` // empty when the requirement is gone
+	Added         []featureState  `json:",omitempty"`
+	Removed       []featureState  `json:",omitempty"`
+	StatusChanges []featureChange `json:",omitempty"`
+	OwnerChanges  []featureChange `json:",omitempty"`
+}
+
+// checkpointDiff is the result of comparing two checkpoints
+type checkpointDiff struct {
+	From         checkpointRef
+	To           checkpointRef
+	Requirements []requirementDiff
+}
+
+// requirementFeatures groups tokens by requirement, then by feature and
+// aspect. A feature with several tokens takes the least advanced status
+// and the first owner set, in file order.
+func requirementFeatures(tokens []*storage.Token, lc *lifecycle.Lifecycle) map[string]map[[2]string]*featureState {
+	sorted := append([]*storage.Token(nil), tokens...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].FilePath != sorted[j].FilePath {
+			return sorted[i].FilePath < sorted[j].FilePath
+		}
+		return sorted[i].LineNumber < sorted[j].LineNumber
+	})
+
+	reqs := map[string]map[[2]string]*featureState{}
+	for _, t := range sorted {
+		features := reqs[t.ReqID]
+		if features == nil {
+			features = map[[2]string]*featureState{}
+			reqs[t.ReqID] = features
+		}
+		key := [2]string{t.Feature, t.Aspect}
+		f := features[key]
+		if f == nil {
+			features[key] = &featureState{Feature: t.Feature, Aspect: t.Aspect, Status: t.Status, Owner: t.Owner}
+			continue
+		}
+		if lc.Rank(t.Status) < lc.Rank(f.Status) {
+			f.Status = t.Status
+		}
+		if f.Owner == "" {
+			f.Owner = t.Owner
+		}
+	}
+	return reqs
+}
+
+// requirementStatus is the least advanced status of a requirement's
+// features
+func requirementStatus(features map[[2]string]*featureState, lc *lifecycle.Lifecycle) string {
+	status := ""
+	for _, f := range features {
+		if status == "" || lc.Rank(f.Status) < lc.Rank(status) ||
+			(lc.Rank(f.Status) == lc.Rank(status) && f.Status < status) {
+			status = f.Status
+		}
+	}
+	return status
+}
+
+// diffCheckpoints compares the tokens of two checkpoints per requirement,
+// listing only requirements that changed, by ID
+func diffCheckpoints(from, to []*storage.Token, lc *lifecycle.Lifecycle) checkpointDiff {
+	before, after := requirementFeatures(from, lc), requirementFeatures(to, lc)
+
+	ids := map[string]bool{}
+	for id := range before {
+		ids[id] = true
+	}
+	for id := range after {
+		ids[id] = true
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	diff := checkpointDiff{Requirements: []requirementDiff{}}
+	for _, id := range sorted {
+		old, cur := before[id], after[id]
+		r := requirementDiff{ReqID: id, FromStatus: requirementStatus(old, lc), ToStatus: requirementStatus(cur, lc)}
+
+		for key, f := range cur {
+			o := old[key]
+			if o == nil {
+				r.Added = append(r.Added, *f)
+				continue
+			}
+			if o.Status != f.Status {
+				r.StatusChanges = append(r.StatusChanges, featureChange{f.Feature, f.Aspect, o.Status, f.Status})
+			}
+			if o.Owner != f.Owner {
+				r.OwnerChanges = append(r.OwnerChanges, featureChange{f.Feature, f.Aspect, o.Owner, f.Owner})
+			}
+		}
+		for key, o := range old {
+			if cur[key] == nil {
+				r.Removed = append(r.Removed, *o)
+			}
+		}
+
+		if r.FromStatus == r.ToStatus && len(r.Added)+len(r.Removed)+len(r.StatusChanges)+len(r.OwnerChanges) == 0 {
+			continue
+		}
+		sortFeatures(r.Added)
+		sortFeatures(r.Removed)
+		sortChanges(r.StatusChanges)
+		sortChanges(r.OwnerChanges)
+		diff.Requirements = append(diff.Requirements, r)
+	}
+
+	return diff
+}
+
+func sortFeatures(fs []featureState) {
+	sort.Slice(fs, func(i, j int) bool {
+		if fs[i].Feature != fs[j].Feature {
+			return fs[i].Feature < fs[j].Feature
+		}
+		return fs[i].Aspect < fs[j].Aspect
+	})
+}
+
+func sortChanges(cs []featureChange) {
+	sort.Slice(cs, func(i, j int) bool {
+		if cs[i].Feature != cs[j].Feature {
+			return cs[i].Feature < cs[j].Feature
+		}
+		return cs[i].Aspect < cs[j].Aspect
+	})
+}
+
+// summary totals the changes of a diff in one sentence
+func (d checkpointDiff) summary() string {
+	var added, removed, status, owner int
+	for _, r := range d.Requirements {
+		added += len(r.Added)
+		removed += len(r.Removed)
+		status += len(r.StatusChanges)
+		owner += len(r.OwnerChanges)
+	}
+	return fmt.Sprintf("%s changed: %s added, %d removed, %s, %s",
+		plural(len(d.Requirements), "requirement"), plural(added, "feature"), removed,
+		plural(status, "status change"), plural(owner, "owner change"))
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// describeRef names a checkpoint with its commit and date
+func describeRef(ref checkpointRef) string {
+	var details []string
+	if ref.CommitHash != "" {
+		details = append(details, shortHash(ref.CommitHash))
+	}
+	if ref.CreatedAt != "" {
+		details = append(details, historyTime(ref.CreatedAt))
+	}
+	if len(details) == 0 {
+		return ref.Name
+	}
+	return fmt.Sprintf("%s (%s)", ref.Name, strings.Join(details, ", "))
+}
+
+// transition renders a requirement status change; a requirement that is
+// new or gone shows as such
+func transition(from, to string) string {
+	switch {
+	case from == "":
+		return "new, " + to
+	case to == "":
+		return "removed, was " + from
+	case from == to:
+		return to
+	default:
+		return from + " → " + to
+	}
+}
+
+func writeDiffText(w io.Writer, d checkpointDiff) error {
+	fmt.Fprintf(w, "Checkpoint diff: %s → %s\n", describeRef(d.From), describeRef(d.To))
+	for _, r := range d.Requirements {
+		fmt.Fprintf(w, "\n%s: %s\n", r.ReqID, transition(r.FromStatus, r.ToStatus))
+		for _, f := range r.Added {
+			fmt.Fprintf(w, "  + %s [%s] %s\n", f.Feature, f.Aspect, f.Status)
+		}
+		for _, f := range r.Removed {
+			fmt.Fprintf(w, "  - %s [%s] %s\n", f.Feature, f.Aspect, f.Status)
+		}
+		for _, c := range r.StatusChanges {
+			fmt.Fprintf(w, "  ~ %s [%s] status %s → %s\n", c.Feature, c.Aspect, c.From, c.To)
+		}
+		for _, c := range r.OwnerChanges {
+			fmt.Fprintf(w, "  ~ %s [%s] owner %s → %s\n", c.Feature, c.Aspect, historyValue(c.From), historyValue(c.To))
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", d.summary())
+	return err
+}
+
+func writeDiffMarkdown(w io.Writer, d checkpointDiff) error {
+	fmt.Fprintf(w, "## Changes from %s to %s\n\n", describeRef(d.From), describeRef(d.To))
+	fmt.Fprintf(w, "%s.\n", d.summary())
+	for _, r := range d.Requirements {
+		fmt.Fprintf(w, "\n### %s: %s\n\n", r.ReqID, transition(r.FromStatus, r.ToStatus))
+		for _, f := range r.Added {
+			fmt.Fprintf(w, "- Added **%s** (%s, %s)\n", f.Feature, f.Aspect, f.Status)
+		}
+		for _, f := range r.Removed {
+			fmt.Fprintf(w, "- Removed **%s** (%s, was %s)\n", f.Feature, f.Aspect, f.Status)
+		}
+		for _, c := range r.StatusChanges {
+			fmt.Fprintf(w, "- **%s** (%s): %s → %s\n", c.Feature, c.Aspect, c.From, c.To)
+		}
+		for _, c := range r.OwnerChanges {
+			fmt.Fprintf(w, "- **%s** (%s) owner: %s → %s\n", c.Feature, c.Aspect, historyValue(c.From), historyValue(c.To))
+		}
+	}
+	return nil
+}
+
+// writeCheckpointText lists the features of each requirement in a
+// checkpoint
+func writeCheckpointText(w io.Writer, ref checkpointRef, tokens []*storage.Token) error {
+	fmt.Fprintf(w, "Checkpoint %s\n", describeRef(ref))
+	if ref.Description != "" {
+		fmt.Fprintln(w, ref.Description)
+	}
+	for _, id := range sortedReqIDs(tokens) {
+		fmt.Fprintf(w, "\n%s\n", id)
+		for _, t := range tokensOf(tokens, id) {
+			fmt.Fprintf(w, "  %-8s %s [%s] %s:%d", t.Status, t.Feature, t.Aspect, t.FilePath, t.LineNumber)
+			if t.Owner != "" {
+				fmt.Fprintf(w, " (%s)", t.Owner)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", plural(len(tokens), "token"))
+	return err
+}
+
+func writeCheckpointMarkdown(w io.Writer, ref checkpointRef, tokens []*storage.Token) error {
+	fmt.Fprintf(w, "## Checkpoint %s\n\n", describeRef(ref))
+	if ref.Description != "" {
+		fmt.Fprintf(w, "%s\n\n", ref.Description)
+	}
+	fmt.Fprintln(w, "| Requirement | Feature | Aspect | Status | Owner | Location |")
+	fmt.Fprintln(w, "|---|---|---|---|---|---|")
+	for _, id := range sortedReqIDs(tokens) {
+		for _, t := range tokensOf(tokens, id) {
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s:%d |\n",
+				t.ReqID, t.Feature, t.Aspect, t.Status, t.Owner, t.FilePath, t.LineNumber)
+		}
+	}
+	return nil
+}
+
+func sortedReqIDs(tokens []*storage.Token) []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, t := range tokens {
+		if !seen[t.ReqID] {
+			seen[t.ReqID] = true
+			ids = append(ids, t.ReqID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// tokensOf returns the tokens of reqID ordered by feature, then location
+func tokensOf(tokens []*storage.Token, reqID string) []*storage.Token {
+	var out []*storage.Token
+	for _, t := range tokens {
+		if t.ReqID == reqID {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Feature != out[j].Feature {
+			return out[i].Feature < out[j].Feature
+		}
+		if out[i].FilePath != out[j].FilePath {
+			return out[i].FilePath < out[j].FilePath
+		}
+		return out[i].LineNumber < out[j].LineNumber
+	})
+	return out
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func init() {
+	checkpointCmd.AddCommand(checkpointListCmd)
+	checkpointCmd.AddCommand(checkpointShowCmd)
+	checkpointCmd.AddCommand(checkpointDiffCmd)
+
+	checkpointListCmd.Flags().String("format", "text", "output format: text or json")
+	checkpointShowCmd.Flags().String("format", "text", "output format: text, json or markdown")
+	checkpointDiffCmd.Flags().String("format", "text", "output format: text, json or markdown")
+}
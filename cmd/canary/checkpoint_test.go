@@ -0,0 +1,124 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+// TestCANARY_CBIN_173_CLI_CheckpointDiff verifies a checkpoint's snapshot
+// reads back by name and the diff against a later state lists status
+// transitions, added and removed features and owner changes per
+// requirement.
+func TestCANARY_CBIN_173_CLI_CheckpointDiff(t *testing.T) {
+	tok := func(req, feature, aspect, status, owner, file string) *storage.Token {
+		return &storage.Token{ReqID: req, Feature: feature, Aspect: aspect, Status: status, Owner: owner, FilePath: file, LineNumber: 1}
+	}
+	v1 := []*storage.Token{
+		tok("CBIN-100", "Parse", "Engine", "IMPL", "ana", "parse.go"),
+		tok("CBIN-100", "Parse", "Engine", "TESTED", "", "parse_extra.go"),
+		tok("CBIN-100", "Lex", "Engine", "STUB", "", "lex.go"),
+		tok("CBIN-101", "Export", "API", "TESTED", "bo", "export.go"),
+		tok("CBIN-102", "Legacy", "CLI", "IMPL", "", "legacy.go"),
+	}
+	head := []*storage.Token{
+		tok("CBIN-100", "Parse", "Engine", "TESTED", "cy", "parse.go"),
+		tok("CBIN-100", "Parse", "Engine", "TESTED", "", "parse_extra.go"),
+		tok("CBIN-100", "Lex", "Engine", "IMPL", "", "lex.go"),
+		tok("CBIN-100", "Format", "CLI", "STUB", "", "format.go"),
+		tok("CBIN-101", "Export", "API", "TESTED", "bo", "export.go"),
+		tok("CBIN-103", "Import", "API", "IMPL", "", "import.go"),
+	}
+
+	// The snapshot stored by `canary checkpoint` reads back by name
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	snapshot, err := json.Marshal(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CreateCheckpoint("v1", "first release", "0123456789ab", string(snapshot)); err != nil {
+		t.Fatal(err)
+	}
+	ref, from, err := loadCheckpoint(db, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Name != "v1" || ref.CommitHash != "0123456789ab" || len(from) != len(v1) {
+		t.Fatalf("loadCheckpoint(v1) = %+v with %d tokens", ref, len(from))
+	}
+	if _, _, err := loadCheckpoint(db, "v2"); err == nil {
+		t.Error("loadCheckpoint(v2) succeeded for a missing checkpoint")
+	}
+
+	diff := diffCheckpoints(from, head, nil)
+	diff.From, diff.To = ref, checkpointRef{Name: "HEAD"}
+
+	want := []requirementDiff{
+		{
+			ReqID: "CBIN-100", FromStatus: "STUB", ToStatus: "STUB",
+			Added:         []featureState{{Feature: "Format", Aspect: "CLI", Status: "STUB"}},
+			StatusChanges: []featureChange{{"Lex", "Engine", "STUB", "IMPL"}, {"Parse", "Engine", "IMPL", "TESTED"}},
+			OwnerChanges:  []featureChange{{"Parse", "Engine", "ana", "cy"}},
+		},
+		{ReqID: "CBIN-102", FromStatus: "IMPL", Removed: []featureState{{Feature: "Legacy", Aspect: "CLI", Status: "IMPL"}}},
+		{ReqID: "CBIN-103", ToStatus: "IMPL", Added: []featureState{{Feature: "Import", Aspect: "API", Status: "IMPL"}}},
+	}
+	if !reflect.DeepEqual(diff.Requirements, want) {
+		t.Errorf("diff = %+v\nwant %+v", diff.Requirements, want)
+	}
+
+	var text bytes.Buffer
+	if err := writeDiffText(&text, diff); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"Checkpoint diff: v1 (01234567, ",
+		"CBIN-100: STUB\n  + Format [CLI] STUB\n  ~ Lex [Engine] status STUB → IMPL\n",
+		"  ~ Parse [Engine] owner ana → cy\n",
+		"CBIN-102: removed, was IMPL\n  - Legacy [CLI] IMPL\n",
+		"CBIN-103: new, IMPL\n",
+		"3 requirements changed: 2 features added, 1 removed, 2 status changes, 1 owner change\n",
+	} {
+		if !strings.Contains(text.String(), line) {
+			t.Errorf("text diff missing %q:\n%s", line, text.String())
+		}
+	}
+
+	var md bytes.Buffer
+	if err := writeDiffMarkdown(&md, diff); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"## Changes from v1 (01234567, ",
+		"### CBIN-100: STUB\n\n- Added **Format** (CLI, STUB)\n",
+		"- **Parse** (Engine): IMPL → TESTED\n",
+		"- Removed **Legacy** (CLI, was IMPL)\n",
+	} {
+		if !strings.Contains(md.String(), line) {
+			t.Errorf("markdown diff missing %q:\n%s", line, md.String())
+		}
+	}
+
+	// Identical states differ in nothing
+	if d := diffCheckpoints(head, head, nil); len(d.Requirements) != 0 {
+		t.Errorf("diff of identical states = %+v", d.Requirements)
+	}
+}
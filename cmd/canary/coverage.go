@@ -0,0 +1,293 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/config"
+	"go.devnw.com/canary/internal/coverage"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/policy"
+	"go.devnw.com/canary/internal/scanner"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// CANARY: REQ=CBIN-166; FEATURE="CoverageCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2026-10-17
+var coverageCmd = &cobra.Command{
+	Use:   "coverage --profile <file> [flags]",
+	Short: "Measure test coverage of the code each token annotates",
+	Long: `Coverage reads line coverage and measures it for the function or other
+declaration each indexed CANARY token annotates, rolled up per feature and
+per requirement.
+
+Supported reports, detected from their contents:
+  Go          go test -coverprofile=coverage.out ./...
+  LCOV        lcov.info from c8, nyc, Jest, grcov, cargo-llvm-cov...
+  Cobertura   coverage.xml from coverage.py, Istanbul, gcovr...
+
+The result replaces the coverage stored in the database, where 'canary
+status' shows it. Run 'canary index' first so tokens know their code.
+
+Completed features (TESTED, BENCHED or whatever satisfies the project
+lifecycle) must reach verification.min_coverage from .canary/project.yaml,
+which can be overridden per aspect and per requirement, or --min when
+given. A completed feature whose code the profile has no data for at all,
+for example because the profile is stale or covers other packages, falls
+short too. The command exits 2 when any feature falls short:
+
+  verification:
+    min_coverage: 80
+    aspects:
+      Docs: {min_coverage: 0}
+
+Examples:
+  go test -coverprofile=coverage.out ./... && canary coverage --profile coverage.out
+  canary coverage --profile lcov.info --min 70
+  canary coverage --profile coverage.xml --format json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profiles, _ := cmd.Flags().GetStringSlice("profile")
+		dbPath, _ := cmd.Flags().GetString("db")
+		format, _ := cmd.Flags().GetString("format")
+		if len(profiles) == 0 {
+			return fmt.Errorf("--profile is required")
+		}
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q (want text or json)", format)
+		}
+
+		prof := coverage.New()
+		for _, path := range profiles {
+			p, err := coverage.Read(path)
+			if err != nil {
+				return err
+			}
+			prof.Merge(p)
+		}
+
+		db, err := storage.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+
+		tokens, err := db.ListTokens(map[string]string{"include_hidden": "true"}, "", "", 0)
+		if err != nil {
+			return fmt.Errorf("query tokens: %w", err)
+		}
+		cfg, err := config.Load(".")
+		if err != nil {
+			return err
+		}
+		lc, err := cfg.StatusLifecycle()
+		if err != nil {
+			return err
+		}
+		if err := applyTestResults(db, tokens, lc); err != nil {
+			return fmt.Errorf("load test results: %w", err)
+		}
+
+		var rows []storage.TokenCoverage
+		var unmeasured []*storage.Token
+		for _, t := range tokens {
+			c := tokenCoverage(prof, t)
+			if c.Total == 0 {
+				if t.SpanStart != 0 && !prof.Has(t.FilePath) {
+					unmeasured = append(unmeasured, t)
+				}
+				continue
+			}
+			t.CoveredLines, t.CoverableLines = c.Covered, c.Total
+			rows = append(rows, storage.TokenCoverage{
+				ReqID: t.ReqID, Feature: t.Feature, FilePath: t.FilePath, LineNumber: t.LineNumber,
+				Covered: c.Covered, Total: c.Total, Source: strings.Join(profiles, ","),
+			})
+		}
+		if err := db.ReplaceCoverage("", rows); err != nil {
+			return err
+		}
+
+		pol := cfg.Policy()
+		if cmd.Flags().Changed("min") {
+			minCoverage, _ := cmd.Flags().GetFloat64("min")
+			pol = &policy.Policy{Project: policy.Rule{MinCoverage: &minCoverage}}
+		}
+
+		reqs := coverageByRequirement(tokens)
+		shortfalls := coverageShortfalls(reqs, unmeasured, pol, lc)
+		out := cmd.OutOrStdout()
+		if format == "json" {
+			err = writeCoverageJSON(out, reqs, shortfalls)
+		} else {
+			err = writeCoverageText(out, reqs, shortfalls, len(rows))
+		}
+		if err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+
+		if len(shortfalls) > 0 {
+			return &scanner.ExitError{Code: scanner.ExitVerifyFail, Err: fmt.Errorf("%d features below the minimum coverage", len(shortfalls))}
+		}
+		return nil
+	},
+}
+
+func init() {
+	coverageCmd.Flags().StringSlice("profile", nil, "Go cover profile, LCOV or Cobertura XML report (repeatable)")
+	coverageCmd.Flags().Float64("min", 0, "minimum line coverage percentage for completed features (overrides project.yaml)")
+	coverageCmd.Flags().String("format", "text", "output format: text or json")
+	coverageCmd.Flags().String("db", ".canary/canary.db", "path to database file")
+}
+
+// tokenCoverage measures the declaration a token annotates, without the
+// token's own comment lines.
+func tokenCoverage(prof *coverage.Profile, t *storage.Token) coverage.Counts {
+	if t.SpanStart == 0 {
+		return coverage.Counts{}
+	}
+	end := t.LineNumber + strings.Count(t.RawToken, "\n")
+	return prof.Span(t.FilePath, t.SpanStart, t.SpanEnd, t.LineNumber, end)
+}
+
+// featureCoverage is the coverage of one feature summed over its tokens.
+type featureCoverage struct {
+	Feature  string          `json:"feature"`
+	Aspect   string          `json:"aspect"`
+	Status   string          `json:"status"`
+	Coverage coverage.Counts `json:"coverage"`
+}
+
+// requirementCoverage is the coverage of a requirement and its features.
+type requirementCoverage struct {
+	ReqID    string             `json:"req_id"`
+	Coverage coverage.Counts    `json:"coverage"`
+	Features []*featureCoverage `json:"features"`
+}
+
+// coverageByRequirement rolls token coverage up per feature and
+// requirement, sorted by ID and feature. Only tokens with recorded coverage
+// count.
+func coverageByRequirement(tokens []*storage.Token) []*requirementCoverage {
+	byReq := map[string]*requirementCoverage{}
+	byFeature := map[[3]string]*featureCoverage{}
+	for _, t := range tokens {
+		if t.CoverableLines == 0 {
+			continue
+		}
+		c := coverage.Counts{Covered: t.CoveredLines, Total: t.CoverableLines}
+		r := byReq[t.ReqID]
+		if r == nil {
+			r = &requirementCoverage{ReqID: t.ReqID}
+			byReq[t.ReqID] = r
+		}
+		r.Coverage = r.Coverage.Add(c)
+		k := [3]string{t.ReqID, t.Feature, t.Aspect}
+		f := byFeature[k]
+		if f == nil {
+			f = &featureCoverage{Feature: t.Feature, Aspect: t.Aspect, Status: effectiveStatus(t)}
+			byFeature[k] = f
+			r.Features = append(r.Features, f)
+		}
+		f.Coverage = f.Coverage.Add(c)
+	}
+
+	out := make([]*requirementCoverage, 0, len(byReq))
+	for _, r := range byReq {
+		sort.Slice(r.Features, func(i, j int) bool {
+			return r.Features[i].Feature+r.Features[i].Aspect < r.Features[j].Feature+r.Features[j].Aspect
+		})
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ReqID < out[j].ReqID })
+	return out
+}
+
+// coverageShortfall is a completed feature below its minimum coverage.
+type coverageShortfall struct {
+	ReqID   string  `json:"req_id"`
+	Feature string  `json:"feature"`
+	Aspect  string  `json:"aspect"`
+	Percent float64 `json:"percent"`
+	Min     float64 `json:"min"`
+	Scope   string  `json:"scope"` // where min_coverage was set
+	// NoData names a file of the feature's code the profile has no data
+	// for
+	NoData string `json:"no_data,omitempty"`
+}
+
+// coverageShortfalls returns the features of reqs completed under lc that
+// are covered less than the policy requires, and those with unmeasured
+// tokens, whose code is in files the profile has no data for.
+func coverageShortfalls(reqs []*requirementCoverage, unmeasured []*storage.Token, pol *policy.Policy, lc *lifecycle.Lifecycle) []coverageShortfall {
+	var out []coverageShortfall
+	add := func(s coverageShortfall, status string) {
+		rule := pol.For(s.ReqID, s.Aspect)
+		if rule.MinCoverage <= 0 || !lc.Satisfies(status, s.Aspect) {
+			return
+		}
+		if s.NoData == "" && s.Percent >= rule.MinCoverage {
+			return
+		}
+		s.Min, s.Scope = rule.MinCoverage, rule.Scope[policy.RuleCoverage]
+		if s.Scope == "" {
+			s.Scope = "--min"
+		}
+		out = append(out, s)
+	}
+	for _, r := range reqs {
+		for _, f := range r.Features {
+			add(coverageShortfall{ReqID: r.ReqID, Feature: f.Feature, Aspect: f.Aspect, Percent: f.Coverage.Percent()}, f.Status)
+		}
+	}
+	seen := map[[3]string]bool{}
+	for _, t := range unmeasured {
+		if k := [3]string{t.ReqID, t.Feature, t.Aspect}; !seen[k] {
+			seen[k] = true
+			add(coverageShortfall{ReqID: t.ReqID, Feature: t.Feature, Aspect: t.Aspect, NoData: t.FilePath}, effectiveStatus(t))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ReqID < out[j].ReqID })
+	return out
+}
+
+func writeCoverageText(w io.Writer, reqs []*requirementCoverage, shortfalls []coverageShortfall, measured int) error {
+	if len(reqs) == 0 {
+		fmt.Fprintln(w, "No token covers instrumented code; run 'canary index' and check the profile paths")
+	}
+	for _, r := range reqs {
+		fmt.Fprintf(w, "%s  %s\n", r.ReqID, r.Coverage)
+		for _, f := range r.Features {
+			fmt.Fprintf(w, "  %-30s %-8s %-8s %s\n", f.Feature, f.Aspect, f.Status, f.Coverage)
+		}
+	}
+	fmt.Fprintln(w)
+	for _, s := range shortfalls {
+		if s.NoData != "" {
+			fmt.Fprintf(w, "%s %q %s: no coverage data for %s, want %.1f%% (%s)\n", s.ReqID, s.Feature, s.Aspect, s.NoData, s.Min, s.Scope)
+			continue
+		}
+		fmt.Fprintf(w, "%s %q %s: coverage %.1f%% is below %.1f%% (%s)\n", s.ReqID, s.Feature, s.Aspect, s.Percent, s.Min, s.Scope)
+	}
+	_, err := fmt.Fprintf(w, "%d tokens measured across %d requirements: %d features below the minimum coverage\n", measured, len(reqs), len(shortfalls))
+	return err
+}
+
+func writeCoverageJSON(w io.Writer, reqs []*requirementCoverage, shortfalls []coverageShortfall) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Requirements []*requirementCoverage `json:"requirements"`
+		Shortfalls   []coverageShortfall    `json:"shortfalls"`
+	}{reqs, shortfalls})
+}
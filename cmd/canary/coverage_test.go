@@ -0,0 +1,57 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.devnw.com/canary/internal/policy"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// TestCANARY_CBIN_166_CLI_CoverageShortfalls verifies completed features
+// fall short of min_coverage both when covered too little and when the
+// profile has no data for their code.
+func TestCANARY_CBIN_166_CLI_CoverageShortfalls(t *testing.T) {
+	measured := []*storage.Token{
+		{ReqID: "CBIN-330", Feature: "Parse", Aspect: "Engine", Status: "TESTED", CoveredLines: 3, CoverableLines: 5},
+		{ReqID: "CBIN-330", Feature: "Lex", Aspect: "Engine", Status: "TESTED", CoveredLines: 5, CoverableLines: 5},
+	}
+	unmeasured := []*storage.Token{
+		{ReqID: "CBIN-331", Feature: "Export", Aspect: "API", Status: "TESTED", FilePath: "export/export.go", SpanStart: 4},
+		{ReqID: "CBIN-331", Feature: "Export", Aspect: "API", Status: "TESTED", FilePath: "export/csv.go", SpanStart: 9},
+		{ReqID: "CBIN-331", Feature: "Draft", Aspect: "API", Status: "IMPL", FilePath: "export/draft.go", SpanStart: 2},
+	}
+	minCoverage := 80.0
+	pol := &policy.Policy{Project: policy.Rule{MinCoverage: &minCoverage}}
+
+	reqs := coverageByRequirement(measured)
+	shortfalls := coverageShortfalls(reqs, unmeasured, pol, nil)
+	if len(shortfalls) != 2 {
+		t.Fatalf("shortfalls = %+v, want Parse and Export", shortfalls)
+	}
+	if s := shortfalls[0]; s.Feature != "Parse" || s.Percent != 60 || s.NoData != "" {
+		t.Errorf("shortfalls[0] = %+v", s)
+	}
+	if s := shortfalls[1]; s.Feature != "Export" || s.NoData != "export/export.go" || s.Min != 80 {
+		t.Errorf("shortfalls[1] = %+v", s)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCoverageText(&buf, reqs, shortfalls, len(measured)); err != nil {
+		t.Fatal(err)
+	}
+	if want := `CBIN-331 "Export" API: no coverage data for export/export.go, want 80.0% (project)`; !strings.Contains(buf.String(), want) {
+		t.Errorf("report missing %q:\n%s", want, buf.String())
+	}
+
+	// Without a minimum nothing falls short
+	if got := coverageShortfalls(reqs, unmeasured, &policy.Policy{}, nil); len(got) != 0 {
+		t.Errorf("shortfalls without min_coverage = %+v", got)
+	}
+}
@@ -53,22 +53,19 @@ This command loads the requirement's dependencies and checks their status
 against the CANARY token database. Only TESTED and BENCHED status satisfy
 dependencies - IMPL is insufficient.
 
+Once 'canary index' has run, dependencies come from the requirement graph
+it stores: spec.md Dependencies sections together with the DEPENDS_ON and
+BLOCKS fields of tokens. Without an index, spec.md files are read directly.
+
 Example:
   canary deps check CBIN-147`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			reqID := args[0]
 
-			// Find spec file
-			specPath, err := findSpecFile(reqID)
-			if err != nil {
-				return fmt.Errorf("failed to find spec for %s: %w", reqID, err)
-			}
-
-			// Parse dependencies
-			deps, err := specs.ParseDependenciesFromFile(reqID, specPath)
+			deps, err := loadDirectDependencies(reqID)
 			if err != nil {
-				return fmt.Errorf("failed to parse dependencies: %w", err)
+				return err
 			}
 
 			if len(deps) == 0 {
@@ -83,7 +80,11 @@ Example:
 			}
 
 			// Check dependency status
-			checker := specs.NewStatusChecker(tokenProvider)
+			lc, err := projectLifecycle(".")
+			if err != nil {
+				return err
+			}
+			checker := specs.NewStatusChecker(tokenProvider, lc)
 			statuses := checker.CheckAllDependencies(deps)
 
 			// Display results
@@ -146,8 +147,7 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			reqID := args[0]
 
-			// Build graph from all specs
-			graph, err := buildDependencyGraph()
+			graph, err := loadDependencyGraph(reqID)
 			if err != nil {
 				return fmt.Errorf("failed to build dependency graph: %w", err)
 			}
@@ -157,10 +157,14 @@ Example:
 
 			// Add status checker if requested
 			if showStatus {
+				lc, err := projectLifecycle(".")
+				if err != nil {
+					return err
+				}
 				tokenProvider, err := createTokenProvider()
 				if err == nil {
 					statusChecker := &dependencyStatusAdapter{
-						checker: specs.NewStatusChecker(tokenProvider),
+						checker: specs.NewStatusChecker(tokenProvider, lc),
 					}
 					generator.SetStatusChecker(statusChecker)
 				}
@@ -201,15 +205,11 @@ Example:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			reqID := args[0]
 
-			// Build graph from all specs
-			graph, err := buildDependencyGraph()
+			reverseDeps, err := loadDependents(reqID)
 			if err != nil {
 				return fmt.Errorf("failed to build dependency graph: %w", err)
 			}
 
-			// Get reverse dependencies
-			reverseDeps := graph.GetReverseDependencies(reqID)
-
 			if len(reverseDeps) == 0 {
 				cmd.Println(fmt.Sprintf("No requirements depend on %s", reqID))
 				return nil
@@ -261,8 +261,7 @@ Example:
   canary deps validate`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Build graph from all specs
-			graph, err := buildDependencyGraph()
+			graph, err := loadDependencyGraph("")
 			if err != nil {
 				return fmt.Errorf("failed to build dependency graph: %w", err)
 			}
@@ -270,8 +269,13 @@ Example:
 			// Create validator
 			validator := specs.NewDependencyValidator(graph)
 
-			// Add spec finder to check for missing requirements
+			// Add spec finder to check for missing requirements; with an
+			// index, requirements that only have tokens exist too
 			specFinder := &filesystemSpecFinder{}
+			if db := openRequirementGraph(); db != nil {
+				defer db.Close()
+				specFinder.db = db
+			}
 			validator.SetSpecFinder(specFinder)
 
 			// Validate
@@ -324,6 +328,127 @@ func findSpecFile(reqID string) (string, error) {
 	return "", fmt.Errorf("spec file not found for %s", reqID)
 }
 
+// CANARY: REQ=CBIN-169; FEATURE="GraphQueries"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_169_CLI_RequirementGraph; OWNER=canary; UPDATED=2026-10-17
+
+// projectDBPath is the project-local database the indexer fills
+const projectDBPath = ".canary/canary.db"
+
+// openRequirementGraph opens the project database when it exists and is
+// migrated, so its requirement_edges table can be queried. It returns nil
+// otherwise and callers fall back to reading spec.md files.
+func openRequirementGraph() *storage.DB {
+	if _, err := os.Stat(projectDBPath); err != nil {
+		return nil
+	}
+	if needs, _, err := storage.NeedsMigration(projectDBPath); err != nil || needs {
+		return nil
+	}
+	db, err := storage.Open(projectDBPath)
+	if err != nil {
+		return nil
+	}
+	return db
+}
+
+// edgeDependency converts a stored dependency edge to the specs model
+func edgeDependency(e storage.RequirementEdge) specs.Dependency {
+	dep := specs.Dependency{
+		Source:      e.Source,
+		Target:      e.Target,
+		Type:        specs.DependencyTypeFull,
+		Description: e.Description,
+	}
+	switch {
+	case len(e.RequiredFeatures) > 0:
+		dep.Type = specs.DependencyTypePartialFeatures
+		dep.RequiredFeatures = e.RequiredFeatures
+	case e.RequiredAspect != "":
+		dep.Type = specs.DependencyTypePartialAspect
+		dep.RequiredAspect = e.RequiredAspect
+	}
+	return dep
+}
+
+// loadDirectDependencies returns the requirements reqID directly depends on
+func loadDirectDependencies(reqID string) ([]specs.Dependency, error) {
+	if db := openRequirementGraph(); db != nil {
+		defer db.Close()
+		edges, err := db.Dependencies("", reqID)
+		if err != nil {
+			return nil, err
+		}
+		var deps []specs.Dependency
+		for _, e := range edges {
+			if e.Depth == 1 {
+				deps = append(deps, edgeDependency(e))
+			}
+		}
+		return deps, nil
+	}
+
+	specPath, err := findSpecFile(reqID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find spec for %s: %w", reqID, err)
+	}
+	deps, err := specs.ParseDependenciesFromFile(reqID, specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dependencies: %w", err)
+	}
+	return deps, nil
+}
+
+// loadDependencyGraph returns the dependencies reachable from reqID, or
+// every dependency when reqID is empty
+func loadDependencyGraph(reqID string) (*specs.DependencyGraph, error) {
+	db := openRequirementGraph()
+	if db == nil {
+		return buildDependencyGraph()
+	}
+	defer db.Close()
+
+	var edges []storage.RequirementEdge
+	var err error
+	if reqID == "" {
+		edges, err = db.DependencyEdges("")
+	} else {
+		edges, err = db.Dependencies("", reqID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	graph := specs.NewDependencyGraph()
+	for _, e := range edges {
+		graph.AddDependency(edgeDependency(e))
+	}
+	return graph, nil
+}
+
+// loadDependents returns the requirements that directly depend on reqID
+func loadDependents(reqID string) ([]specs.Dependency, error) {
+	db := openRequirementGraph()
+	if db == nil {
+		graph, err := buildDependencyGraph()
+		if err != nil {
+			return nil, err
+		}
+		return graph.GetReverseDependencies(reqID), nil
+	}
+	defer db.Close()
+
+	edges, err := db.Dependents("", reqID)
+	if err != nil {
+		return nil, err
+	}
+	var deps []specs.Dependency
+	for _, e := range edges {
+		if e.Depth == 1 {
+			deps = append(deps, edgeDependency(e))
+		}
+	}
+	return deps, nil
+}
+
 // buildDependencyGraph builds the complete dependency graph from all specs
 func buildDependencyGraph() (*specs.DependencyGraph, error) {
 	graph := specs.NewDependencyGraph()
@@ -412,12 +537,21 @@ func (a *dependencyStatusAdapter) IsDependencySatisfied(dep specs.Dependency) bo
 	return status.IsSatisfied
 }
 
-// filesystemSpecFinder implements SpecFinder using filesystem
-type filesystemSpecFinder struct{}
+// filesystemSpecFinder implements SpecFinder using filesystem, and counts
+// requirements with indexed tokens as existing when db is set
+type filesystemSpecFinder struct {
+	db *storage.DB
+}
 
 func (f *filesystemSpecFinder) SpecExists(reqID string) bool {
-	_, err := findSpecFile(reqID)
-	return err == nil
+	if _, err := findSpecFile(reqID); err == nil {
+		return true
+	}
+	if f.db == nil {
+		return false
+	}
+	tokens, err := f.db.GetTokensByReqID(reqID)
+	return err == nil && len(tokens) > 0
 }
 
 func (f *filesystemSpecFinder) FindSpecPath(reqID string) (string, error) {
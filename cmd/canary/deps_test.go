@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.devnw.com/canary/internal/storage"
 )
 
 // CANARY: REQ=CBIN-147; FEATURE="DepsCheckCommand"; ASPECT=CLI; STATUS=TESTED; TEST=TestDepsCheckCommand; UPDATED=2025-10-18
@@ -214,3 +215,47 @@ func TestDepsParentCommand(t *testing.T) {
 	subcommands := cmd.Commands()
 	assert.GreaterOrEqual(t, len(subcommands), 4) // check, graph, reverse, validate
 }
+
+func TestCANARY_CBIN_169_CLI_RequirementGraph(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(tmpDir))
+
+	require.NoError(t, os.MkdirAll(".canary", 0o755))
+	require.NoError(t, storage.AutoMigrate(projectDBPath))
+	db, err := storage.Open(projectDBPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// CBIN-401 needs CBIN-402, which is done but needs unfinished CBIN-403
+	for _, tok := range []*storage.Token{
+		{ReqID: "CBIN-401", Feature: "Top", Aspect: "API", Status: "STUB", Priority: 1, FilePath: "a.go", LineNumber: 1, DependsOn: "CBIN-402"},
+		{ReqID: "CBIN-402", Feature: "Middle", Aspect: "API", Status: "TESTED", Priority: 2, FilePath: "b.go", LineNumber: 1},
+		{ReqID: "CBIN-403", Feature: "Bottom", Aspect: "API", Status: "STUB", Priority: 3, FilePath: "c.go", LineNumber: 1, Blocks: "CBIN-402"},
+	} {
+		require.NoError(t, db.UpsertToken(tok))
+	}
+
+	// next skips CBIN-401, blocked through CBIN-402
+	selected, err := selectNextPriority(projectDBPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, selected)
+	assert.Equal(t, "CBIN-403", selected.ReqID)
+
+	var buf bytes.Buffer
+	cmd := createDepsGraphCommand()
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"CBIN-401"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), "CBIN-402")
+	assert.Contains(t, buf.String(), "CBIN-403")
+
+	buf.Reset()
+	cmd = createDepsReverseCommand()
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"CBIN-402"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), "CBIN-401")
+	assert.NotContains(t, buf.String(), "CBIN-403 ")
+}
@@ -21,7 +21,8 @@ var filesCmd = &cobra.Command{
 	Long: `Files lists all implementation files containing tokens for a requirement.
 
 By default, excludes spec and template files, showing only actual implementation.
-Files are grouped by aspect and show token counts.
+Files are grouped by aspect and show token counts and the functions or other
+declarations their tokens annotate.
 
 Examples:
   canary files CBIN-133
@@ -105,6 +106,11 @@ func formatFilesList(fileGroups map[string][]*storage.Token) {
 				plural = "tokens"
 			}
 			fmt.Printf("  %s (%d %s)\n", file, count, plural)
+			for _, token := range fileGroups[file] {
+				if token.Aspect == aspect && token.Symbol != "" {
+					fmt.Printf("    %s %s (lines %d-%d): %s\n", token.SymbolKind, token.Symbol, token.SpanStart, token.SpanEnd, token.Feature)
+				}
+			}
 		}
 		fmt.Println()
 	}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/scanner"
+	"go.devnw.com/canary/internal/token"
+)
+
+// CANARY: REQ=CBIN-157; FEATURE="FmtCmd"; ASPECT=CLI; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_157_CLI_FmtCheck
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [path...]",
+	Short: "Rewrite CANARY tokens in canonical form",
+	Long: `Rewrite every CANARY token under the given paths (default ".") in place.
+
+Fields are put in canonical order (REQ, FEATURE, ASPECT, STATUS, evidence,
+ownership, dependencies, docs, then dates), FEATURE is double-quoted, ASPECT
+uses its canonical casing and STATUS is upper-cased. A missing UPDATED field
+is set to today. Indentation, comment markers and closing delimiters are kept.
+
+Tokens that span several lines are rewritten with one CANARY+: line per field
+group. Tokens with other errors are left untouched and reported.
+
+Use --check in CI: nothing is written, the files that need formatting are
+listed, and the command exits non-zero if there are any.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		check, _ := cmd.Flags().GetBool("check")
+		if len(args) == 0 {
+			args = []string{"."}
+		}
+
+		res, err := formatPaths(args, check, time.Now().UTC().Format("2006-01-02"), cmd.ErrOrStderr())
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return &scanner.ExitError{Code: scanner.ExitParseError, Err: err}
+		}
+
+		out := cmd.OutOrStdout()
+		for _, f := range res.files {
+			fmt.Fprintln(out, f)
+		}
+		if check {
+			if len(res.files) > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%d file(s) need formatting; run 'canary fmt'\n", len(res.files))
+				return &scanner.ExitError{Code: scanner.ExitVerifyFail}
+			}
+			return nil
+		}
+		if len(res.files) > 0 {
+			fmt.Fprintf(out, "✅ Formatted %d tokens in %d files\n", res.tokens, len(res.files))
+		}
+		return nil
+	},
+}
+
+func init() {
+	fmtCmd.Flags().Bool("check", false, "list files that need formatting and exit non-zero instead of rewriting")
+}
+
+type fmtResult struct {
+	files  []string // files that changed (or would change)
+	tokens int      // tokens rewritten
+}
+
+// formatPaths formats every file under paths, honouring the index ignore
+// rules and status lifecycle of directories, and those of the working
+// directory for files. Tokens that can't be formatted are reported to
+// stderr as warnings.
+func formatPaths(paths []string, check bool, today string, stderr io.Writer) (fmtResult, error) {
+	var res fmtResult
+
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return res, err
+		}
+
+		root, files := ".", []string{p}
+		if info.IsDir() {
+			root = p
+			files, err = indexer.Files(indexer.Options{Root: p, ExcludePaths: excludePaths(p)})
+			if err != nil {
+				return res, err
+			}
+		}
+		lc, err := projectLifecycle(root)
+		if err != nil {
+			return res, err
+		}
+
+		for _, f := range files {
+			src, err := os.ReadFile(f)
+			if err != nil {
+				return res, err
+			}
+			if indexer.IsBinary(src) || !bytes.Contains(src, []byte("CANARY:")) {
+				continue
+			}
+
+			out, changed, diags := token.FormatSource(f, src, today, lc)
+			for _, d := range diags {
+				fmt.Fprintf(stderr, "Warning: leaving token unformatted at %v\n", d)
+			}
+			if len(changed) == 0 {
+				continue
+			}
+
+			res.files = append(res.files, f)
+			res.tokens += len(changed)
+			if check {
+				continue
+			}
+
+			fi, err := os.Stat(f)
+			if err != nil {
+				return res, err
+			}
+			if err := os.WriteFile(f, out, fi.Mode().Perm()); err != nil {
+				return res, fmt.Errorf("write %s: %w", f, err)
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCANARY_CBIN_157_CLI_FmtCheck(t *testing.T) {
+	dir := t.TempDir()
+	messy := "package a\n\n// CANARY: STATUS=impl; REQ=CBIN-200; FEATURE=A; ASPECT=api\nfunc A() {}\n"
+	clean := "package b\n\n// CANARY: REQ=CBIN-201; FEATURE=\"B\"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15\nfunc B() {}\n"
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(messy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.go"), []byte(clean), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// --check reports without writing
+	var stderr bytes.Buffer
+	res, err := formatPaths([]string{dir}, true, "2026-01-02", &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.files) != 1 || filepath.Base(res.files[0]) != "a.go" {
+		t.Fatalf("check files = %v", res.files)
+	}
+	if b, _ := os.ReadFile(filepath.Join(dir, "a.go")); string(b) != messy {
+		t.Error("check mode rewrote the file")
+	}
+
+	// Formatting rewrites only the messy file
+	res, err = formatPaths([]string{dir}, false, "2026-01-02", &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.tokens != 1 {
+		t.Errorf("formatted %d tokens, want 1", res.tokens)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "a.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `// CANARY: REQ=CBIN-200; FEATURE="A"; ASPECT=API; STATUS=IMPL; UPDATED=2026-01-02`
+	if !strings.Contains(string(b), want) {
+		t.Errorf("a.go = %s", b)
+	}
+
+	res, err = formatPaths([]string{dir}, true, "2026-01-02", &stderr)
+	if err != nil || len(res.files) != 0 {
+		t.Errorf("second check = %v, %v", res.files, err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("unexpected warnings: %s", stderr.String())
+	}
+}
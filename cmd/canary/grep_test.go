@@ -6,9 +6,11 @@
 package main
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
+	"go.devnw.com/canary/internal/indexer"
 	"go.devnw.com/canary/internal/storage"
 )
 
@@ -200,3 +202,45 @@ func TestCANARY_CBIN_CLI_001_CLI_GrepCmd_EmptyPattern(t *testing.T) {
 		t.Errorf("empty pattern should return 0 results, got %d", len(tokens))
 	}
 }
+
+func TestCANARY_CBIN_CLI_001_CLI_GrepCmd_ContinuedToken(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	src := `package auth
+
+// CANARY: REQ=CBIN-TEST; FEATURE="Login"; ASPECT=API; STATUS=TESTED; UPDATED=2025-10-16
+// CANARY+: TEST=TestLoginFlow
+func Login() {}
+`
+	root := filepath.Join(tmpDir, "src")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "auth.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := indexer.Sync(db, indexer.SyncOptions{Options: indexer.Options{Root: root}}); err != nil {
+		t.Fatalf("index failed: %v", err)
+	}
+
+	// The test name lives on the continuation line
+	tokens, err := grepTokens(db, "TestLoginFlow")
+	if err != nil {
+		t.Fatalf("grepTokens failed: %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Fatalf("got %d matches, want 1", len(tokens))
+	}
+	if tokens[0].LineNumber != 3 {
+		t.Errorf("line = %d, want the starting line 3", tokens[0].LineNumber)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// CANARY: REQ=CBIN-172; FEATURE="HistoryCmd"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_172_CLI_HistoryTimeline; OWNER=canary; UPDATED=2026-10-17
+var historyCmd = &cobra.Command{
+	Use:   "history <REQ-ID>",
+	Short: "Show how a requirement's tokens changed over time",
+	Long: `History shows the timeline of a requirement's tokens as recorded by
+'canary index' and 'canary watch': when each token appeared or disappeared,
+moved, or changed status, owner or priority, with the commit and branch
+checked out at the time.
+
+Events are only recorded from the first index pass after upgrading; tokens
+indexed before then appear as of their last index pass.
+
+Examples:
+  canary history CBIN-133
+  canary history CBIN-133 --format json`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reqID := args[0]
+		dbPath, _ := cmd.Flags().GetString("db")
+		format, _ := cmd.Flags().GetString("format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q (want text or json)", format)
+		}
+
+		db, err := storage.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+
+		events, err := db.GetTokenEvents("", reqID)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return fmt.Errorf("no history recorded for %s; run 'canary index' first", reqID)
+		}
+
+		out := cmd.OutOrStdout()
+		if format == "json" {
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(events)
+		} else {
+			err = writeHistoryText(out, reqID, events)
+		}
+		if err != nil {
+			return fmt.Errorf("write history: %w", err)
+		}
+		return nil
+	},
+}
+
+// writeHistoryText prints events grouped by the index pass that recorded
+// them, oldest first
+func writeHistoryText(w io.Writer, reqID string, events []storage.TokenEvent) error {
+	fmt.Fprintf(w, "History of %s (%d events)\n", reqID, len(events))
+
+	var pass string
+	for _, e := range events {
+		if p := e.RecordedAt + e.CommitHash + e.Branch; p != pass {
+			pass = p
+			fmt.Fprintf(w, "\n%s  %s\n", historyTime(e.RecordedAt), historyCommit(e))
+		}
+		fmt.Fprintf(w, "  %s\n", describeEvent(e))
+	}
+
+	return nil
+}
+
+// describeEvent renders one event as a line of the timeline
+func describeEvent(e storage.TokenEvent) string {
+	token := fmt.Sprintf("%s [%s]", e.Feature, e.Aspect)
+	switch e.Kind {
+	case storage.EventAppear:
+		return fmt.Sprintf("+ %s appeared at %s:%d as %s", token, e.FilePath, e.LineNumber, e.NewValue)
+	case storage.EventDisappear:
+		return fmt.Sprintf("- %s disappeared from %s:%d (was %s)", token, e.FilePath, e.LineNumber, e.OldValue)
+	case storage.EventMove:
+		return fmt.Sprintf("→ %s moved %s → %s", token, e.OldValue, e.NewValue)
+	default:
+		return fmt.Sprintf("~ %s %s %s → %s", token, e.Kind, historyValue(e.OldValue), historyValue(e.NewValue))
+	}
+}
+
+func historyTime(recordedAt string) string {
+	t, err := time.Parse(time.RFC3339, recordedAt)
+	if err != nil {
+		return recordedAt
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func historyCommit(e storage.TokenEvent) string {
+	commit := "no commit"
+	if e.CommitHash != "" {
+		commit = shortHash(e.CommitHash)
+	}
+	if e.Branch != "" {
+		commit += " (" + e.Branch + ")"
+	}
+	return commit
+}
+
+func historyValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
+
+func init() {
+	historyCmd.Flags().String("db", ".canary/canary.db", "path to database file")
+	historyCmd.Flags().String("format", "text", "output format: text or json")
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// TestCANARY_CBIN_172_CLI_HistoryTimeline verifies index passes record a
+// token's status change and move with their commit, and the timeline lists
+// them per pass.
+func TestCANARY_CBIN_172_CLI_HistoryTimeline(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	index := func(commit, src string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(root, "parse.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		opts := indexer.SyncOptions{Options: indexer.Options{Root: root}, CommitHash: commit, Branch: "main", Full: true}
+		if _, err := indexer.Sync(db, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	index("1111111111aa", "package p\n\n// CANARY: REQ=CBIN-300; FEATURE=\"Parse\"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-01\nfunc Parse() {}\n")
+	index("2222222222bb", "package p\n\n// CANARY: REQ=CBIN-300; FEATURE=\"Parse\"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-01\nfunc Parse() {}\n")
+	index("3333333333cc", "package p\n\nimport \"fmt\"\n\n// CANARY: REQ=CBIN-300; FEATURE=\"Parse\"; ASPECT=Engine; STATUS=TESTED; TEST=TestParse; UPDATED=2026-10-02\nfunc Parse() { fmt.Println() }\n")
+
+	events, err := db.GetTokenEvents("", "CBIN-300")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeHistoryText(&buf, "CBIN-300", events); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	file := filepath.Join(root, "parse.go")
+	for _, want := range []string{
+		"History of CBIN-300 (3 events)",
+		"11111111 (main)\n  + Parse [Engine] appeared at " + file + ":3 as IMPL\n",
+		"33333333 (main)\n  → Parse [Engine] moved " + file + ":3 → " + file + ":5\n  ~ Parse [Engine] status IMPL → TESTED\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("history missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "22222222") {
+		t.Errorf("unchanged pass listed in history:\n%s", out)
+	}
+}
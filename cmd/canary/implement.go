@@ -9,7 +9,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -70,7 +69,12 @@ func findRequirement(query string) (*RequirementSpec, error) {
 		return loadSpecFromDir(matches[0])
 	}
 
-	// Attempt 3: Fuzzy match
+	// Attempt 3: Full-text search of what specs, plans, tokens and gaps say
+	if found, err := searchSpecs("implement", query, 1); err == nil && len(found) > 0 {
+		return loadSpecFromDir(found[0].Dir)
+	}
+
+	// Attempt 4: Fuzzy match on directory names
 	fuzzyMatches, err := matcher.FindBestMatches(query, specsDir, 5)
 	if err != nil {
 		return nil, fmt.Errorf("fuzzy search failed: %w", err)
@@ -194,7 +198,10 @@ func renderImplementPrompt(spec *RequirementSpec, flags *ImplementFlags) (string
 	constitutionContent, _ := os.ReadFile(constitutionPath)
 
 	// Calculate progress
-	progress, _ := calculateProgress(spec.ReqID)
+	progress, err := calculateProgress(spec.ReqID)
+	if err != nil {
+		return "", err
+	}
 
 	// Extract implementation checklist from spec
 	checklist := extractImplementationChecklist(spec.SpecContent)
@@ -223,38 +230,35 @@ func renderImplementPrompt(spec *RequirementSpec, flags *ImplementFlags) (string
 	return buf.String(), nil
 }
 
-// calculateProgress scans codebase for tokens matching reqID
+// calculateProgress counts the tokens for reqID found in the working tree
 func calculateProgress(reqID string) (*ProgressStats, error) {
-	// Use grep to find all tokens for this requirement
-	grepCmd := exec.Command("grep", "-rn", "--include=*.go", "--include=*.md",
-		fmt.Sprintf("CANARY:.*REQ=%s", reqID), ".")
-
-	output, err := grepCmd.CombinedOutput()
-	if err != nil && len(output) == 0 {
+	lc, err := projectLifecycle(".")
+	if err != nil {
+		return nil, err
+	}
+	toks, _, err := collectTokens(".")
+	if err != nil {
 		return &ProgressStats{}, nil // No tokens found
 	}
 
 	stats := &ProgressStats{}
-	lines := strings.Split(string(output), "\n")
-
-	for _, line := range lines {
-		if line == "" {
+	for _, tok := range toks {
+		if tok.ReqID() != reqID {
 			continue
 		}
-
-		status := extractField(line, "STATUS")
 		stats.Total++
 
-		switch status {
+		switch tok.Status() {
 		case "STUB":
 			stats.Stub++
 		case "IMPL":
 			stats.Impl++
 		case "TESTED":
 			stats.Tested++
-			stats.Completed++
 		case "BENCHED":
 			stats.Benched++
+		}
+		if lc.Satisfies(tok.Status(), tok.Aspect()) {
 			stats.Completed++
 		}
 	}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+// TestIndexCmd_SkipsDatabase verifies `canary index` doesn't index the
+// database it writes to when that lives under the indexed root.
+func TestIndexCmd_SkipsDatabase(t *testing.T) {
+	root := t.TempDir()
+	src := "package p\n\n// CANARY: REQ=CBIN-320; FEATURE=\"Parse\"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17\nfunc Parse() {}\n"
+	if err := os.WriteFile(filepath.Join(root, "parse.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".canary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dbPath := filepath.Join(root, ".canary", "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+
+	for flag, value := range map[string]string{"db": dbPath, "root": root} {
+		if err := indexCmd.Flags().Set(flag, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		indexCmd.Flags().Set("db", ".canary/canary.db") //nolint:errcheck // restoring defaults
+		indexCmd.Flags().Set("root", ".")               //nolint:errcheck // restoring defaults
+	})
+	for range 2 {
+		if err := indexCmd.RunE(indexCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	files, err := db.GetIndexedFiles("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := files[filepath.Join(root, "parse.go")]; !ok || len(files) != 1 {
+		t.Errorf("indexed files = %v, want only parse.go", keysOf(files))
+	}
+}
+
+func keysOf(m map[string]*storage.IndexedFile) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/lint"
+	"go.devnw.com/canary/internal/scanner"
+)
+
+// CANARY: REQ=CBIN-158; FEATURE="LintCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2026-10-17
+var lintCmd = &cobra.Command{
+	Use:   "lint [flags]",
+	Short: "Report every problem with CANARY tokens",
+	Long: `Check every CANARY token and report all problems with file, line and column.
+
+Each problem has a stable rule ID:
+  bad-segment           segment is not KEY=value
+  missing-field         REQ, FEATURE, ASPECT, STATUS or UPDATED is missing
+  invalid-status        STATUS is not a lifecycle state
+  invalid-aspect        ASPECT is unknown (with a suggestion when close)
+  unknown-key           field CANARY does not recognize (warning)
+  malformed-date        date field is not YYYY-MM-DD
+  tested-without-test   TESTED or BENCHED without TEST=
+  missing-doc           DOC path does not exist
+  duplicate-feature     REQ and FEATURE reused under another ASPECT (warning)
+
+Output formats are text (default), json and sarif (SARIF 2.1.0 for
+code-scanning UIs). The command exits 3 when any error is found.
+
+Examples:
+  canary lint
+  canary lint --format sarif --out canary.sarif`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootPath, _ := cmd.Flags().GetString("root")
+		format, _ := cmd.Flags().GetString("format")
+		outPath, _ := cmd.Flags().GetString("out")
+
+		write := map[string]func(io.Writer, *lint.Result) error{
+			"text":  lint.WriteText,
+			"json":  lint.WriteJSON,
+			"sarif": func(w io.Writer, r *lint.Result) error { return lint.WriteSARIF(w, r, version) },
+		}[format]
+		if write == nil {
+			return fmt.Errorf("unknown format %q (want text, json or sarif)", format)
+		}
+
+		lc, err := projectLifecycle(rootPath)
+		if err != nil {
+			return err
+		}
+		res, err := lint.Lint(lint.Options{Root: rootPath, ExcludePaths: excludePaths(rootPath), Lifecycle: lc})
+		if err != nil {
+			return fmt.Errorf("lint: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		if outPath != "" {
+			f, err := os.Create(outPath)
+			if err != nil {
+				return fmt.Errorf("create %s: %w", outPath, err)
+			}
+			defer f.Close()
+			out = f
+		}
+		if err := write(out, res); err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+
+		if res.Errors() > 0 {
+			if outPath != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%d errors, %d warnings\n", res.Errors(), res.Warnings())
+			}
+			return &scanner.ExitError{Code: scanner.ExitParseError}
+		}
+		return nil
+	},
+}
+
+func init() {
+	lintCmd.Flags().String("root", ".", "root directory to lint")
+	lintCmd.Flags().String("format", "text", "output format: text, json or sarif")
+	lintCmd.Flags().String("out", "", "write the report to a file instead of stdout")
+}
@@ -8,13 +8,13 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"text/template"
@@ -24,8 +24,12 @@ import (
 	"go.devnw.com/canary/embedded"
 	"go.devnw.com/canary/internal/config"
 	"go.devnw.com/canary/internal/gap"
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/lifecycle"
 	"go.devnw.com/canary/internal/migrate"
 	"go.devnw.com/canary/internal/reqid"
+	"go.devnw.com/canary/internal/scanner"
+	"go.devnw.com/canary/internal/specs"
 	"go.devnw.com/canary/internal/storage"
 )
 
@@ -55,6 +59,9 @@ commands for scanning, creating, and managing requirement tokens.`,
 				"rollback":     true, // rollback command manages migrations itself
 				"detect":       true, // detect command just reads, doesn't need DB
 				"migrate-from": true, // migrate-from creates .canary/, shouldn't auto-migrate first
+				"fmt":          true, // fmt only rewrites source files
+				"lint":         true, // lint only reads source files
+				"verify":       true, // verify only reads source files
 			}
 
 			if skipCommands[cmd.Name()] {
@@ -79,13 +86,18 @@ commands for scanning, creating, and managing requirement tokens.`,
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
+		// scan reports its own diagnostics and uses dedicated exit codes
+		var exitErr *scanner.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-// CANARY: REQ=CBIN-111; FEATURE="ScanCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2025-10-17
-// scanCmd wraps the existing tools/canary scanner
+// CANARY: REQ=CBIN-111; FEATURE="ScanCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2026-10-17
+// scanCmd runs the internal/scanner engine in-process
 var scanCmd = &cobra.Command{
 	Use:   "scan [flags]",
 	Short: "Scan for CANARY tokens and generate reports",
@@ -99,6 +111,10 @@ Flags:
   --csv <file>            Optional status.csv path
   --verify <file>         GAP_ANALYSIS file to verify claims
   --strict                Enforce staleness on TESTED/BENCHED tokens (30 days)
+  --git-stale             Fail on completed tokens whose code or tests changed after them in git
+  --verify-tests          Downgrade tokens whose TEST/BENCH functions don't exist
+  --results <file>        Grade tokens against a go test -json, JUnit or TAP report
+  --coverage <file>       Add line coverage from a Go, LCOV or Cobertura report
   --update-stale          Rewrite UPDATED field for stale tokens
   --skip <regex>          Skip path regex (RE2)
   --project-only          Filter by project requirement ID pattern
@@ -110,62 +126,43 @@ Examples:
   # Verify GAP_ANALYSIS.md claims
   canary scan --verify GAP_ANALYSIS.md
 
-  # Update stale tokens
-  canary scan --update-stale
-
-  # Strict mode with staleness enforcement
-  canary scan --strict`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// Build path to the canary scanner
-		scanner := filepath.Join("tools", "canary", "main.go")
+  # Check that every TEST=/BENCH= name is a real test function
+  canary scan --verify-tests
 
-		// Get all flags
-		rootDir, _ := cmd.Flags().GetString("root")
-		out, _ := cmd.Flags().GetString("out")
-		csv, _ := cmd.Flags().GetString("csv")
-		verify, _ := cmd.Flags().GetString("verify")
-		strict, _ := cmd.Flags().GetBool("strict")
-		updateStale, _ := cmd.Flags().GetBool("update-stale")
-		skip, _ := cmd.Flags().GetString("skip")
-		projectOnly, _ := cmd.Flags().GetBool("project-only")
+  # Only count tests that ran and passed; failures show as REGRESSED
+  go test -json ./... > results.json
+  canary scan --results results.json
 
-		// Build scanner arguments
-		scanArgs := []string{"run", scanner}
-
-		if rootDir != "" {
-			scanArgs = append(scanArgs, "-root", rootDir)
-		}
-		if out != "" {
-			scanArgs = append(scanArgs, "-out", out)
-		}
-		if csv != "" {
-			scanArgs = append(scanArgs, "-csv", csv)
-		}
-		if verify != "" {
-			scanArgs = append(scanArgs, "-verify", verify)
-		}
-		if strict {
-			scanArgs = append(scanArgs, "-strict")
-		}
-		if updateStale {
-			scanArgs = append(scanArgs, "-update-stale")
-		}
-		if skip != "" {
-			scanArgs = append(scanArgs, "-skip", skip)
-		}
-		if projectOnly {
-			scanArgs = append(scanArgs, "-project-only")
-		}
+  # Report coverage of each feature's code, enforcing min_coverage
+  canary scan --coverage coverage.out
 
-		// Pass through any additional args
-		scanArgs = append(scanArgs, args...)
+  # Update stale tokens
+  canary scan --update-stale
 
-		goCmd := exec.Command("go", scanArgs...)
-		goCmd.Stdout = os.Stdout
-		goCmd.Stderr = os.Stderr
-		goCmd.Stdin = os.Stdin
+  # Strict mode with staleness enforcement
+  canary scan --strict
 
-		return goCmd.Run()
+  # Flag tokens whose code or tests changed in git since the token did
+  canary scan --git-stale`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts scanner.Options
+		opts.Root, _ = cmd.Flags().GetString("root")
+		opts.Out, _ = cmd.Flags().GetString("out")
+		opts.CSV, _ = cmd.Flags().GetString("csv")
+		opts.Verify, _ = cmd.Flags().GetString("verify")
+		opts.Strict, _ = cmd.Flags().GetBool("strict")
+		opts.UpdateStale, _ = cmd.Flags().GetBool("update-stale")
+		opts.GitStale, _ = cmd.Flags().GetBool("git-stale")
+		opts.Skip, _ = cmd.Flags().GetString("skip")
+		opts.ProjectOnly, _ = cmd.Flags().GetBool("project-only")
+		opts.VerifyTests, _ = cmd.Flags().GetBool("verify-tests")
+		opts.Results, _ = cmd.Flags().GetStringSlice("results")
+		opts.Coverage, _ = cmd.Flags().GetStringSlice("coverage")
+
+		return scanner.Run(opts, os.Stderr)
 	},
 }
 
@@ -1302,11 +1299,20 @@ creates a spec directory, and populates it with a specification template.`,
 		content = strings.ReplaceAll(content, "[FEATURE NAME]", featureDesc)
 		content = strings.ReplaceAll(content, "YYYY-MM-DD", time.Now().UTC().Format("2006-01-02"))
 		content = strings.ReplaceAll(content, "<ASPECT>", aspect)
+		content = fillSpecAspect(content, aspect)
 
 		if err := os.WriteFile(specFile, []byte(content), 0644); err != nil {
 			return fmt.Errorf("write spec file: %w", err)
 		}
 
+		recordRequirement(&storage.Requirement{
+			ReqID:     generatedID,
+			Title:     featureDesc,
+			Aspect:    aspect,
+			SpecPath:  specFile,
+			CreatedAt: time.Now().UTC().Format("2006-01-02"),
+		})
+
 		fmt.Printf("✅ Created specification: %s\n", specFile)
 		fmt.Printf("\nRequirement ID: %s\n", generatedID)
 		fmt.Printf("Aspect: %s\n", aspect)
@@ -1319,7 +1325,9 @@ creates a spec directory, and populates it with a specification template.`,
 	},
 }
 
-// CANARY: REQ=CBIN-133; FEATURE="ImplementCmd"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_133_CLI_ExactMatch; OWNER=canary; DOC=user:docs/user/implement-command-guide.md; DOC_HASH=ed68fb1d97cf0562; UPDATED=2025-10-17
+// CANARY: REQ=CBIN-133; FEATURE="ImplementCmd"; ASPECT=CLI; STATUS=TESTED; OWNER=canary; UPDATED=2025-10-17
+// CANARY+: TEST=TestCANARY_CBIN_133_CLI_ExactMatch
+// CANARY+: DOC=user:docs/user/implement-command-guide.md; DOC_HASH=ed68fb1d97cf0562
 var implementCmd = &cobra.Command{
 	Use:   "implement <query>",
 	Short: "Generate implementation guidance for a requirement",
@@ -1385,25 +1393,14 @@ func loadProjectConfig() (*config.ProjectConfig, error) {
 	return config.Load(".")
 }
 
-// extractField extracts a field value from a CANARY token string
-func extractField(token, field string) string {
-	// Look for FIELD="value" or FIELD=value
-	pattern := field + `="([^"]+)"`
-	re := regexp.MustCompile(pattern)
-	matches := re.FindStringSubmatch(token)
-	if len(matches) > 1 {
-		return matches[1]
-	}
-
-	// Try without quotes
-	pattern = field + `=([^;\s]+)`
-	re = regexp.MustCompile(pattern)
-	matches = re.FindStringSubmatch(token)
-	if len(matches) > 1 {
-		return matches[1]
+// projectLifecycle returns the status lifecycle configured in root's
+// .canary/project.yaml, or the default lifecycle when there is none.
+func projectLifecycle(root string) (*lifecycle.Lifecycle, error) {
+	cfg, err := config.Load(root)
+	if err != nil {
+		return nil, err
 	}
-
-	return ""
+	return cfg.StatusLifecycle()
 }
 
 // CANARY: REQ=CBIN-121; FEATURE="PlanCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2025-10-16
@@ -1457,39 +1454,20 @@ tech stack decisions, and CANARY token placement instructions.`,
 
 		// Read spec to get feature name and aspect if not provided
 		specFile := filepath.Join(specDir, "spec.md")
-		specContent, err := os.ReadFile(specFile)
+		header, err := specs.ReadHeader(specFile)
 		if err != nil {
 			return fmt.Errorf("read spec file: %w", err)
 		}
 
-		// Extract feature name and aspect from spec
-		featureName := "Feature"
-		specAspect := ""
-		for _, line := range strings.Split(string(specContent), "\n") {
-			if strings.HasPrefix(line, "# Feature Specification:") {
-				featureName = strings.TrimPrefix(line, "# Feature Specification: ")
-				featureName = strings.TrimSpace(featureName)
-			}
-			if strings.HasPrefix(line, "**Aspect:**") {
-				// Extract aspect from markdown like "**Aspect:** API" or "**Aspect:** [API|CLI|...]"
-				parts := strings.SplitN(line, ":", 2)
-				if len(parts) == 2 {
-					aspectVal := strings.TrimSpace(parts[1])
-					// Remove brackets and extract first option if it's a list
-					aspectVal = strings.TrimPrefix(aspectVal, "[")
-					aspectVal = strings.Split(aspectVal, "|")[0]
-					aspectVal = strings.TrimSpace(aspectVal)
-					if aspectVal != "" {
-						specAspect = aspectVal
-					}
-				}
-			}
+		featureName := header.Title
+		if featureName == "" {
+			featureName = "Feature"
 		}
 
 		// Use aspect from flag, or fall back to spec, or default to "Engine"
 		if aspect == "" {
-			if specAspect != "" {
-				aspect = specAspect
+			if header.Aspect != "" {
+				aspect = header.Aspect
 			} else {
 				aspect = "Engine"
 			}
@@ -1532,6 +1510,8 @@ tech stack decisions, and CANARY token placement instructions.`,
 			return fmt.Errorf("write plan file: %w", err)
 		}
 
+		recordRequirement(&storage.Requirement{ReqID: reqID, SpecPath: specFile, PlanPath: planFile})
+
 		fmt.Printf("✅ Created implementation plan: %s\n", planFile)
 		fmt.Printf("\nRequirement: %s\n", reqID)
 		fmt.Println("\nNext steps:")
@@ -1551,13 +1531,27 @@ var indexCmd = &cobra.Command{
 	Long: `Scan the codebase for CANARY tokens and store metadata in SQLite database.
 
 This enables advanced features like priority ordering, keyword search, and checkpoints.
-The database is stored at .canary/canary.db by default.`,
+The database is stored at .canary/canary.db by default.
+
+Indexing is incremental: only files whose size, mtime or content hash changed
+since the last run are parsed again, and tokens from deleted or edited files
+are removed. Use --full to re-parse everything.
+
+Use --reconcile to re-parse everything in a single transaction and delete any
+indexed rows that were not seen in this run (for example, rows left behind by
+older versions that keyed tokens on line numbers). Tokens that only changed
+location are reported as moves rather than new tokens.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dbPath, _ := cmd.Flags().GetString("db")
 		rootPath, _ := cmd.Flags().GetString("root")
 
 		fmt.Printf("Indexing CANARY tokens from: %s\n", rootPath)
 
+		walk, err := indexOptions(rootPath, dbPath)
+		if err != nil {
+			return err
+		}
+
 		// Open or create database
 		db, err := storage.Open(dbPath)
 		if err != nil {
@@ -1567,136 +1561,35 @@ The database is stored at .canary/canary.db by default.`,
 		defer db.Close()
 
 		// Get git info if in a repo
-		var commitHash, branch string
-		if gitCmd := exec.Command("git", "rev-parse", "HEAD"); gitCmd.Dir == "" {
-			if output, err := gitCmd.Output(); err == nil {
-				commitHash = strings.TrimSpace(string(output))
-			}
-		}
-		if gitCmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD"); gitCmd.Dir == "" {
-			if output, err := gitCmd.Output(); err == nil {
-				branch = strings.TrimSpace(string(output))
-			}
+		commitHash, branch := gitHead()
+
+		full, _ := cmd.Flags().GetBool("full")
+		reconcile, _ := cmd.Flags().GetBool("reconcile")
+
+		// Only files whose size, mtime or content changed are re-parsed
+		stats, err := indexer.Sync(db, indexer.SyncOptions{
+			Options:    walk,
+			CommitHash: commitHash,
+			Branch:     branch,
+			Full:       full,
+			Reconcile:  reconcile,
+		})
+		if err != nil {
+			return fmt.Errorf("index tokens: %w", err)
 		}
-
-		// Scan for all CANARY tokens
-		grepCmd := exec.Command("grep",
-			"-rn",
-			"--include=*.go", "--include=*.md", "--include=*.py",
-			"--include=*.js", "--include=*.ts", "--include=*.java",
-			"--include=*.rb", "--include=*.rs", "--include=*.c",
-			"--include=*.cpp", "--include=*.h", "--include=*.sql",
-			"CANARY:",
-			rootPath,
-		)
-
-		output, err := grepCmd.CombinedOutput()
-		if err != nil && len(output) == 0 {
-			fmt.Println("No CANARY tokens found")
-			return nil
+		for _, d := range stats.Diagnostics {
+			fmt.Fprintf(os.Stderr, "Warning: skipping token at %v\n", d)
 		}
 
-		// Parse and store tokens
-		indexed := 0
-		lines := strings.Split(string(output), "\n")
-		for _, line := range lines {
-			if line == "" {
-				continue
-			}
-
-			// Parse grep output: file:line:content
-			parts := strings.SplitN(line, ":", 3)
-			if len(parts) < 3 {
-				continue
-			}
-
-			file := parts[0]
-			lineNum := 0
-			//nolint:errcheck // Best-effort parse, default to 0 on failure
-			fmt.Sscanf(parts[1], "%d", &lineNum)
-			content := parts[2]
-
-			// Extract all CANARY fields
-			reqID := extractField(content, "REQ")
-			feature := extractField(content, "FEATURE")
-			aspect := extractField(content, "ASPECT")
-			status := extractField(content, "STATUS")
-
-			if reqID == "" || feature == "" {
-				continue // Skip malformed tokens
-			}
-
-			// Build token struct
-			docPath := extractField(content, "DOC")
-			docType := extractField(content, "DOC_TYPE")
-
-			// Auto-infer DOC_TYPE from type prefix if not explicitly set
-			if docPath != "" && docType == "" {
-				// Extract type from first doc path (e.g., "user:docs/file.md" -> "user")
-				firstPath := strings.Split(docPath, ",")[0]
-				if strings.Contains(firstPath, ":") {
-					docType = strings.Split(firstPath, ":")[0]
-				}
-			}
-
-			token := &storage.Token{
-				ReqID:       reqID,
-				Feature:     feature,
-				Aspect:      aspect,
-				Status:      status,
-				FilePath:    file,
-				LineNumber:  lineNum,
-				Test:        extractField(content, "TEST"),
-				Bench:       extractField(content, "BENCH"),
-				Owner:       extractField(content, "OWNER"),
-				Phase:       extractField(content, "PHASE"),
-				Keywords:    extractField(content, "KEYWORDS"),
-				SpecStatus:  extractField(content, "SPEC_STATUS"),
-				UpdatedAt:   extractField(content, "UPDATED"),
-				CreatedAt:   extractField(content, "CREATED"),
-				StartedAt:   extractField(content, "STARTED"),
-				CompletedAt: extractField(content, "COMPLETED"),
-				CommitHash:  commitHash,
-				Branch:      branch,
-				DependsOn:   extractField(content, "DEPENDS_ON"),
-				Blocks:      extractField(content, "BLOCKS"),
-				RelatedTo:   extractField(content, "RELATED_TO"),
-				DocPath:     docPath,
-				DocHash:     extractField(content, "DOC_HASH"),
-				DocType:     docType,
-				RawToken:    content,
-				IndexedAt:   time.Now().UTC().Format(time.RFC3339),
+		fmt.Printf("\n✅ Indexed %d files (%d parsed, %d unchanged, %d removed)\n",
+			stats.Files, stats.Parsed, stats.Unchanged, stats.RemovedFiles)
+		fmt.Printf("Tokens: %d added, %d changed, %d moved, %d removed\n",
+			stats.Added, stats.Changed, stats.Moved, stats.Removed)
+		if reconcile {
+			for _, m := range stats.Moves {
+				fmt.Printf("  moved %s %q: %s:%d -> %s:%d\n", m.ReqID, m.Feature, m.FromFile, m.FromLine, m.ToFile, m.ToLine)
 			}
-
-			// Parse priority
-			if priorityStr := extractField(content, "PRIORITY"); priorityStr != "" {
-				if p, err := strconv.Atoi(priorityStr); err == nil {
-					token.Priority = p
-				} else {
-					token.Priority = 5 // default
-				}
-			} else {
-				token.Priority = 5 // default
-			}
-
-			// Set defaults
-			if token.UpdatedAt == "" {
-				token.UpdatedAt = time.Now().UTC().Format("2006-01-02")
-			}
-			if token.SpecStatus == "" {
-				token.SpecStatus = "draft"
-			}
-
-			// Store in database
-			if err := db.UpsertToken(token); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to store token %s/%s: %v\n", reqID, feature, err)
-				continue
-			}
-
-			indexed++
 		}
-
-		fmt.Printf("\n✅ Indexed %d CANARY tokens\n", indexed)
 		fmt.Printf("Database: %s\n", dbPath)
 
 		if commitHash != "" {
@@ -1725,7 +1618,10 @@ By default, hides requirements from:
 - Documentation examples (IMPLEMENTATION_SUMMARY, FINAL_SUMMARY, etc.)
 - AI agent directories (.claude/, .cursor/, .github/prompts/, etc.)
 
-Use --include-hidden to show all requirements including hidden ones.`,
+Use --include-hidden to show all requirements including hidden ones.
+
+Requirements that have a spec but no tokens yet are listed after the tokens,
+unless filtering by status or phase. JSON output lists tokens only.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dbPath, _ := cmd.Flags().GetString("db")
 		filterStatus, _ := cmd.Flags().GetString("status")
@@ -1789,17 +1685,30 @@ Use --include-hidden to show all requirements including hidden ones.`,
 			return fmt.Errorf("list tokens: %w", err)
 		}
 
-		if len(tokens) == 0 {
-			fmt.Println("No tokens found")
-			return nil
-		}
-
 		if jsonOutput {
+			if len(tokens) == 0 {
+				fmt.Println("No tokens found")
+				return nil
+			}
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
 			return enc.Encode(tokens)
 		}
 
+		pending, err := pendingRequirements(db, filters)
+		if err != nil {
+			return fmt.Errorf("list requirements: %w", err)
+		}
+
+		if len(tokens) == 0 {
+			fmt.Println("No tokens found")
+			if len(pending) > 0 {
+				fmt.Println()
+				printPendingRequirements(pending)
+			}
+			return nil
+		}
+
 		// Display as table
 		fmt.Printf("Found %d tokens:\n\n", len(tokens))
 		for _, token := range tokens {
@@ -1822,6 +1731,10 @@ Use --include-hidden to show all requirements including hidden ones.`,
 			fmt.Println()
 		}
 
+		if len(pending) > 0 {
+			printPendingRequirements(pending)
+		}
+
 		return nil
 	},
 }
@@ -1829,14 +1742,23 @@ Use --include-hidden to show all requirements including hidden ones.`,
 // CANARY: REQ=CBIN-126; FEATURE="SearchCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2025-10-16
 var searchCmd = &cobra.Command{
 	Use:   "search <keywords>",
-	Short: "Search CANARY tokens by keywords",
-	Long: `Search tokens by keywords in feature names, requirement IDs, and keyword tags.
+	Short: "Search tokens, specs, plans and gaps by keywords",
+	Long: `Search the full-text index of tokens, spec.md and plan.md sections, and gap
+analysis entries.
 
-Keywords are matched case-insensitively using LIKE queries.`,
+Results are ranked with BM25, best first, and show a snippet with the
+matching words marked. Words match by stem and prefix ("auth" finds
+"authentication"), and every word must match; when no result matches them
+all, results matching any word are shown. Each search is recorded in the
+search history.
+
+Use --kind to search only some of token, spec, plan and gap.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dbPath, _ := cmd.Flags().GetString("db")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		kinds, _ := cmd.Flags().GetStringSlice("kind")
+		limit, _ := cmd.Flags().GetInt("limit")
 		keywords := strings.Join(args, " ")
 
 		db, err := storage.Open(dbPath)
@@ -1846,32 +1768,23 @@ Keywords are matched case-insensitively using LIKE queries.`,
 
 		defer db.Close()
 
-		tokens, err := db.SearchTokens(keywords)
+		results, err := runSearch(db, "search", keywords, storage.SearchOptions{Kinds: kinds, Limit: limit})
 		if err != nil {
-			return fmt.Errorf("search tokens: %w", err)
+			return fmt.Errorf("search: %w", err)
 		}
 
-		if len(tokens) == 0 {
-			fmt.Printf("No tokens found for: %s\n", keywords)
+		if len(results) == 0 {
+			fmt.Printf("No results found for: %s\n", keywords)
 			return nil
 		}
 
 		if jsonOutput {
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
-			return enc.Encode(tokens)
+			return enc.Encode(results)
 		}
 
-		fmt.Printf("Search results for '%s' (%d tokens):\n\n", keywords, len(tokens))
-		for _, token := range tokens {
-			fmt.Printf("📌 %s - %s\n", token.ReqID, token.Feature)
-			fmt.Printf("   Status: %s | Priority: %d | %s:%d\n",
-				token.Status, token.Priority, token.FilePath, token.LineNumber)
-			if token.Keywords != "" {
-				fmt.Printf("   Tags: %s\n", token.Keywords)
-			}
-			fmt.Println()
-		}
+		printSearchResults(keywords, results)
 
 		return nil
 	},
@@ -1879,19 +1792,27 @@ Keywords are matched case-insensitively using LIKE queries.`,
 
 // CANARY: REQ=CBIN-127; FEATURE="PrioritizeCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2025-10-16
 var prioritizeCmd = &cobra.Command{
-	Use:   "prioritize <REQ-ID> <feature> <priority>",
-	Short: "Update priority of a CANARY token",
-	Long: `Update the priority of a specific token (1=highest, 10=lowest).
+	Use:   "prioritize <REQ-ID> <priority>",
+	Short: "Update priority of a requirement",
+	Long: `Update the priority of a requirement (1=highest, 10=lowest).
 
-Priority affects ordering in list and search results.`,
-	Args: cobra.ExactArgs(3),
+The priority is stored on the requirement and applies to all of its tokens,
+overriding their PRIORITY fields. It affects ordering in list, search and
+next results.
+
+The older "prioritize <REQ-ID> <feature> <priority>" form is deprecated but
+still accepted; the feature is ignored.`,
+	Args: cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dbPath, _ := cmd.Flags().GetString("db")
 		reqID := args[0]
-		feature := args[1]
-		priority, err := strconv.Atoi(args[2])
+		value := args[len(args)-1]
+		if len(args) == 3 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: the feature argument is deprecated and ignored; priorities apply to the whole requirement. Use 'canary prioritize %s %s'.\n", reqID, value)
+		}
+		priority, err := strconv.Atoi(value)
 		if err != nil {
-			return fmt.Errorf("invalid priority: %s (must be 1-10)", args[2])
+			return fmt.Errorf("invalid priority: %s (must be 1-10)", value)
 		}
 
 		if priority < 1 || priority > 10 {
@@ -1905,11 +1826,11 @@ Priority affects ordering in list and search results.`,
 
 		defer db.Close()
 
-		if err := db.UpdatePriority(reqID, feature, priority); err != nil {
+		if err := db.UpdatePriority(reqID, priority); err != nil {
 			return fmt.Errorf("update priority: %w", err)
 		}
 
-		fmt.Printf("✅ Updated priority for %s/%s to %d\n", reqID, feature, priority)
+		fmt.Printf("✅ Updated priority for %s to %d\n", reqID, priority)
 		return nil
 	},
 }
@@ -1925,7 +1846,11 @@ Checkpoints include:
 - Commit hash and timestamp
 - Full JSON snapshot of all tokens
 
-Useful for tracking progress over time.`,
+Useful for tracking progress over time. Checkpoints can then be inspected
+and compared (names list, show and diff are taken by these subcommands):
+  canary checkpoint list
+  canary checkpoint show <name>
+  canary checkpoint diff <a> [b|HEAD]`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dbPath, _ := cmd.Flags().GetString("db")
@@ -1950,17 +1875,10 @@ Useful for tracking progress over time.`,
 			}
 		}
 
-		// Load project config for ID pattern filtering
-		cfg, _ := loadProjectConfig()
-		idPattern := ""
-		if cfg != nil && cfg.Requirements.IDPattern != "" {
-			idPattern = cfg.Requirements.IDPattern
-		}
-
 		// Get all tokens for snapshot
-		tokens, err := db.ListTokens(nil, idPattern, "", 0)
+		tokens, err := checkpointTokens(db)
 		if err != nil {
-			return fmt.Errorf("get tokens: %w", err)
+			return err
 		}
 
 		snapshotJSON, err := json.Marshal(tokens)
@@ -2003,6 +1921,23 @@ Steps can be:
 			return fmt.Errorf("migration failed: %w", err)
 		}
 
+		// Fill the requirements table from the tokens already indexed and
+		// the specs on disk
+		if needs, _, err := storage.NeedsMigration(dbPath); err == nil && !needs {
+			db, err := storage.Open(dbPath)
+			if err != nil {
+				return fmt.Errorf("open database: %w", err)
+			}
+			defer db.Close()
+			lc, err := projectLifecycle(".")
+			if err != nil {
+				return err
+			}
+			if err := indexer.SyncRequirements(db, indexer.SyncOptions{Options: indexer.Options{Lifecycle: lc}}); err != nil {
+				return fmt.Errorf("sync requirements: %w", err)
+			}
+		}
+
 		fmt.Println("✅ Migrations completed successfully")
 		return nil
 	},
@@ -2273,6 +2208,13 @@ func init() {
 	rootCmd.AddCommand(implementCmd)
 	rootCmd.AddCommand(nextCmd)
 	rootCmd.AddCommand(indexCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(fmtCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(coverageCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(historyCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(prioritizeCmd)
@@ -2329,6 +2271,8 @@ func init() {
 	// indexCmd flags
 	indexCmd.Flags().String("db", ".canary/canary.db", "path to database file")
 	indexCmd.Flags().String("root", ".", "root directory to scan")
+	indexCmd.Flags().Bool("full", false, "re-parse every file even if unchanged")
+	indexCmd.Flags().Bool("reconcile", false, "re-parse everything and delete rows not seen in this run")
 
 	// listCmd flags
 	listCmd.Flags().String("db", ".canary/canary.db", "path to database file")
@@ -2347,12 +2291,14 @@ func init() {
 	// searchCmd flags
 	searchCmd.Flags().String("db", ".canary/canary.db", "path to database file")
 	searchCmd.Flags().Bool("json", false, "output as JSON")
+	searchCmd.Flags().StringSlice("kind", nil, "result kinds to search: token, spec, plan, gap (default all)")
+	searchCmd.Flags().Int("limit", 20, "maximum number of results")
 
 	// prioritizeCmd flags
 	prioritizeCmd.Flags().String("db", ".canary/canary.db", "path to database file")
 
 	// checkpointCmd flags
-	checkpointCmd.Flags().String("db", ".canary/canary.db", "path to database file")
+	checkpointCmd.PersistentFlags().String("db", ".canary/canary.db", "path to database file")
 
 	// migrateCmd flags
 	migrateCmd.Flags().String("db", ".canary/canary.db", "path to database file")
@@ -2371,8 +2317,12 @@ func init() {
 	scanCmd.Flags().String("verify", "", "GAP_ANALYSIS file to verify claims")
 	scanCmd.Flags().Bool("strict", false, "enforce staleness on TESTED/BENCHED tokens (30 days)")
 	scanCmd.Flags().Bool("update-stale", false, "rewrite UPDATED field for stale tokens")
+	scanCmd.Flags().Bool("git-stale", false, "fail on completed tokens whose code or tests changed after them in git")
 	scanCmd.Flags().String("skip", "", "skip path regex (RE2)")
 	scanCmd.Flags().Bool("project-only", false, "filter by project requirement ID pattern")
+	scanCmd.Flags().Bool("verify-tests", false, "downgrade tokens whose TEST/BENCH functions don't exist")
+	scanCmd.Flags().StringSlice("results", nil, "go test -json, JUnit XML or TAP report to grade tokens against (repeatable)")
+	scanCmd.Flags().StringSlice("coverage", nil, "Go cover profile, LCOV or Cobertura XML report to measure token code with (repeatable)")
 
 	// nextCmd flags
 	nextCmd.Flags().String("db", ".canary/canary.db", "path to database file")
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/mutate"
+	"go.devnw.com/canary/internal/span"
+	"go.devnw.com/canary/internal/storage"
+	"go.devnw.com/canary/internal/token"
+)
+
+// CANARY: REQ=CBIN-168; FEATURE="VerifyMutate"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2026-10-17
+
+// tokenMutation is the outcome of mutating the code one token annotates.
+type tokenMutation struct {
+	Token   *token.Token
+	Tests   []string
+	Score   mutate.Score
+	Results []mutate.Result
+	Err     error // why the token's code couldn't be graded
+}
+
+// mutateTokens grades the tests of every Go token completed under lc that
+// names resolvable Go tests, mutating the declaration the token annotates.
+// Tokens are processed by file and line; progress goes to progress.
+func mutateTokens(ctx context.Context, root string, toks []*token.Token, ix *evidence.Index, lc *lifecycle.Lifecycle, maxMutants int, timeout time.Duration, progress io.Writer) ([]tokenMutation, error) {
+	byFile := map[string][]*token.Token{}
+	var files []string
+	for _, tok := range toks {
+		if !strings.HasSuffix(tok.File, ".go") || strings.HasSuffix(tok.File, "_test.go") {
+			continue
+		}
+		if len(tok.Tests()) == 0 || !lc.Satisfies(tok.Status(), tok.Aspect()) {
+			continue
+		}
+		if byFile[tok.File] == nil {
+			files = append(files, tok.File)
+		}
+		byFile[tok.File] = append(byFile[tok.File], tok)
+	}
+	sort.Strings(files)
+
+	runner := &mutate.Runner{Dir: root, Timeout: timeout}
+	var out []tokenMutation
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+		fileToks := byFile[file]
+		spans := span.Tokens(file, src, fileToks)
+		for i, tok := range fileToks {
+			m := tokenMutation{Token: tok}
+			pkgs := map[string]bool{}
+			for _, name := range tok.Tests() {
+				for _, sym := range ix.Lookup(evidence.Test, name) {
+					if strings.HasSuffix(sym.File, "_test.go") {
+						pkgs["./"+relPath(root, filepath.Dir(sym.File))] = true
+						m.Tests = append(m.Tests, name)
+						break
+					}
+				}
+			}
+			switch {
+			case len(m.Tests) == 0:
+				continue // no Go tests to run
+			case spans[i].Start == 0:
+				m.Err = errors.New("annotates no declaration")
+				out = append(out, m)
+				continue
+			}
+
+			fmt.Fprintf(progress, "Mutating %s %q (%s:%d)...\n", tok.ReqID(), tok.Feature(), relPath(root, file), tok.Line)
+			m.Score, m.Results, m.Err = runner.Test(ctx, file, src, spans[i].Start, spans[i].End, sortedKeys(pkgs), m.Tests, maxMutants)
+			if m.Err != nil && !errors.Is(m.Err, mutate.ErrTestsFail) {
+				return nil, m.Err
+			}
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// recordMutationScores stores the score of every graded token in the
+// database at dbPath. Tokens are stored under the path they were walked at,
+// as the indexer stores them, so the scores match the indexed tokens.
+func recordMutationScores(dbPath string, mutations []tokenMutation) error {
+	var rows []storage.MutationScore
+	for _, m := range mutations {
+		if m.Err != nil {
+			continue
+		}
+		rows = append(rows, storage.MutationScore{
+			ReqID: m.Token.ReqID(), Feature: m.Token.Feature(),
+			FilePath: filepath.Clean(m.Token.File), LineNumber: m.Token.Line,
+			Killed: m.Score.Killed, Survived: m.Score.Survived, Invalid: m.Score.Invalid,
+		})
+	}
+
+	if err := storage.AutoMigrate(dbPath); err != nil {
+		return fmt.Errorf("migrate database: %w", err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+	return db.ReplaceMutationScores("", rows)
+}
+
+// survivors returns the mutants of m no test caught.
+func (m tokenMutation) survivors() []mutate.Result {
+	var out []mutate.Result
+	for _, r := range m.Results {
+		if r.Outcome == mutate.Survived {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func sortedKeys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
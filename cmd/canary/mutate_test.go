@@ -0,0 +1,75 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/mutate"
+	"go.devnw.com/canary/internal/storage"
+	"go.devnw.com/canary/internal/token"
+)
+
+// TestCANARY_CBIN_168_CLI_RecordMutationScores verifies scores recorded for
+// a project scanned from outside its root match the tokens the indexer
+// stored for it.
+func TestCANARY_CBIN_168_CLI_RecordMutationScores(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "proj")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	src := "package p\n\n// CANARY: REQ=CBIN-310; FEATURE=\"Parse\"; ASPECT=Engine; STATUS=TESTED; TEST=TestParse; UPDATED=2026-10-17\nfunc Parse() {}\n"
+	if err := os.WriteFile(filepath.Join(root, "parse.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := indexer.Sync(db, indexer.SyncOptions{Options: indexer.Options{Root: root}, Full: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tokens are walked the way `canary verify --mutate` walks them
+	paths, err := indexer.Files(indexer.Options{Root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var toks []*token.Token
+	for _, f := range indexer.ParseFiles(paths, 0, nil) {
+		toks = append(toks, f.Tokens...)
+	}
+	if len(toks) != 1 {
+		t.Fatalf("parsed %d tokens, want 1", len(toks))
+	}
+	mutations := []tokenMutation{{Token: toks[0], Score: mutate.Score{Killed: 3, Survived: 1}}}
+	if err := recordMutationScores(dbPath, mutations); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := db.GetTokensByReqID("CBIN-310")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 {
+		t.Fatalf("indexed %d tokens, want 1", len(tokens))
+	}
+	if err := db.ApplyMutationScores("", tokens); err != nil {
+		t.Fatal(err)
+	}
+	if got := tokens[0]; got.MutantsKilled != 3 || got.MutantsSurvived != 1 {
+		t.Errorf("scores of %s = %d killed, %d survived; want 3, 1", got.FilePath, got.MutantsKilled, got.MutantsSurvived)
+	}
+}
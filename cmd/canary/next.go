@@ -9,14 +9,15 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"text/template"
 	"time"
 
 	"go.devnw.com/canary/internal/config"
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/specs"
 	"go.devnw.com/canary/internal/storage"
 )
 
@@ -51,25 +52,30 @@ type RelatedSpec struct {
 // selectNextPriority identifies the highest priority unimplemented requirement
 // Uses database if available, falls back to filesystem scan
 func selectNextPriority(dbPath string, filters map[string]string) (*storage.Token, error) {
+	lc, err := projectLifecycle(".")
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if database file exists
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
 		// Fall back to filesystem scan if database doesn't exist
-		return selectFromFilesystem(filters)
+		return selectFromFilesystem(filters, lc)
 	}
 
 	// Try database first
 	db, err := storage.Open(dbPath)
 	if err != nil {
 		// Fall back to filesystem scan if database unavailable
-		return selectFromFilesystem(filters)
+		return selectFromFilesystem(filters, lc)
 	}
 
 	defer db.Close()
-	return selectFromDatabase(db, filters)
+	return selectFromDatabase(db, filters, lc)
 }
 
 // selectFromDatabase queries the database for next priority
-func selectFromDatabase(db *storage.DB, filters map[string]string) (*storage.Token, error) {
+func selectFromDatabase(db *storage.DB, filters map[string]string, lc *lifecycle.Lifecycle) (*storage.Token, error) {
 	// Build filters for incomplete requirements
 	if filters == nil {
 		filters = make(map[string]string)
@@ -82,43 +88,26 @@ func selectFromDatabase(db *storage.DB, filters map[string]string) (*storage.Tok
 		idPattern = cfg.Requirements.IDPattern
 	}
 
-	// If no status filter, only select STUB or IMPL by default
+	// If no status filter, only select pending work (STUB or IMPL by
+	// default), trying each pending state in lifecycle order
 	if _, hasStatusFilter := filters["status"]; !hasStatusFilter {
-		// Query separately for STUB and IMPL, prioritizing STUB
-		stubFilters := make(map[string]string)
-		for k, v := range filters {
-			stubFilters[k] = v
-		}
-		stubFilters["status"] = "STUB"
-
-		// Try STUB first
-		tokens, err := db.ListTokens(stubFilters, idPattern, "priority ASC, updated_at DESC", 50)
-		if err != nil {
-			return nil, fmt.Errorf("query STUB tokens: %w", err)
-		}
-
-		// Filter out blocked tokens
-		for _, token := range tokens {
-			if !hasUnresolvedDependencies(db, token) {
-				return token, nil
+		for _, status := range lc.Pending() {
+			statusFilters := make(map[string]string)
+			for k, v := range filters {
+				statusFilters[k] = v
 			}
-		}
-
-		// Try IMPL if no STUB available
-		implFilters := make(map[string]string)
-		for k, v := range filters {
-			implFilters[k] = v
-		}
-		implFilters["status"] = "IMPL"
+			statusFilters["status"] = status
 
-		tokens, err = db.ListTokens(implFilters, idPattern, "priority ASC, updated_at DESC", 50)
-		if err != nil {
-			return nil, fmt.Errorf("query IMPL tokens: %w", err)
-		}
+			tokens, err := db.ListTokens(statusFilters, idPattern, "priority ASC, updated_at DESC", 50)
+			if err != nil {
+				return nil, fmt.Errorf("query %s tokens: %w", status, err)
+			}
 
-		for _, token := range tokens {
-			if !hasUnresolvedDependencies(db, token) {
-				return token, nil
+			// Filter out blocked tokens
+			for _, token := range tokens {
+				if !hasUnresolvedDependencies(db, token, lc) {
+					return token, nil
+				}
 			}
 		}
 
@@ -133,7 +122,7 @@ func selectFromDatabase(db *storage.DB, filters map[string]string) (*storage.Tok
 
 	// Find first unblocked token
 	for _, token := range tokens {
-		if !hasUnresolvedDependencies(db, token) {
+		if !hasUnresolvedDependencies(db, token, lc) {
 			return token, nil
 		}
 	}
@@ -141,37 +130,18 @@ func selectFromDatabase(db *storage.DB, filters map[string]string) (*storage.Tok
 	return nil, nil // No unblocked work available
 }
 
-// hasUnresolvedDependencies checks if a token has blocking dependencies
-func hasUnresolvedDependencies(db *storage.DB, token *storage.Token) bool {
-	if token.DependsOn == "" {
-		return false
+// hasUnresolvedDependencies checks if a token's requirement needs, directly
+// or through other requirements, one that isn't complete yet under lc
+func hasUnresolvedDependencies(db *storage.DB, token *storage.Token, lc *lifecycle.Lifecycle) bool {
+	edges, err := db.Dependencies(token.ProjectID, token.ReqID)
+	if err != nil {
+		return true // Unknown dependencies = blocking
 	}
 
-	// Parse comma-separated dependencies
-	deps := strings.Split(token.DependsOn, ",")
-	for _, dep := range deps {
-		dep = strings.TrimSpace(dep)
-		if dep == "" {
-			continue
-		}
-
-		// Query dependency status
-		depTokens, err := db.GetTokensByReqID(dep)
-		if err != nil || len(depTokens) == 0 {
-			return true // Dependency not found = blocking
-		}
-
-		// Check if any token for this requirement is incomplete
-		allComplete := true
-		for _, depToken := range depTokens {
-			if depToken.Status != "TESTED" && depToken.Status != "BENCHED" {
-				allComplete = false
-				break
-			}
-		}
-
-		if !allComplete {
-			return true // Dependency incomplete = blocking
+	checker := specs.NewStatusChecker(&dbTokenProvider{db: db}, lc)
+	for _, e := range edges {
+		if !checker.CheckDependency(edgeDependency(e)).IsSatisfied {
+			return true // Dependency missing or incomplete = blocking
 		}
 	}
 
@@ -200,50 +170,18 @@ func isHiddenPath(filePath string) bool {
 	return false
 }
 
-// selectFromFilesystem scans filesystem for CANARY tokens when database unavailable
-func selectFromFilesystem(filters map[string]string) (*storage.Token, error) {
-	// Use grep to find all CANARY tokens
-	grepCmd := exec.Command("grep",
-		"-rn",
-		"--include=*.go", "--include=*.md", "--include=*.py",
-		"--include=*.js", "--include=*.ts", "--include=*.java",
-		"--include=*.rb", "--include=*.rs",
-		"CANARY:",
-		".",
-	)
-
-	output, err := grepCmd.CombinedOutput()
-	if err != nil && len(output) == 0 {
+// selectFromFilesystem parses CANARY tokens from disk when the database is unavailable
+func selectFromFilesystem(filters map[string]string, lc *lifecycle.Lifecycle) (*storage.Token, error) {
+	toks, _, err := collectTokens(".")
+	if err != nil {
 		return nil, nil // No tokens found
 	}
 
-	// Parse tokens from grep output
+	// Convert parsed tokens into candidates
 	var candidates []*storage.Token
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		// Parse grep output: file:line:content
-		parts := strings.SplitN(line, ":", 3)
-		if len(parts) < 3 {
-			continue
-		}
-
-		file := parts[0]
-		content := parts[2]
-
-		// Extract CANARY fields
-		reqID := extractField(content, "REQ")
-		feature := extractField(content, "FEATURE")
-		aspect := extractField(content, "ASPECT")
-		status := extractField(content, "STATUS")
-		priorityStr := extractField(content, "PRIORITY")
-
-		if reqID == "" || feature == "" {
-			continue
-		}
+	for _, tok := range toks {
+		status := tok.Status()
+		aspect := tok.Aspect()
 
 		// Apply filters
 		if filterStatus, ok := filters["status"]; ok && status != filterStatus {
@@ -253,45 +191,28 @@ func selectFromFilesystem(filters map[string]string) (*storage.Token, error) {
 			continue
 		}
 
-		// Parse priority
-		priority := 5 // default
-		if priorityStr != "" {
-			//nolint:errcheck // Best-effort parse, default to 5 on failure
-			fmt.Sscanf(priorityStr, "%d", &priority)
-		}
-
-		// Only include STUB or IMPL unless filtered
+		// Only include pending work (STUB or IMPL by default) unless filtered
 		if _, hasFilter := filters["status"]; !hasFilter {
-			if status != "STUB" && status != "IMPL" {
+			if !lc.IsPending(status) {
 				continue
 			}
 		}
 
 		// Skip hidden paths unless include_hidden is set
 		if includeHidden, ok := filters["include_hidden"]; !ok || includeHidden != "true" {
-			if isHiddenPath(file) {
+			if isHiddenPath(tok.File) {
 				continue
 			}
 		}
 
-		token := &storage.Token{
-			ReqID:    reqID,
-			Feature:  feature,
-			Aspect:   aspect,
-			Status:   status,
-			Priority: priority,
-			FilePath: file,
-			RawToken: content,
-		}
-
-		candidates = append(candidates, token)
+		candidates = append(candidates, indexer.StorageToken(tok))
 	}
 
 	if len(candidates) == 0 {
 		return nil, nil
 	}
 
-	// Sort by priority (1=highest), then by status (STUB > IMPL)
+	// Sort by priority (1=highest), then by lifecycle order (STUB > IMPL)
 	var best *storage.Token
 	for _, candidate := range candidates {
 		if best == nil {
@@ -308,8 +229,8 @@ func selectFromFilesystem(filters map[string]string) (*storage.Token, error) {
 			continue
 		}
 
-		// Same priority: prefer STUB over IMPL
-		if candidate.Status == "STUB" && best.Status == "IMPL" {
+		// Same priority: prefer the earlier state, e.g. STUB over IMPL
+		if lc.Rank(candidate.Status) < lc.Rank(best.Status) {
 			best = candidate
 		}
 	}
@@ -402,17 +323,16 @@ func loadPromptData(token *storage.Token) (*PromptData, error) {
 	dbPath := ".canary/canary.db"
 	if db, err := storage.Open(dbPath); err == nil {
 		defer db.Close()
-		if token.DependsOn != "" {
-			deps := strings.Split(token.DependsOn, ",")
-			for _, dep := range deps {
-				dep = strings.TrimSpace(dep)
-				if dep == "" {
-					continue
-				}
-				depTokens, err := db.GetTokensByReqID(dep)
-				if err == nil && len(depTokens) > 0 {
-					data.Dependencies = append(data.Dependencies, depTokens[0])
-				}
+		edges, _ := db.Dependencies(token.ProjectID, token.ReqID)
+		seen := map[string]bool{}
+		for _, e := range edges {
+			if e.Depth != 1 || seen[e.Target] {
+				continue
+			}
+			seen[e.Target] = true
+			depTokens, err := db.GetTokensByReqID(e.Target)
+			if err == nil && len(depTokens) > 0 {
+				data.Dependencies = append(data.Dependencies, depTokens[0])
 			}
 		}
 	}
@@ -514,25 +434,3 @@ func guessPackageName(aspect string) string {
 
 	return "main"
 }
-
-// extractField extracts a field value from a CANARY token string (already defined in main.go)
-// This is a duplicate for use in next.go - consider moving to shared utility
-func extractFieldInternal(token, field string) string {
-	// Look for FIELD="value" or FIELD=value
-	pattern := field + `="([^"]+)"`
-	re := regexp.MustCompile(pattern)
-	matches := re.FindStringSubmatch(token)
-	if len(matches) > 1 {
-		return matches[1]
-	}
-
-	// Try without quotes
-	pattern = field + `=([^;\s]+)`
-	re = regexp.MustCompile(pattern)
-	matches = re.FindStringSubmatch(token)
-	if len(matches) > 1 {
-		return matches[1]
-	}
-
-	return ""
-}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+// CANARY: REQ=CBIN-170; FEATURE="RequirementsCLI"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_170_CLI_PendingRequirements; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+// specAspectPlaceholder matches the template's list of aspects to choose from
+var specAspectPlaceholder = regexp.MustCompile(`(?m)^\*\*Aspect:\*\* \[.*\]$`)
+
+// fillSpecAspect replaces the aspect placeholder of a new spec, so the spec
+// stays the record of its requirement's aspect
+func fillSpecAspect(content, aspect string) string {
+	return specAspectPlaceholder.ReplaceAllLiteralString(content, "**Aspect:** "+aspect)
+}
+
+// recordRequirement saves r to the project database when there is one, so
+// a requirement is tracked from the moment its spec or plan is written.
+// Projects without a database pick it up on their first index.
+func recordRequirement(r *storage.Requirement) {
+	if _, err := os.Stat(projectDBPath); err != nil {
+		return
+	}
+	if err := saveRequirement(projectDBPath, r); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not record %s in the database: %v\n", r.ReqID, err)
+	}
+}
+
+func saveRequirement(dbPath string, r *storage.Requirement) error {
+	if err := storage.AutoMigrate(dbPath); err != nil {
+		return fmt.Errorf("migrate database: %w", err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+	return db.SaveRequirement(r)
+}
+
+// pendingRequirements returns the requirements without tokens yet that
+// pass the list filters. Filters on token fields (status, phase) match no
+// requirement without tokens.
+func pendingRequirements(db *storage.DB, filters map[string]string) ([]*storage.Requirement, error) {
+	if filters["status"] != "" || filters["phase"] != "" {
+		return nil, nil
+	}
+
+	reqs, err := db.ListRequirements("")
+	if err != nil {
+		return nil, err
+	}
+
+	minPriority, _ := strconv.Atoi(filters["priority_min"])
+	maxPriority, _ := strconv.Atoi(filters["priority_max"])
+	var out []*storage.Requirement
+	for _, r := range reqs {
+		switch {
+		case r.Tokens > 0:
+		case filters["aspect"] != "" && r.Aspect != filters["aspect"]:
+		case filters["owner"] != "" && r.Owner != filters["owner"]:
+		case filters["spec_status"] != "" && r.SpecStatus != filters["spec_status"]:
+		case minPriority > 0 && r.Priority < minPriority:
+		case maxPriority > 0 && r.Priority > maxPriority:
+		default:
+			out = append(out, r)
+		}
+	}
+
+	return out, nil
+}
+
+// printPendingRequirements lists requirements that have no tokens yet
+func printPendingRequirements(reqs []*storage.Requirement) {
+	fmt.Printf("Not started (%d requirements without tokens):\n\n", len(reqs))
+	for _, r := range reqs {
+		fmt.Printf("📄 %s", r.ReqID)
+		if r.Title != "" {
+			fmt.Printf(" - %s", r.Title)
+		}
+		fmt.Println()
+		fmt.Printf("   Spec Status: %s | Aspect: %s | Priority: %d\n", r.SpecStatus, r.Aspect, r.Priority)
+		if r.SpecPath != "" {
+			fmt.Printf("   Spec: %s\n", r.SpecPath)
+		}
+		if r.PlanPath != "" {
+			fmt.Printf("   Plan: %s\n", r.PlanPath)
+		}
+		fmt.Println()
+	}
+}
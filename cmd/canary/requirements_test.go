@@ -0,0 +1,58 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.devnw.com/canary/internal/storage"
+)
+
+func TestCANARY_CBIN_170_CLI_PendingRequirements(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(tmpDir))
+
+	// Without a database nothing is recorded
+	recordRequirement(&storage.Requirement{ReqID: "CBIN-500"})
+	_, err := os.Stat(projectDBPath)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, os.MkdirAll(".canary", 0o755))
+	require.NoError(t, storage.AutoMigrate(projectDBPath))
+	recordRequirement(&storage.Requirement{ReqID: "CBIN-501", Title: "Parser", Aspect: "Engine", SpecPath: "spec.md"})
+	recordRequirement(&storage.Requirement{ReqID: "CBIN-502", Title: "Server", Aspect: "API", SpecPath: "spec.md"})
+
+	db, err := storage.Open(projectDBPath)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.UpsertToken(&storage.Token{ReqID: "CBIN-502", Feature: "Serve", Aspect: "API", Status: "STUB", FilePath: "a.go", LineNumber: 1}))
+	require.NoError(t, db.UpdatePriority("CBIN-501", 2))
+
+	pending, err := pendingRequirements(db, map[string]string{})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "CBIN-501", pending[0].ReqID)
+	assert.Equal(t, "Parser", pending[0].Title)
+	assert.Equal(t, 2, pending[0].Priority)
+
+	for _, filters := range []map[string]string{
+		{"status": "STUB"},
+		{"aspect": "API"},
+		{"priority_min": "3"},
+	} {
+		pending, err := pendingRequirements(db, filters)
+		require.NoError(t, err)
+		assert.Empty(t, pending, "filters %v", filters)
+	}
+
+	spec := "# Feature Specification: Parser\n\n**Aspect:** [API|CLI|Engine]\n**Status:** STUB\n"
+	assert.Contains(t, fillSpecAspect(spec, "Engine"), "**Aspect:** Engine\n**Status:**")
+}
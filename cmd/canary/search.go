@@ -0,0 +1,124 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+// CANARY: REQ=CBIN-171; FEATURE="SearchCLI"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_171_CLI_SearchSpecs; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"go.devnw.com/canary/internal/specs"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// searchFilters is recorded in search_history alongside each query
+type searchFilters struct {
+	Command string   `json:"command"`
+	Kinds   []string `json:"kinds,omitempty"`
+	Limit   int      `json:"limit,omitempty"`
+}
+
+// runSearch runs a full-text search and records it in search_history
+func runSearch(db *storage.DB, command, query string, opts storage.SearchOptions) ([]storage.SearchResult, error) {
+	results, err := db.Search(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := json.Marshal(searchFilters{Command: command, Kinds: opts.Kinds, Limit: opts.Limit})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.RecordSearch(query, string(filters), len(results)); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// specMatch is a requirement whose spec directory a full-text search found
+type specMatch struct {
+	ReqID   string
+	Dir     string
+	Title   string // section heading or feature of the best match
+	Snippet string
+}
+
+// searchSpecs returns the requirements with a spec whose specs, plans,
+// tokens or gap entries best match query, best first. It returns nothing
+// when the project has no migrated database.
+func searchSpecs(command, query string, limit int) ([]specMatch, error) {
+	db := openRequirementGraph()
+	if db == nil {
+		return nil, nil
+	}
+	defer db.Close()
+
+	results, err := runSearch(db, command, query, storage.SearchOptions{Limit: 50})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []specMatch
+	seen := map[string]bool{}
+	for _, r := range results {
+		if seen[r.ReqID] {
+			continue
+		}
+		seen[r.ReqID] = true
+
+		var dir string
+		if r.Kind == storage.SearchSpec || r.Kind == storage.SearchPlan {
+			dir = filepath.Dir(r.Path)
+		} else if path, err := specs.FindSpecByID(r.ReqID); err == nil {
+			dir = filepath.Dir(path)
+		} else {
+			continue
+		}
+
+		matches = append(matches, specMatch{ReqID: r.ReqID, Dir: dir, Title: r.Title, Snippet: flatSnippet(r.Snippet)})
+		if len(matches) == limit {
+			break
+		}
+	}
+
+	return matches, nil
+}
+
+// flatSnippet puts a search snippet on one line
+func flatSnippet(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// searchIcons marks each kind of search result
+var searchIcons = map[string]string{
+	storage.SearchToken: "📌",
+	storage.SearchSpec:  "📄",
+	storage.SearchPlan:  "📋",
+	storage.SearchGap:   "⚠️ ",
+}
+
+// printSearchResults lists full-text search results with their snippets
+func printSearchResults(query string, results []storage.SearchResult) {
+	fmt.Printf("Search results for '%s' (%d matches):\n\n", query, len(results))
+	for _, r := range results {
+		fmt.Printf("%s %s", searchIcons[r.Kind], r.ReqID)
+		if r.Title != "" {
+			fmt.Printf(" - %s", r.Title)
+		}
+		fmt.Printf(" [%s]\n", r.Kind)
+		if r.Path != "" {
+			fmt.Printf("   %s:%d\n", r.Path, r.Line)
+		}
+		if s := flatSnippet(r.Snippet); s != "" {
+			fmt.Printf("   %s\n", s)
+		}
+		fmt.Println()
+	}
+}
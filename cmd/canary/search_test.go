@@ -0,0 +1,73 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/storage"
+)
+
+func TestCANARY_CBIN_171_CLI_SearchSpecs(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(tmpDir))
+
+	// Without an index there is nothing to search
+	found, err := searchSpecs("implement", "password", 5)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	for dir, body := range map[string]string{
+		"CBIN-105-login":  "# Feature Specification: Login\n\n## Overview\n\nUsers sign in with a password; failures are rate limited.\n",
+		"CBIN-106-export": "# Feature Specification: Export\n\n## Overview\n\nWrites reports as CSV.\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(".canary", "specs", dir), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(".canary", "specs", dir, "spec.md"), []byte(body), 0o644))
+	}
+	require.NoError(t, os.WriteFile("export.go", []byte(`// CANARY: REQ=CBIN-106; FEATURE="CSVWriter"; ASPECT=API; STATUS=IMPL; KEYWORDS=spreadsheet; UPDATED=2026-10-17`+"\n"), 0o644))
+
+	require.NoError(t, storage.AutoMigrate(projectDBPath))
+	db, err := storage.Open(projectDBPath)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = indexer.Sync(db, indexer.SyncOptions{Options: indexer.Options{Root: "."}})
+	require.NoError(t, err)
+
+	// Spec text, not the directory name, finds the requirement
+	found, err = searchSpecs("specify update", "rate limited", 5)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "CBIN-105", found[0].ReqID)
+	assert.Equal(t, filepath.Join(".canary", "specs", "CBIN-105-login"), found[0].Dir)
+	assert.Contains(t, found[0].Snippet, "**rate**")
+
+	// A token keyword leads to its requirement's spec
+	spec, err := findRequirement("spreadsheet")
+	require.NoError(t, err)
+	assert.Equal(t, "CBIN-106", spec.ReqID)
+
+	var history []struct {
+		Query   string `db:"query"`
+		Filters string `db:"filters"`
+		Count   int    `db:"results_count"`
+	}
+	raw, err := storage.InitDB(projectDBPath)
+	require.NoError(t, err)
+	defer raw.Close()
+	require.NoError(t, raw.Select(&history, `SELECT query, filters, results_count FROM search_history ORDER BY id`))
+	require.Len(t, history, 2)
+	assert.Equal(t, "rate limited", history[0].Query)
+	assert.Contains(t, history[0].Filters, `"command":"specify update"`)
+	assert.Equal(t, 1, history[0].Count)
+	assert.Equal(t, "spreadsheet", history[1].Query)
+}
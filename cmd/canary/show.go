@@ -24,9 +24,14 @@ var showCmd = &cobra.Command{
 Displays:
 - Feature name, aspect, status
 - File location and line number
+- The function or declaration the token annotates
 - Test and benchmark references
 - Owner and priority
 
+When test results were recorded with 'canary verify --results', the
+effective status (e.g. REGRESSED for failing tests) is shown next to the
+declared one and included in the JSON output as EffectiveStatus.
+
 Grouping:
 - By default, groups by aspect (CLI, API, Engine, etc.)
 - Use --group-by status to group by implementation status
@@ -68,6 +73,14 @@ Examples:
 			return fmt.Errorf("requirement not found")
 		}
 
+		lc, err := projectLifecycle(".")
+		if err != nil {
+			return err
+		}
+		if err := applyTestResults(db, tokens, lc); err != nil {
+			return fmt.Errorf("load test results: %w", err)
+		}
+
 		// Format output
 		if jsonOutput {
 			return outputTokensJSON(tokens)
@@ -103,13 +116,20 @@ func formatTokensTable(tokens []*storage.Token, groupBy string, useColor bool) s
 			buf.WriteString(fmt.Sprintf("📌 %s - %s\n", token.ReqID, token.Feature))
 
 			// Status with optional color
-			statusLine := fmt.Sprintf("   Status: %s | Aspect: %s", token.Status, token.Aspect)
+			status := token.Status
+			if eff := effectiveStatus(token); eff != token.Status {
+				status = fmt.Sprintf("%s (declared %s)", eff, token.Status)
+			}
+			statusLine := fmt.Sprintf("   Status: %s | Aspect: %s", status, token.Aspect)
 			if token.Priority > 0 {
 				statusLine += fmt.Sprintf(" | Priority: %d", token.Priority)
 			}
 			buf.WriteString(statusLine + "\n")
 
 			buf.WriteString(fmt.Sprintf("   Location: %s:%d\n", token.FilePath, token.LineNumber))
+			if token.Symbol != "" {
+				buf.WriteString(fmt.Sprintf("   Code: %s %s (lines %d-%d)\n", token.SymbolKind, token.Symbol, token.SpanStart, token.SpanEnd))
+			}
 
 			if token.Test != "" {
 				buf.WriteString(fmt.Sprintf("   Test: %s\n", token.Test))
@@ -135,7 +155,7 @@ func groupTokens(tokens []*storage.Token, groupBy string) map[string][]*storage.
 		var key string
 		switch groupBy {
 		case "status":
-			key = token.Status
+			key = effectiveStatus(token)
 		case "aspect":
 			key = token.Aspect
 		default:
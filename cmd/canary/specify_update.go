@@ -21,8 +21,10 @@ var updateCmd = &cobra.Command{
 	Short: "Update an existing requirement specification",
 	Long: `Locate and update an existing CANARY requirement specification.
 
-Supports exact ID lookup, fuzzy text search, and section-specific loading
-to minimize context usage for AI agents.
+Supports exact ID lookup, text search, and section-specific loading to
+minimize context usage for AI agents. With --search, an indexed project is
+searched by what its specs, plans, tokens and gaps say; otherwise spec
+directory names are fuzzy matched.
 
 Examples:
   canary specify update CBIN-134                     # Exact ID lookup
@@ -39,6 +41,29 @@ Examples:
 
 		// Determine lookup method
 		if searchFlag {
+			// Full-text search of spec contents when the project is
+			// indexed, directory-name fuzzy search otherwise
+			found, err := searchSpecs("specify update", query, 5)
+			if err != nil {
+				return fmt.Errorf("search specs: %w", err)
+			}
+			if len(found) > 0 {
+				fmt.Printf("Found %d matching specs:\n\n", len(found))
+				for i, m := range found {
+					fmt.Printf("  %d. %s - %s\n", i+1, m.ReqID, m.Title)
+					if m.Snippet != "" {
+						fmt.Printf("     %s\n", m.Snippet)
+					}
+				}
+				if len(found) > 1 {
+					return fmt.Errorf("multiple matches found - please use exact REQ-ID for precision")
+				}
+				specPath = filepath.Join(found[0].Dir, "spec.md")
+				fmt.Printf("\nAuto-selected: %s\n\n", found[0].ReqID)
+			}
+		}
+
+		if searchFlag && specPath == "" {
 			// Fuzzy search mode
 			matches, err := specs.FindSpecBySearch(query, 5)
 			if err != nil {
@@ -63,7 +88,7 @@ Examples:
 			} else {
 				return fmt.Errorf("multiple matches found - please use exact REQ-ID for precision")
 			}
-		} else {
+		} else if !searchFlag {
 			// Exact ID lookup
 			specPath, err = specs.FindSpecByID(query)
 			if err != nil {
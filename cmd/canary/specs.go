@@ -13,6 +13,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/storage"
 )
 
 // CANARY: REQ=CBIN-145; FEATURE="SpecsCmd"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_145_CLI_SpecsCmd; UPDATED=2025-10-17
@@ -22,7 +24,9 @@ var specsCmd = &cobra.Command{
 	Long: `Specs lists all requirement specification directories in .canary/specs/.
 
 Shows requirement ID, feature name (extracted from directory name), and paths
-to spec.md and plan.md files if they exist.
+to spec.md and plan.md files if they exist. When the project has an index,
+each spec also shows its requirement's spec status, priority and how many
+tokens it has, so specs nobody has started on stand out.
 
 Examples:
   canary specs
@@ -43,6 +47,20 @@ Examples:
 			return fmt.Errorf("read specs directory: %w", err)
 		}
 
+		// Requirement rows add spec status, priority and token counts
+		// when the project has an index
+		reqs := map[string]*storage.Requirement{}
+		if db := openRequirementGraph(); db != nil {
+			list, err := db.ListRequirements("")
+			db.Close()
+			if err != nil {
+				return fmt.Errorf("list requirements: %w", err)
+			}
+			for _, r := range list {
+				reqs[r.ReqID] = r
+			}
+		}
+
 		// Collect spec information
 		type SpecInfo struct {
 			ReqID       string `json:"req_id"`
@@ -50,6 +68,8 @@ Examples:
 			Directory   string `json:"directory"`
 			HasSpec     bool   `json:"has_spec"`
 			HasPlan     bool   `json:"has_plan"`
+
+			Requirement *storage.Requirement `json:"-"`
 		}
 
 		var specs []SpecInfo
@@ -86,12 +106,19 @@ Examples:
 				hasPlan = true
 			}
 
+			if id, ok := indexer.SpecReqID(specPath); ok {
+				reqID = id
+			}
+			if r := reqs[reqID]; r != nil && r.Title != "" {
+				featureName = r.Title
+			}
 			specs = append(specs, SpecInfo{
 				ReqID:       reqID,
 				FeatureName: featureName,
 				Directory:   dirPath,
 				HasSpec:     hasSpec,
 				HasPlan:     hasPlan,
+				Requirement: reqs[reqID],
 			})
 		}
 
@@ -114,8 +141,12 @@ Examples:
 				if i == len(specs)-1 {
 					comma = ""
 				}
-				fmt.Printf("  {\"req_id\": \"%s\", \"feature_name\": \"%s\", \"directory\": \"%s\", \"has_spec\": %t, \"has_plan\": %t}%s\n",
-					spec.ReqID, spec.FeatureName, spec.Directory, spec.HasSpec, spec.HasPlan, comma)
+				extra := ""
+				if r := spec.Requirement; r != nil {
+					extra = fmt.Sprintf(", \"spec_status\": \"%s\", \"priority\": %d, \"tokens\": %d", r.SpecStatus, r.Priority, r.Tokens)
+				}
+				fmt.Printf("  {\"req_id\": \"%s\", \"feature_name\": \"%s\", \"directory\": \"%s\", \"has_spec\": %t, \"has_plan\": %t%s}%s\n",
+					spec.ReqID, spec.FeatureName, spec.Directory, spec.HasSpec, spec.HasPlan, extra, comma)
 			}
 			fmt.Println("]")
 		} else {
@@ -142,6 +173,13 @@ Examples:
 				} else {
 					fmt.Printf("   (no spec or plan files)\n")
 				}
+				if r := spec.Requirement; r != nil {
+					tokens := fmt.Sprintf("%d", r.Tokens)
+					if r.Tokens == 0 {
+						tokens = "none yet"
+					}
+					fmt.Printf("   Spec Status: %s | Priority: %d | Tokens: %s\n", r.SpecStatus, r.Priority, tokens)
+				}
 				fmt.Println()
 			}
 
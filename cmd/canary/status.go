@@ -11,7 +11,15 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/coverage"
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/gitstale"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/mutate"
+	"go.devnw.com/canary/internal/scanner"
+	"go.devnw.com/canary/internal/specs"
 	"go.devnw.com/canary/internal/storage"
+	"go.devnw.com/canary/internal/token"
 )
 
 // CANARY: REQ=CBIN-CLI-001; FEATURE="StatusCmd"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_CLI_001_CLI_StatusCmd; UPDATED=2025-10-16
@@ -26,6 +34,25 @@ Shows:
 - Completion percentage
 - List of incomplete work
 
+When test results were recorded with 'canary verify --results', statuses
+are the effective ones: a TESTED or BENCHED feature whose tests failed is
+REGRESSED, and one whose tests didn't run only keeps what passing tests
+support.
+
+When coverage was recorded with 'canary coverage', the line coverage of
+the requirement and of each feature is shown, and after 'canary verify
+--mutate' the mutation score: the share of mutants of a feature's code its
+tests caught. Features whose tests let mutants survive are highlighted.
+
+Requirements this one depends on, directly or through other requirements,
+are listed with whether they are complete enough, followed by those that
+depend on it. Dependencies come from spec.md Dependencies sections and token
+DEPENDS_ON and BLOCKS fields, as last indexed.
+
+In a git repository, completed features whose code or named tests changed
+in a later commit than the token itself are listed as warnings: the token
+may no longer describe the code.
+
 Examples:
   canary status CBIN-133
   canary status CBIN-133 --no-color`,
@@ -60,11 +87,29 @@ Examples:
 			return fmt.Errorf("requirement not found")
 		}
 
+		lc, err := projectLifecycle(".")
+		if err != nil {
+			return err
+		}
+		if err := applyTestResults(db, tokens, lc); err != nil {
+			return fmt.Errorf("load test results: %w", err)
+		}
+		if err := db.ApplyCoverage("", tokens); err != nil {
+			return fmt.Errorf("load coverage: %w", err)
+		}
+		if err := db.ApplyMutationScores("", tokens); err != nil {
+			return fmt.Errorf("load mutation scores: %w", err)
+		}
+
 		// Calculate statistics
-		stats := calculateStats(tokens)
+		stats := calculateStats(tokens, lc)
 
 		// Display summary
-		displayStatusSummary(reqID, stats, tokens)
+		displayStatusSummary(reqID, stats, tokens, lc)
+		if err := displayDependencies(db, reqID, lc); err != nil {
+			return fmt.Errorf("load dependencies: %w", err)
+		}
+		displayGitStale(gitStaleFindings(tokens, lc))
 
 		return nil
 	},
@@ -77,27 +122,44 @@ type StatusStats struct {
 	Impl      int
 	Tested    int
 	Benched   int
+	Regressed int
 	Completed int
+	Pending   int             // tokens in a pending lifecycle state
+	ByStatus  map[string]int  // effective status -> count, including custom states
+	Coverage  coverage.Counts // line coverage of the tokens' code, if recorded
+	Mutation  mutate.Score    // mutants of the tokens' code caught, if recorded
 }
 
-// calculateStats computes statistics from tokens
-func calculateStats(tokens []*storage.Token) *StatusStats {
+// calculateStats computes statistics from tokens. A token is completed when
+// its effective status satisfies the project lifecycle lc for its aspect.
+func calculateStats(tokens []*storage.Token, lc *lifecycle.Lifecycle) *StatusStats {
 	stats := &StatusStats{
-		Total: len(tokens),
+		Total:    len(tokens),
+		ByStatus: map[string]int{},
 	}
 
 	for _, token := range tokens {
-		switch token.Status {
+		status := effectiveStatus(token)
+		stats.ByStatus[status]++
+		stats.Coverage = stats.Coverage.Add(coverage.Counts{Covered: token.CoveredLines, Total: token.CoverableLines})
+		stats.Mutation = stats.Mutation.Add(mutate.Score{Killed: token.MutantsKilled, Survived: token.MutantsSurvived})
+		switch status {
 		case "STUB":
 			stats.Stub++
 		case "IMPL":
 			stats.Impl++
 		case "TESTED":
 			stats.Tested++
-			stats.Completed++
 		case "BENCHED":
 			stats.Benched++
+		case evidence.Regressed:
+			stats.Regressed++
+		}
+		switch {
+		case lc.Satisfies(status, token.Aspect):
 			stats.Completed++
+		case lc.IsPending(status):
+			stats.Pending++
 		}
 	}
 
@@ -105,7 +167,7 @@ func calculateStats(tokens []*storage.Token) *StatusStats {
 }
 
 // displayStatusSummary shows formatted progress summary
-func displayStatusSummary(reqID string, stats *StatusStats, tokens []*storage.Token) {
+func displayStatusSummary(reqID string, stats *StatusStats, tokens []*storage.Token, lc *lifecycle.Lifecycle) {
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 	red := color.New(color.FgRed).SprintFunc()
@@ -121,37 +183,220 @@ func displayStatusSummary(reqID string, stats *StatusStats, tokens []*storage.To
 
 	fmt.Printf("Progress: %s\n\n", progressBar(completionPct, 40))
 
-	// Statistics
+	// Statistics, pending states first and the rest in lifecycle order;
+	// states without tokens are left out unless they are pending or
+	// satisfying. The earliest pending state (STUB) is shown in red.
+	pendingColor := func(status string) func(a ...interface{}) string {
+		if p := lc.Pending(); len(p) > 0 && status == p[0] {
+			return red
+		}
+		return yellow
+	}
+	width := len(evidence.Regressed) + 1
+	for _, name := range lc.Names() {
+		if n := len(name) + 1; n > width {
+			width = n
+		}
+	}
 	fmt.Printf("Total:     %d tokens\n", stats.Total)
 	fmt.Printf("Completed: %s (%d%%)\n", green(fmt.Sprintf("%d", stats.Completed)), completionPct)
+	if stats.Coverage.Total > 0 {
+		fmt.Printf("Coverage:  %s\n", stats.Coverage)
+	}
+	if stats.Mutation.Total() > 0 {
+		fmt.Printf("Mutation:  %s\n", stats.Mutation)
+	}
 	fmt.Printf("In Progress:\n")
-	fmt.Printf("  • IMPL:   %s\n", yellow(fmt.Sprintf("%d", stats.Impl)))
-	fmt.Printf("  • STUB:   %s\n", red(fmt.Sprintf("%d", stats.Stub)))
+	for _, s := range lc.Pending() {
+		fmt.Printf("  • %-*s %s\n", width, s+":", pendingColor(s)(fmt.Sprintf("%d", stats.ByStatus[s])))
+	}
 	fmt.Printf("Status Breakdown:\n")
-	fmt.Printf("  • TESTED: %s\n", green(fmt.Sprintf("%d", stats.Tested)))
-	fmt.Printf("  • BENCHED: %s\n", green(fmt.Sprintf("%d", stats.Benched)))
+	for _, name := range lc.Names() {
+		if lc.IsPending(name) || (!lc.Satisfies(name, "") && stats.ByStatus[name] == 0) {
+			continue
+		}
+		fmt.Printf("  • %-*s %s\n", width, name+":", green(fmt.Sprintf("%d", stats.ByStatus[name])))
+	}
+	if stats.Regressed > 0 {
+		fmt.Printf("  • %-*s %s\n", width, evidence.Regressed+":", red(fmt.Sprintf("%d", stats.Regressed)))
+	}
 	fmt.Println()
 
+	// Line coverage per feature
+	if stats.Coverage.Total > 0 {
+		fmt.Println("Coverage by Feature:")
+		for _, r := range coverageByRequirement(tokens) {
+			for _, f := range r.Features {
+				fmt.Printf("  %-30s %s\n", f.Feature, f.Coverage)
+			}
+		}
+		fmt.Println()
+	}
+
+	// Mutation score per feature; surviving mutants mean weak tests
+	if stats.Mutation.Total() > 0 {
+		fmt.Println("Mutation Score by Feature:")
+		for _, f := range mutationByFeature(tokens) {
+			score := green(f.score.String())
+			if f.score.Survived > 0 {
+				score = red(f.score.String())
+			}
+			fmt.Printf("  %-30s %s\n", f.feature, score)
+		}
+		fmt.Println()
+	}
+
+	// List features whose tests fail
+	if stats.Regressed > 0 {
+		fmt.Println("Failing Tests:")
+		for _, token := range tokens {
+			if effectiveStatus(token) == evidence.Regressed {
+				fmt.Printf("  %s %s - %s (declared %s)\n", red(evidence.Regressed), token.Feature, token.FilePath, token.Status)
+			}
+		}
+		fmt.Println()
+	}
+
 	// List incomplete work
-	if stats.Stub > 0 || stats.Impl > 0 {
+	if stats.Pending > 0 {
 		fmt.Println("Incomplete Work:")
 		for _, token := range tokens {
-			if token.Status == "STUB" || token.Status == "IMPL" {
-				statusColor := red
-				if token.Status == "IMPL" {
-					statusColor = yellow
-				}
+			status := effectiveStatus(token)
+			if lc.IsPending(status) && !lc.Satisfies(status, token.Aspect) {
 				fmt.Printf("  %s %s - %s\n",
-					statusColor(token.Status),
+					pendingColor(status)(status),
 					token.Feature,
 					token.FilePath)
 			}
 		}
-	} else {
+	} else if stats.Regressed == 0 {
 		fmt.Println(green("✅ All features completed!"))
 	}
 }
 
+// displayDependencies lists the requirements reqID needs, marked satisfied
+// or blocking under lc, and the requirements that need it.
+func displayDependencies(db *storage.DB, reqID string, lc *lifecycle.Lifecycle) error {
+	deps, err := db.Dependencies("", reqID)
+	if err != nil {
+		return err
+	}
+	dependents, err := db.Dependents("", reqID)
+	if err != nil {
+		return err
+	}
+	if len(deps) == 0 && len(dependents) == 0 {
+		return nil
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	via := func(e storage.RequirementEdge, through string) string {
+		if e.Depth > 1 {
+			return fmt.Sprintf(" (via %s)", through)
+		}
+		return ""
+	}
+
+	if len(deps) > 0 {
+		checker := specs.NewStatusChecker(&dbTokenProvider{db: db}, lc)
+		fmt.Println()
+		fmt.Println("Dependencies:")
+		for _, e := range deps {
+			st := checker.CheckDependency(edgeDependency(e))
+			mark := green("✅")
+			if !st.IsSatisfied {
+				mark = red("❌")
+			}
+			fmt.Printf("  %s %s%s - %s\n", mark, e.Target, via(e, e.Source), st.Message)
+		}
+	}
+	if len(dependents) > 0 {
+		fmt.Println()
+		fmt.Println("Required By:")
+		for _, e := range dependents {
+			fmt.Printf("  %s%s\n", e.Source, via(e, e.Target))
+		}
+	}
+
+	return nil
+}
+
+// gitStaleFindings returns the completed tokens whose code or tests changed
+// in git after the token did. It returns nil outside a git repository.
+func gitStaleFindings(tokens []*storage.Token, lc *lifecycle.Lifecycle) []gitstale.Finding {
+	b, err := gitstale.NewBlamer(".")
+	if err != nil {
+		return nil
+	}
+
+	// Every token of the files is parsed since the next token in a file
+	// bounds the code the one before it covers
+	want := map[string]map[int]bool{}
+	hasTests := false
+	for _, t := range tokens {
+		if !lc.Satisfies(effectiveStatus(t), t.Aspect) {
+			continue
+		}
+		if want[t.FilePath] == nil {
+			want[t.FilePath] = map[int]bool{}
+		}
+		want[t.FilePath][t.LineNumber] = true
+		hasTests = hasTests || t.Test != ""
+	}
+	var toks []*token.Token
+	for path := range want {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		parsed, _ := token.Parse(path, content, lc)
+		toks = append(toks, parsed...)
+	}
+
+	var ix *evidence.Index
+	if hasTests {
+		ix, _ = scanner.EvidenceIndex(".")
+	}
+	found, err := gitstale.Check(b, toks, gitstale.Options{Index: ix})
+	if err != nil {
+		return nil
+	}
+	var out []gitstale.Finding
+	for _, f := range found {
+		if want[f.Token.File][f.Token.Line] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// displayGitStale warns about tokens that may no longer describe their code.
+func displayGitStale(found []gitstale.Finding) {
+	if len(found) == 0 {
+		return
+	}
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Println()
+	fmt.Println("Code Changed Since Token Last Touched:")
+	for _, f := range found {
+		what := "code"
+		switch {
+		case f.Test != "":
+			what = "test " + f.Test
+		case f.Symbol != "":
+			what = f.Symbol
+		}
+		fmt.Printf("  %s %s - %s:%d (token %s, %s %s)\n",
+			yellow("⚠"),
+			f.Token.Feature(),
+			f.Token.File, f.Token.Line,
+			f.TokenTime.Format("2006-01-02"),
+			what,
+			f.Changed.Format("2006-01-02"))
+	}
+}
+
 // progressBar generates a text progress bar
 func progressBar(pct int, width int) string {
 	if pct < 0 {
@@ -183,3 +428,29 @@ func init() {
 	statusCmd.Flags().Bool("no-color", false, "Disable colored output")
 	statusCmd.Flags().String("db", ".canary/canary.db", "Path to database file")
 }
+
+type featureMutation struct {
+	feature string
+	score   mutate.Score
+}
+
+// mutationByFeature sums the recorded mutation scores of tokens per
+// feature, in the order features first appear.
+func mutationByFeature(tokens []*storage.Token) []*featureMutation {
+	var out []*featureMutation
+	byFeature := map[[2]string]*featureMutation{}
+	for _, t := range tokens {
+		if t.MutantsKilled+t.MutantsSurvived == 0 {
+			continue
+		}
+		k := [2]string{t.ReqID, t.Feature}
+		f := byFeature[k]
+		if f == nil {
+			f = &featureMutation{feature: t.Feature}
+			byFeature[k] = f
+			out = append(out, f)
+		}
+		f.score = f.score.Add(mutate.Score{Killed: t.MutantsKilled, Survived: t.MutantsSurvived})
+	}
+	return out
+}
@@ -50,7 +50,7 @@ func TestCANARY_CBIN_CLI_001_CLI_StatusCmd(t *testing.T) {
 		t.Fatalf("GetTokensByReqID failed: %v", err)
 	}
 
-	stats := calculateStats(tokens)
+	stats := calculateStats(tokens, nil)
 
 	// Verify statistics
 	if stats.Total != 5 {
@@ -176,7 +176,7 @@ func TestCANARY_CBIN_CLI_001_CLI_StatusCmd_CompletionPercentage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			stats := calculateStats(tt.tokens)
+			stats := calculateStats(tt.tokens, nil)
 
 			completionPct := 0
 			if stats.Total > 0 {
@@ -198,9 +198,9 @@ func TestCANARY_CBIN_CLI_001_CLI_StatusCmd_DisplaySummary(t *testing.T) {
 		{ReqID: "TEST", Feature: "F3", Status: "TESTED", FilePath: "f3.go"},
 	}
 
-	stats := calculateStats(tokens)
+	stats := calculateStats(tokens, nil)
 
 	// Test that displayStatusSummary doesn't panic
 	// (actual output is tested manually since it uses color formatting)
-	displayStatusSummary("TEST", stats, tokens)
+	displayStatusSummary("TEST", stats, tokens, nil)
 }
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"go.devnw.com/canary/internal/config"
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/token"
+)
+
+// CANARY: REQ=CBIN-150; FEATURE="SharedParserCLI"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2026-10-17
+
+// collectTokens parses every CANARY token under root with the shared
+// indexer, honouring .gitignore, .canaryignore and scanner.exclude_paths
+// from project.yaml. Invalid tokens, including those whose STATUS isn't a
+// state of the project's lifecycle, are reported as diagnostics.
+func collectTokens(root string) ([]*token.Token, []token.Diagnostic, error) {
+	lc, err := projectLifecycle(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := indexer.Walk(indexer.Options{Root: root, ExcludePaths: excludePaths(root), Lifecycle: lc})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.Tokens, res.Diagnostics, nil
+}
+
+// excludePaths returns scanner.exclude_paths from root's project.yaml.
+func excludePaths(root string) []string {
+	cfg, err := config.Load(root)
+	if err != nil {
+		return nil
+	}
+	return cfg.Scanner.ExcludePaths
+}
@@ -0,0 +1,402 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/config"
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/mutate"
+	"go.devnw.com/canary/internal/policy"
+	"go.devnw.com/canary/internal/scanner"
+	"go.devnw.com/canary/internal/storage"
+	"go.devnw.com/canary/internal/token"
+)
+
+// CANARY: REQ=CBIN-159; FEATURE="VerifyCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2026-10-17
+var verifyCmd = &cobra.Command{
+	Use:   "verify [flags]",
+	Short: "Check test evidence and the verification policy of every token",
+	Long: `Resolve every TEST= and BENCH= name against the tests in the repository.
+
+Test functions are found per language:
+  Go           func TestX(t *testing.T), FuzzX(f *testing.F), BenchmarkX(b *testing.B) (go/parser)
+  pytest       def test_x() and class TestX in test_*.py and *_test.py
+  Jest/Vitest  describe, it, test and bench titles in *.test.*, *.spec.* and __tests__/
+  Rust         fns marked #[test], #[tokio::test] or #[bench], and Criterion bench_function names
+
+Go subtests (TestX/case), pytest node IDs (file.py::TestC::test_y) and Rust
+module paths (tests::it_works) resolve to their function.
+
+Tokens that name missing tests are reported with the status they drop to:
+BENCHED without a benchmark becomes TESTED, TESTED without a test becomes
+IMPL. The command exits 2 when any evidence is missing.
+
+With --results, tests must also have run and passed in the given go test
+-json output, JUnit XML or TAP report. A TESTED or BENCHED token with a
+failing test is REGRESSED, and tests that didn't run count as missing. The
+results are recorded in the database so 'canary status' and 'canary show'
+report the same effective status.
+
+The verification policy in .canary/project.yaml is enforced too:
+  require_test_field   TESTED and BENCHED tokens must name a TEST
+  require_bench_field  BENCHED tokens must name a BENCH
+  require_fields       fields every token must carry, e.g. [OWNER]
+  staleness_days       completed tokens must be UPDATED within this many days
+Each can be overridden per aspect and per requirement:
+
+  verification:
+    require_test_field: true
+    staleness_days: 30
+    aspects:
+      Security: {require_fields: [BENCH]}
+      API:      {require_fields: [OWNER]}
+      Docs:     {staleness_days: 180}
+    requirements:
+      CBIN-105: {staleness_days: 0}
+
+With --mutate, the tests of completed Go tokens are graded by mutation:
+conditionals are flipped, returned values zeroed and constants changed
+inside the declaration each token annotates, one at a time, and only the
+tests named in TEST= are run against each mutant (go test -overlay, so no
+file is modified). The share of mutants a failing test caught is the
+token's mutation score, recorded for 'canary status'. Tests that assert
+nothing let every mutant survive. Scores don't change the exit status.
+
+Examples:
+  canary verify
+  canary verify --format json
+  canary verify --mutate --max-mutants 10
+  go test -json ./... > results.json && canary verify --results results.json
+  canary verify --results junit.xml --results rust.tap`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootPath, _ := cmd.Flags().GetString("root")
+		format, _ := cmd.Flags().GetString("format")
+		resultPaths, _ := cmd.Flags().GetStringSlice("results")
+		dbPath, _ := cmd.Flags().GetString("db")
+		mutateTests, _ := cmd.Flags().GetBool("mutate")
+		maxMutants, _ := cmd.Flags().GetInt("max-mutants")
+		mutateTimeout, _ := cmd.Flags().GetDuration("mutate-timeout")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q (want text or json)", format)
+		}
+
+		var res *evidence.Results
+		if len(resultPaths) > 0 {
+			var err error
+			if res, err = recordResults(dbPath, resultPaths); err != nil {
+				return err
+			}
+		}
+
+		cfg, err := config.Load(rootPath)
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		lc, err := cfg.StatusLifecycle()
+		if err != nil {
+			return err
+		}
+
+		opts := indexer.Options{Root: rootPath, ExcludePaths: excludePaths(rootPath), Lifecycle: lc}
+		paths, err := indexer.Files(opts)
+		if err != nil {
+			return fmt.Errorf("list files: %w", err)
+		}
+		var toks []*token.Token
+		for _, f := range indexer.ParseFiles(paths, 0, lc) {
+			toks = append(toks, f.Tokens...)
+		}
+		ix, err := scanner.EvidenceIndex(rootPath)
+		if ix == nil {
+			return fmt.Errorf("resolve tests: %w", err)
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+		}
+
+		findings := evidence.Check(toks, ix, res, lc)
+		violations := cfg.Policy().Check(toks, lc, time.Now().UTC())
+
+		var mutations []tokenMutation
+		if mutateTests {
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if mutations, err = mutateTokens(ctx, rootPath, toks, ix, lc, maxMutants, mutateTimeout, cmd.ErrOrStderr()); err != nil {
+				return fmt.Errorf("mutate: %w", err)
+			}
+			if err := recordMutationScores(dbPath, mutations); err != nil {
+				return err
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		if format == "json" {
+			err = writeVerifyJSON(out, rootPath, findings, violations, mutations)
+		} else {
+			err = writeVerifyText(out, rootPath, findings, violations, mutations, len(toks), ix.Files())
+		}
+		if err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+
+		if len(findings) > 0 || len(violations) > 0 {
+			return &scanner.ExitError{Code: scanner.ExitVerifyFail, Err: fmt.Errorf("%d tokens with missing or failing evidence, %d policy violations", len(findings), len(violations))}
+		}
+		return nil
+	},
+}
+
+func init() {
+	verifyCmd.Flags().String("root", ".", "root directory to verify")
+	verifyCmd.Flags().String("format", "text", "output format: text or json")
+	verifyCmd.Flags().StringSlice("results", nil, "go test -json, JUnit XML or TAP report (repeatable)")
+	verifyCmd.Flags().String("db", ".canary/canary.db", "database to record test results and mutation scores in")
+	verifyCmd.Flags().Bool("mutate", false, "grade the tests of completed Go tokens by mutating their code")
+	verifyCmd.Flags().Int("max-mutants", 25, "most mutants to run per token with --mutate (0 for all)")
+	verifyCmd.Flags().Duration("mutate-timeout", mutate.DefaultTimeout, "longest test run per mutant before it counts as killed")
+}
+
+// recordResults parses the test reports at paths and stores every outcome
+// in the database at dbPath.
+func recordResults(dbPath string, paths []string) (*evidence.Results, error) {
+	res := evidence.NewResults(nil)
+	var rows []storage.TestResult
+	for _, path := range paths {
+		r, err := evidence.ReadResults(path)
+		if err != nil {
+			return nil, fmt.Errorf("read results: %w", err)
+		}
+		res.Merge(r)
+		for name, o := range r.Outcomes() {
+			rows = append(rows, storage.TestResult{Name: name, Outcome: string(o), Source: path})
+		}
+	}
+
+	// verify skips the automatic migration since it doesn't need the
+	// database without --results
+	if err := storage.AutoMigrate(dbPath); err != nil {
+		return nil, fmt.Errorf("migrate database: %w", err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+	if err := db.RecordTestResults(rows); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// applyTestResults sets EffectiveStatus on tokens from the test outcomes
+// recorded by `canary verify --results`, judged by lc. Nothing changes when
+// no results were recorded.
+func applyTestResults(db *storage.DB, tokens []*storage.Token, lc *lifecycle.Lifecycle) error {
+	outcomes, err := db.TestOutcomes("")
+	if err != nil {
+		return err
+	}
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	m := make(map[string]evidence.Outcome, len(outcomes))
+	for name, o := range outcomes {
+		m[name] = evidence.Outcome(o)
+	}
+	res := evidence.NewResults(m)
+	for _, t := range tokens {
+		t.EffectiveStatus = res.Evaluate(t.Status, t.Aspect, token.SplitList(t.Test), token.SplitList(t.Bench), lc).Status
+	}
+	return nil
+}
+
+// effectiveStatus returns the token's status after test results are taken
+// into account.
+func effectiveStatus(t *storage.Token) string {
+	if t.EffectiveStatus != "" {
+		return t.EffectiveStatus
+	}
+	return t.Status
+}
+
+// relPath returns path relative to root with forward slashes.
+func relPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		path = rel
+	}
+	return filepath.ToSlash(path)
+}
+
+func writeVerifyText(w io.Writer, root string, findings []evidence.Finding, violations []policy.Violation, mutations []tokenMutation, tokens, files int) error {
+	for _, f := range findings {
+		var problems []string
+		add := func(what string, tests, benches []string) {
+			var names []string
+			for _, n := range tests {
+				names = append(names, "TEST="+n)
+			}
+			for _, n := range benches {
+				names = append(names, "BENCH="+n)
+			}
+			if len(names) > 0 {
+				problems = append(problems, what+": "+strings.Join(names, ", "))
+			}
+		}
+		add("not found", f.MissingTests, f.MissingBenches)
+		add("failed", f.Failing, nil)
+		add("did not run", f.NotRunTests, f.NotRunBenches)
+
+		change := f.Status
+		if f.Effective != f.Status {
+			change += " -> " + f.Effective
+		}
+		tok := f.Token
+		if _, err := fmt.Fprintf(w, "%s:%d: %s %q %s (%s)\n",
+			relPath(root, tok.File), tok.Line, tok.ReqID(), tok.Feature(), strings.Join(problems, "; "), change); err != nil {
+			return err
+		}
+	}
+	for _, v := range violations {
+		tok := v.Token
+		if _, err := fmt.Fprintf(w, "%s:%d: %s %q %s: %s (%s)\n",
+			relPath(root, tok.File), tok.Line, tok.ReqID(), tok.Feature(), v.Rule, v.Message, v.Scope); err != nil {
+			return err
+		}
+	}
+	var total mutate.Score
+	for _, m := range mutations {
+		tok := m.Token
+		loc := fmt.Sprintf("%s:%d: %s %q", relPath(root, tok.File), tok.Line, tok.ReqID(), tok.Feature())
+		if m.Err != nil {
+			if _, err := fmt.Fprintf(w, "%s not mutated: %v\n", loc, m.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		total = total.Add(m.Score)
+		if _, err := fmt.Fprintf(w, "%s mutation score %s\n", loc, m.Score); err != nil {
+			return err
+		}
+		for _, r := range m.survivors() {
+			if _, err := fmt.Fprintf(w, "  survived %s:%d: %s %s\n", relPath(root, tok.File), r.Line, r.Kind, r.Description); err != nil {
+				return err
+			}
+		}
+	}
+	if len(mutations) > 0 {
+		if _, err := fmt.Fprintf(w, "%d tokens mutated: %s\n", len(mutations), total); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d tokens checked against %d test files: %d with missing or failing evidence, %d policy violations\n",
+		tokens, files, len(findings), len(violations))
+	return err
+}
+
+type verifyJSON struct {
+	File           string                `json:"file"`
+	Line           int                   `json:"line"`
+	Req            string                `json:"req"`
+	Feature        string                `json:"feature"`
+	Aspect         string                `json:"aspect"`
+	Status         string                `json:"status"`
+	Effective      string                `json:"effective_status"`
+	MissingTests   []string              `json:"missing_tests,omitempty"`
+	MissingBenches []string              `json:"missing_benches,omitempty"`
+	Failing        []string              `json:"failing,omitempty"`
+	NotRunTests    []string              `json:"not_run_tests,omitempty"`
+	NotRunBenches  []string              `json:"not_run_benches,omitempty"`
+	Violations     []verifyViolationJSON `json:"violations,omitempty"`
+	Mutation       *verifyMutationJSON   `json:"mutation,omitempty"`
+}
+
+type verifyMutationJSON struct {
+	mutate.Score
+	Percent  float64         `json:"percent"`
+	Tests    []string        `json:"tests"`
+	Survived []mutate.Result `json:"survived_mutants,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+type verifyViolationJSON struct {
+	Rule    string `json:"rule"`
+	Scope   string `json:"scope"`
+	Message string `json:"message"`
+}
+
+// writeVerifyJSON writes one entry per token with missing or failing
+// evidence, policy violations or a mutation score, ordered by file and line.
+func writeVerifyJSON(w io.Writer, root string, findings []evidence.Finding, violations []policy.Violation, mutations []tokenMutation) error {
+	out := []*verifyJSON{}
+	byToken := map[*token.Token]*verifyJSON{}
+	entry := func(tok *token.Token) *verifyJSON {
+		if e, ok := byToken[tok]; ok {
+			return e
+		}
+		e := &verifyJSON{
+			File:      relPath(root, tok.File),
+			Line:      tok.Line,
+			Req:       tok.ReqID(),
+			Feature:   tok.Feature(),
+			Aspect:    tok.Aspect(),
+			Status:    tok.Status(),
+			Effective: tok.Status(),
+		}
+		byToken[tok] = e
+		out = append(out, e)
+		return e
+	}
+	for _, f := range findings {
+		e := entry(f.Token)
+		e.Status = f.Status
+		e.Effective = f.Effective
+		e.MissingTests = f.MissingTests
+		e.MissingBenches = f.MissingBenches
+		e.Failing = f.Failing
+		e.NotRunTests = f.NotRunTests
+		e.NotRunBenches = f.NotRunBenches
+	}
+	for _, v := range violations {
+		e := entry(v.Token)
+		e.Violations = append(e.Violations, verifyViolationJSON{Rule: v.Rule, Scope: v.Scope, Message: v.Message})
+	}
+	for _, m := range mutations {
+		mj := &verifyMutationJSON{Score: m.Score, Percent: m.Score.Percent(), Tests: m.Tests, Survived: m.survivors()}
+		if m.Err != nil {
+			mj.Error = m.Err.Error()
+		}
+		entry(m.Token).Mutation = mj
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].File != out[j].File {
+			return out[i].File < out[j].File
+		}
+		return out[i].Line < out[j].Line
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
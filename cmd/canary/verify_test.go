@@ -0,0 +1,126 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/policy"
+	"go.devnw.com/canary/internal/scanner"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// TestCANARY_CBIN_160_CLI_VerifyResults verifies that results recorded by
+// `canary verify --results` make status report failing tests as REGRESSED.
+func TestCANARY_CBIN_160_CLI_VerifyResults(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "canary.db")
+	report := filepath.Join(dir, "results.json")
+	if err := os.WriteFile(report, []byte(`{"Action":"pass","Test":"TestGood"}
+{"Action":"fail","Test":"TestBad"}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := recordResults(dbPath, []string{report})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Len() != 2 {
+		t.Fatalf("recorded %d results, want 2", res.Len())
+	}
+
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, tok := range []*storage.Token{
+		{ReqID: "CBIN-300", Feature: "Good", Status: "TESTED", Test: "TestGood", FilePath: "a.go"},
+		{ReqID: "CBIN-300", Feature: "Bad", Status: "BENCHED", Test: "TestBad", Bench: "BenchmarkBad", FilePath: "b.go"},
+		{ReqID: "CBIN-300", Feature: "Gone", Status: "TESTED", Test: "TestGone", FilePath: "c.go"},
+	} {
+		if err := db.UpsertToken(tok); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tokens, err := db.GetTokensByReqID("CBIN-300")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := applyTestResults(db, tokens, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"Good": "TESTED", "Bad": evidence.Regressed, "Gone": "IMPL"}
+	for _, tok := range tokens {
+		if got := effectiveStatus(tok); got != want[tok.Feature] {
+			t.Errorf("%s: effective status %s, want %s", tok.Feature, got, want[tok.Feature])
+		}
+	}
+
+	stats := calculateStats(tokens, nil)
+	if stats.Regressed != 1 || stats.Completed != 1 || stats.Impl != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestVerifyCmd_Policy(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".canary/project.yaml": `verification:
+  require_test_field: true
+  aspects:
+    API: {require_fields: [OWNER]}
+`,
+		"api.go": "package p\n" +
+			`// CANARY: REQ=CBIN-300; FEATURE="Login"; ASPECT=API; STATUS=TESTED; TEST=TestLogin; UPDATED=2026-10-15` + "\n" +
+			`// CANARY: REQ=CBIN-300; FEATURE="Logout"; ASPECT=API; STATUS=IMPL; OWNER=auth; UPDATED=2026-10-15` + "\n",
+		"api_test.go": "package p\n\nimport \"testing\"\n\nfunc TestLogin(t *testing.T) {}\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	verifyCmd.SetOut(&out)
+	t.Cleanup(func() {
+		verifyCmd.SetOut(nil)
+		_ = verifyCmd.Flags().Set("root", ".")
+		_ = verifyCmd.Flags().Set("format", "text")
+	})
+	_ = verifyCmd.Flags().Set("root", root)
+	_ = verifyCmd.Flags().Set("format", "json")
+
+	err := verifyCmd.RunE(verifyCmd, nil)
+	var exitErr *scanner.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != scanner.ExitVerifyFail {
+		t.Fatalf("err = %v, want exit %d", err, scanner.ExitVerifyFail)
+	}
+
+	var entries []verifyJSON
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("%v: %s", err, out.String())
+	}
+	if len(entries) != 1 || entries[0].Feature != "Login" || len(entries[0].Violations) != 1 {
+		t.Fatalf("entries = %s", out.String())
+	}
+	if v := entries[0].Violations[0]; v.Rule != policy.RuleRequireField || v.Scope != "aspect API" {
+		t.Errorf("violation = %+v", v)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// CANARY: REQ=CBIN-154; FEATURE="WatchCmd"; ASPECT=CLI; STATUS=IMPL; OWNER=canary; UPDATED=2026-10-17
+var watchCmd = &cobra.Command{
+	Use:   "watch [flags]",
+	Short: "Keep the CANARY database up to date while you edit",
+	Long: `Index the codebase once, then watch it and re-index changed files as they
+are saved, so status, next and show always reflect the working tree.
+
+Events are debounced: a burst of saves is indexed as one batch after the tree
+has been quiet for --debounce. Each batch prints a compact line with the token
+changes and how the per-status totals moved. The same ignore rules as index
+apply (.gitignore, .canaryignore and scanner.exclude_paths), and the database
+file itself is never watched.
+
+Press Ctrl+C to stop.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbPath, _ := cmd.Flags().GetString("db")
+		rootPath, _ := cmd.Flags().GetString("root")
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+
+		walk, err := indexOptions(rootPath, dbPath)
+		if err != nil {
+			return err
+		}
+		db, err := storage.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer db.Close()
+
+		commitHash, branch := gitHead()
+		opts := indexer.SyncOptions{
+			Options:    walk,
+			CommitHash: commitHash,
+			Branch:     branch,
+		}
+
+		stats, err := indexer.Sync(db, opts)
+		if err != nil {
+			return fmt.Errorf("index tokens: %w", err)
+		}
+		printDiagnostics(stats)
+
+		counts, err := db.CountByStatus("")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Indexed %d files (%d parsed), %s\n", stats.Files, stats.Parsed, formatCounts(counts, nil))
+		fmt.Printf("Watching %s (Ctrl+C to stop)\n", rootPath)
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		err = indexer.Watch(ctx, db, indexer.WatchOptions{
+			SyncOptions: opts,
+			Debounce:    debounce,
+			OnSync: func(paths []string, stats *indexer.SyncStats) {
+				printDiagnostics(stats)
+				if stats.Added+stats.Changed+stats.Moved+stats.Removed == 0 {
+					return
+				}
+				next, err := db.CountByStatus("")
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+					return
+				}
+				fmt.Printf("[%s] %s: +%d ~%d >%d -%d | %s\n",
+					time.Now().Format("15:04:05"), describePaths(rootPath, paths),
+					stats.Added, stats.Changed, stats.Moved, stats.Removed,
+					formatCounts(next, counts))
+				counts = next
+			},
+			OnError: func(err error) {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("watch: %w", err)
+		}
+
+		fmt.Println("\nStopped watching")
+		return nil
+	},
+}
+
+func init() {
+	watchCmd.Flags().String("db", ".canary/canary.db", "path to database file")
+	watchCmd.Flags().String("root", ".", "root directory to watch")
+	watchCmd.Flags().Duration("debounce", indexer.DefaultDebounce, "quiet period before re-indexing changed files")
+}
+
+// gitHead returns the current commit hash and branch, or empty strings
+// outside a git repository.
+func gitHead() (commitHash, branch string) {
+	if output, err := exec.Command("git", "rev-parse", "HEAD").Output(); err == nil {
+		commitHash = strings.TrimSpace(string(output))
+	}
+	if output, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output(); err == nil {
+		branch = strings.TrimSpace(string(output))
+	}
+	return commitHash, branch
+}
+
+// indexOptions returns the walk options for indexing root into the
+// database at dbPath: the project's exclude_paths plus the database itself,
+// and the project's status lifecycle.
+func indexOptions(root, dbPath string) (indexer.Options, error) {
+	lc, err := projectLifecycle(root)
+	if err != nil {
+		return indexer.Options{}, err
+	}
+	return indexer.Options{
+		Root:         root,
+		ExcludePaths: append(excludePaths(root), dbExclude(root, dbPath)...),
+		Lifecycle:    lc,
+	}, nil
+}
+
+// dbExclude returns an ignore pattern for the database and its journal
+// files when they live under root, so writing the index does not trigger
+// another pass.
+func dbExclude(root, dbPath string) []string {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil
+	}
+	absDB, err := filepath.Abs(dbPath)
+	if err != nil {
+		return nil
+	}
+	rel, err := filepath.Rel(absRoot, absDB)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	return []string{"/" + filepath.ToSlash(rel) + "*"}
+}
+
+func printDiagnostics(stats *indexer.SyncStats) {
+	for _, d := range stats.Diagnostics {
+		fmt.Fprintf(os.Stderr, "Warning: skipping token at %v\n", d)
+	}
+}
+
+// describePaths names the single changed file or counts them.
+func describePaths(root string, paths []string) string {
+	if len(paths) != 1 {
+		return fmt.Sprintf("%d paths", len(paths))
+	}
+	if rel, err := filepath.Rel(root, paths[0]); err == nil {
+		return rel
+	}
+	return paths[0]
+}
+
+// formatCounts renders per-status totals, with the difference from prev
+// when prev is given, e.g. "IMPL 41 (+1) TESTED 80 (-1)".
+func formatCounts(counts, prev map[string]int) string {
+	seen := map[string]bool{}
+	var statuses []string
+	for _, m := range []map[string]int{counts, prev} {
+		for s := range m {
+			if !seen[s] {
+				seen[s] = true
+				statuses = append(statuses, s)
+			}
+		}
+	}
+	sort.Strings(statuses)
+
+	parts := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		part := fmt.Sprintf("%s %d", s, counts[s])
+		if prev != nil {
+			if d := counts[s] - prev[s]; d != 0 {
+				part += fmt.Sprintf(" (%+d)", d)
+			}
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "no tokens"
+	}
+	return strings.Join(parts, " ")
+}
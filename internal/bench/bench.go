@@ -0,0 +1,254 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package bench reads `go test -bench` output, plain or -json, and the
+// benchstat-compatible Go benchmark format, and compares benchmark results
+// between two runs.
+package bench
+
+// CANARY: REQ=CBIN-167; FEATURE="BenchResults"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_167_Engine_ParseBench
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultThreshold is the slowdown, in percent, compare flags when no
+// threshold is configured.
+const DefaultThreshold = 10.0
+
+// Result is one benchmark averaged over every run of it in a report.
+type Result struct {
+	Name        string  `json:"name"` // without the -GOMAXPROCS suffix
+	Package     string  `json:"package,omitempty"`
+	Runs        int     `json:"runs"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	Mem         bool    `json:"benchmem"` // B/op and allocs/op were reported (-benchmem)
+	BytesPerOp  float64 `json:"bytes_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+}
+
+// Read loads the benchmarks in the report at path.
+func Read(path string) ([]Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read benchmark report: %w", err)
+	}
+	defer f.Close()
+
+	res, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return res, nil
+}
+
+// Parse reads benchmark results from `go test -bench` output, with or
+// without -json, or a file in the Go benchmark format benchstat reads.
+// Repeated runs of a benchmark (-count) are averaged. Results are sorted by
+// package and name.
+func Parse(r io.Reader) ([]Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("{")) {
+		if data, err = jsonOutput(trimmed); err != nil {
+			return nil, err
+		}
+	}
+
+	type sum struct {
+		Result
+		mem int // runs that reported B/op and allocs/op
+	}
+	sums := map[[2]string]*sum{}
+	var order [][2]string
+	pkg := ""
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if v, ok := strings.CutPrefix(line, "pkg:"); ok {
+			pkg = strings.TrimSpace(v)
+			continue
+		}
+		name, metrics, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+		if _, ok := metrics["ns/op"]; !ok {
+			return nil, fmt.Errorf("line %d: %s reports no ns/op", n, name)
+		}
+
+		k := [2]string{pkg, name}
+		s := sums[k]
+		if s == nil {
+			s = &sum{Result: Result{Name: name, Package: pkg}}
+			sums[k] = s
+			order = append(order, k)
+		}
+		s.Runs++
+		s.NsPerOp += metrics["ns/op"]
+		b, hasB := metrics["B/op"]
+		a, hasA := metrics["allocs/op"]
+		if hasB || hasA {
+			s.mem++
+			s.BytesPerOp += b
+			s.AllocsPerOp += a
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]Result, 0, len(order))
+	for _, k := range order {
+		s := sums[k]
+		s.NsPerOp /= float64(s.Runs)
+		if s.mem > 0 {
+			s.Mem = true
+			s.BytesPerOp /= float64(s.mem)
+			s.AllocsPerOp /= float64(s.mem)
+		}
+		out = append(out, s.Result)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Package != out[j].Package {
+			return out[i].Package < out[j].Package
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// jsonOutput joins the output of every event in a `go test -json` stream.
+// Benchmark lines can be split over several events.
+func jsonOutput(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var ev struct {
+			Action string
+			Output string
+		}
+		if err := dec.Decode(&ev); err == io.EOF {
+			return out.Bytes(), nil
+		} else if err != nil {
+			return nil, fmt.Errorf("parse go test -json: %w", err)
+		}
+		if ev.Action == "output" {
+			out.WriteString(ev.Output)
+		}
+	}
+}
+
+var procsRe = regexp.MustCompile(`-\d+$`)
+
+// parseLine reads a benchmark result line:
+//
+//	BenchmarkName-8   1000   1234 ns/op   256 B/op   3 allocs/op
+func parseLine(line string) (string, map[string]float64, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || len(fields)%2 != 0 || !isBenchmark(fields[0]) {
+		return "", nil, false
+	}
+	if _, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return "", nil, false
+	}
+	metrics := map[string]float64{}
+	for i := 2; i < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return "", nil, false
+		}
+		metrics[fields[i+1]] = v
+	}
+	return procsRe.ReplaceAllString(fields[0], ""), metrics, true
+}
+
+// isBenchmark reports whether name is a Go benchmark name, which like
+// `go test` requires the character after "Benchmark" to not be lowercase.
+func isBenchmark(name string) bool {
+	rest, ok := strings.CutPrefix(name, "Benchmark")
+	if !ok {
+		return false
+	}
+	return rest == "" || rest[0] < 'a' || rest[0] > 'z'
+}
+
+// Matches reports whether the benchmark name, e.g. "BenchmarkParse/small",
+// is the one a BENCH= reference names or one of its sub-benchmarks.
+// Qualified references such as "parser.BenchmarkParse" match by their last
+// element.
+func Matches(ref, name string) bool {
+	ref = strings.TrimSpace(ref)
+	if i := strings.LastIndexAny(ref, ".:"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	return ref != "" && (name == ref || strings.HasPrefix(name, ref+"/"))
+}
+
+// Metric is a measurement compare checks.
+type Metric string
+
+const (
+	NsPerOp     Metric = "ns/op"
+	BytesPerOp  Metric = "B/op"
+	AllocsPerOp Metric = "allocs/op"
+)
+
+// Value returns the measurement m of r.
+func (r Result) Value(m Metric) float64 {
+	switch m {
+	case BytesPerOp:
+		return r.BytesPerOp
+	case AllocsPerOp:
+		return r.AllocsPerOp
+	}
+	return r.NsPerOp
+}
+
+// Delta is the change of one metric between a baseline and a later run.
+type Delta struct {
+	Metric  Metric  `json:"metric"`
+	Base    float64 `json:"base"`
+	Current float64 `json:"current"`
+	Percent float64 `json:"percent"` // positive when the current run is worse
+}
+
+// Compare returns the change of each metric measured in both base and cur.
+// B/op and allocs/op are left out unless both runs used -benchmem.
+func Compare(base, cur Result) []Delta {
+	metrics := []Metric{NsPerOp}
+	if base.Mem && cur.Mem {
+		metrics = append(metrics, BytesPerOp, AllocsPerOp)
+	}
+	var out []Delta
+	for _, m := range metrics {
+		b, c := base.Value(m), cur.Value(m)
+		d := Delta{Metric: m, Base: b, Current: c}
+		switch {
+		case b != 0:
+			d.Percent = (c - b) * 100 / b
+		case c == 0:
+			continue
+		default:
+			// allocating where the baseline did not is always a regression
+			d.Percent = 100
+		}
+		out = append(out, d)
+	}
+	return out
+}
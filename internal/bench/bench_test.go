@@ -0,0 +1,90 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package bench
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const textOutput = `goos: linux
+goarch: amd64
+pkg: example.com/parser
+cpu: Intel(R) Xeon(R)
+BenchmarkParse-8         	  100000	      1000 ns/op	     256 B/op	       4 allocs/op
+BenchmarkParse-8         	  100000	      1200 ns/op	     256 B/op	       4 allocs/op
+BenchmarkParse/small-8   	 1000000	       150 ns/op
+Benchmarkhelper          	     100	        10 ns/op
+PASS
+ok  	example.com/parser	3.2s
+`
+
+// the same benchmark as `go test -json` splits it over two events
+const jsonReport = `{"Action":"start","Package":"example.com/parser"}
+{"Action":"output","Package":"example.com/parser","Output":"pkg: example.com/parser\n"}
+{"Action":"output","Package":"example.com/parser","Test":"BenchmarkRender","Output":"BenchmarkRender-4   \t"}
+{"Action":"output","Package":"example.com/parser","Test":"BenchmarkRender","Output":"   50000\t      2000 ns/op\n"}
+{"Action":"pass","Package":"example.com/parser"}
+`
+
+// TestCANARY_CBIN_167_Engine_ParseBench verifies go test -bench output,
+// plain and -json, is averaged per benchmark and compared against a
+// baseline.
+func TestCANARY_CBIN_167_Engine_ParseBench(t *testing.T) {
+	got, err := Parse(strings.NewReader(textOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Result{
+		{Name: "BenchmarkParse", Package: "example.com/parser", Runs: 2, NsPerOp: 1100, Mem: true, BytesPerOp: 256, AllocsPerOp: 4},
+		{Name: "BenchmarkParse/small", Package: "example.com/parser", Runs: 1, NsPerOp: 150},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("text:\n got %+v\nwant %+v", got, want)
+	}
+
+	got, err = Parse(strings.NewReader(jsonReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != "BenchmarkRender" || got[0].NsPerOp != 2000 {
+		t.Errorf("json: %+v", got)
+	}
+
+	if _, err := Parse(strings.NewReader("BenchmarkX-8 10 5 MB/s\n")); err == nil {
+		t.Error("benchmark without ns/op accepted")
+	}
+
+	for _, tt := range []struct {
+		ref, name string
+		want      bool
+	}{
+		{"BenchmarkParse", "BenchmarkParse", true},
+		{"BenchmarkParse", "BenchmarkParse/small", true},
+		{"parser.BenchmarkParse", "BenchmarkParse", true},
+		{"BenchmarkParse", "BenchmarkParser", false},
+	} {
+		if got := Matches(tt.ref, tt.name); got != tt.want {
+			t.Errorf("Matches(%q, %q) = %v", tt.ref, tt.name, got)
+		}
+	}
+
+	base := Result{NsPerOp: 1000, Mem: true, BytesPerOp: 0, AllocsPerOp: 2}
+	cur := Result{NsPerOp: 1250, Mem: true, BytesPerOp: 64, AllocsPerOp: 2}
+	wantDeltas := []Delta{
+		{NsPerOp, 1000, 1250, 25},
+		{BytesPerOp, 0, 64, 100},
+		{AllocsPerOp, 2, 2, 0},
+	}
+	if d := Compare(base, cur); !reflect.DeepEqual(d, wantDeltas) {
+		t.Errorf("Compare = %+v, want %+v", d, wantDeltas)
+	}
+	cur.Mem = false
+	if d := Compare(base, cur); len(d) != 1 {
+		t.Errorf("Compare without -benchmem = %+v, want ns/op only", d)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/policy"
 	"gopkg.in/yaml.v3"
 )
 
@@ -30,10 +32,60 @@ type ProjectConfig struct {
 		RequireTestField  bool `yaml:"require_test_field"`
 		RequireBenchField bool `yaml:"require_bench_field"`
 		StalenessDays     int  `yaml:"staleness_days"`
+		// RequireFields lists fields every token must carry, e.g. OWNER
+		RequireFields []string `yaml:"require_fields"`
+		// MinCoverage is the line coverage percentage completed features
+		// need in `canary coverage` and `scan --coverage`; 0 turns it off
+		MinCoverage float64 `yaml:"min_coverage"`
+		// BenchRegression is the slowdown in percent `canary bench
+		// compare` allows; 0 uses the default of 10
+		BenchRegression float64 `yaml:"bench_regression"`
+		// Aspects and Requirements override the rules above for the
+		// tokens of one aspect or requirement
+		Aspects      map[string]policy.Rule `yaml:"aspects"`
+		Requirements map[string]policy.Rule `yaml:"requirements"`
+		Claims       struct {
+			Implemented    []string `yaml:"implemented"`
+			NotImplemented []string `yaml:"not_implemented"`
+		} `yaml:"claims"`
 	} `yaml:"verification"`
 	Agent struct {
 		DefaultModel string `yaml:"default_model"`
 	} `yaml:"agent"`
+	Lifecycle *lifecycle.Lifecycle `yaml:"lifecycle"`
+}
+
+// Policy returns the verification policy: the project-wide rules with their
+// per-aspect and per-requirement overrides.
+func (c *ProjectConfig) Policy() *policy.Policy {
+	if c == nil {
+		return &policy.Policy{}
+	}
+	v := c.Verification
+	return &policy.Policy{
+		Project: policy.Rule{
+			RequireTestField:  &v.RequireTestField,
+			RequireBenchField: &v.RequireBenchField,
+			RequireFields:     v.RequireFields,
+			StalenessDays:     &v.StalenessDays,
+			MinCoverage:       &v.MinCoverage,
+			BenchRegression:   &v.BenchRegression,
+		},
+		Aspects:      v.Aspects,
+		Requirements: v.Requirements,
+	}
+}
+
+// StatusLifecycle returns the configured status lifecycle, or the default
+// lifecycle when project.yaml has no lifecycle section.
+func (c *ProjectConfig) StatusLifecycle() (*lifecycle.Lifecycle, error) {
+	if c == nil || c.Lifecycle == nil {
+		return lifecycle.Default(), nil
+	}
+	if err := c.Lifecycle.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid lifecycle: %w", err)
+	}
+	return c.Lifecycle, nil
 }
 
 // Load reads and parses the project.yaml configuration file
@@ -0,0 +1,261 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package coverage reads line coverage from Go cover profiles, LCOV
+// tracefiles and Cobertura XML reports, and measures the coverage of the
+// code a CANARY token annotates.
+package coverage
+
+// CANARY: REQ=CBIN-166; FEATURE="CoverageProfiles"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_166_Engine_ParseProfiles
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Counts is the number of instrumented lines in some code and how many of
+// them ran.
+type Counts struct {
+	Covered int `json:"covered"`
+	Total   int `json:"total"`
+}
+
+// Add returns the sum of c and o.
+func (c Counts) Add(o Counts) Counts {
+	return Counts{Covered: c.Covered + o.Covered, Total: c.Total + o.Total}
+}
+
+// Percent returns the covered share of lines, 0 when nothing is
+// instrumented.
+func (c Counts) Percent() float64 {
+	if c.Total == 0 {
+		return 0
+	}
+	return float64(c.Covered) * 100 / float64(c.Total)
+}
+
+// String formats c as e.g. "82.5% (33/40 lines)".
+func (c Counts) String() string {
+	return fmt.Sprintf("%.1f%% (%d/%d lines)", c.Percent(), c.Covered, c.Total)
+}
+
+// Profile is the hit count of every instrumented line, by file.
+type Profile struct {
+	files map[string]map[int]int
+	// resolved caches the profile file each looked up path maps to
+	resolved map[string]string
+}
+
+// New returns an empty profile.
+func New() *Profile {
+	return &Profile{files: map[string]map[int]int{}, resolved: map[string]string{}}
+}
+
+// Read loads a coverage report, detecting its format.
+func Read(path string) (*Profile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read coverage profile: %w", err)
+	}
+	p, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse reads a Go cover profile, an LCOV tracefile or a Cobertura XML
+// report.
+func Parse(data []byte) (*Profile, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("mode:")):
+		return parseGo(trimmed)
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return parseCobertura(trimmed)
+	case bytes.HasPrefix(trimmed, []byte("TN:")) || bytes.HasPrefix(trimmed, []byte("SF:")):
+		return parseLCOV(trimmed)
+	}
+	return nil, fmt.Errorf("unrecognized coverage format (want a Go cover profile, LCOV or Cobertura XML)")
+}
+
+// Merge adds the hits of o to p.
+func (p *Profile) Merge(o *Profile) {
+	for f, lines := range o.files {
+		for l, n := range lines {
+			p.hit(f, l, n)
+		}
+	}
+	p.resolved = map[string]string{}
+}
+
+// Files returns the number of files in the profile.
+func (p *Profile) Files() int { return len(p.files) }
+
+// Has reports whether the profile has data for file, matched as Lines
+// matches it.
+func (p *Profile) Has(file string) bool { return p.resolve(file) != "" }
+
+func (p *Profile) hit(file string, line, n int) {
+	file = path.Clean(strings.ReplaceAll(file, "\\", "/"))
+	lines := p.files[file]
+	if lines == nil {
+		lines = map[int]int{}
+		p.files[file] = lines
+	}
+	if cur, ok := lines[line]; !ok || n > cur {
+		lines[line] = n
+	}
+}
+
+// Lines counts the instrumented and covered lines from through to of file.
+// Profiles name files differently (Go by import path, LCOV often by
+// absolute path), so file matches the profile entry sharing the longest
+// path suffix with it.
+func (p *Profile) Lines(file string, from, to int) Counts {
+	lines := p.files[p.resolve(file)]
+	var c Counts
+	for l := from; l <= to; l++ {
+		if n, ok := lines[l]; ok {
+			c.Total++
+			if n > 0 {
+				c.Covered++
+			}
+		}
+	}
+	return c
+}
+
+// Span counts the lines of a token's span from through to of file,
+// leaving out the token's own lines tokFrom through tokTo, which an
+// enclosing declaration includes.
+func (p *Profile) Span(file string, from, to, tokFrom, tokTo int) Counts {
+	if tokTo < from || tokFrom > to {
+		return p.Lines(file, from, to)
+	}
+	return p.Lines(file, from, tokFrom-1).Add(p.Lines(file, tokTo+1, to))
+}
+
+func (p *Profile) resolve(file string) string {
+	file = strings.TrimPrefix(path.Clean(strings.ReplaceAll(file, "\\", "/")), "./")
+	if r, ok := p.resolved[file]; ok {
+		return r
+	}
+	best := ""
+	if _, ok := p.files[file]; ok {
+		best = file
+	} else {
+		for f := range p.files {
+			if (strings.HasSuffix(f, "/"+file) || strings.HasSuffix(file, "/"+f)) && len(f) > len(best) {
+				best = f
+			}
+		}
+	}
+	p.resolved[file] = best
+	return best
+}
+
+var goBlockRe = regexp.MustCompile(`^(.+):(\d+)\.\d+,(\d+)\.(\d+) (\d+) (\d+)$`)
+
+// parseGo reads `go test -coverprofile` output: one block per line as
+// file:startLine.col,endLine.col statements count.
+func parseGo(data []byte) (*Profile, error) {
+	p := New()
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		m := goBlockRe.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("line %d: malformed cover block %q", n, line)
+		}
+		start, _ := strconv.Atoi(m[2])
+		end, _ := strconv.Atoi(m[3])
+		endCol, _ := strconv.Atoi(m[4])
+		stmts, _ := strconv.Atoi(m[5])
+		count, _ := strconv.Atoi(m[6])
+		if stmts == 0 {
+			continue
+		}
+		// a block ending at column 1 holds nothing of its last line
+		if endCol <= 1 && end > start {
+			end--
+		}
+		for l := start; l <= end; l++ {
+			p.hit(m[1], l, count)
+		}
+	}
+	return p, sc.Err()
+}
+
+// parseLCOV reads an LCOV tracefile: SF: opens a file, DA:line,count
+// records a line and end_of_record closes it.
+func parseLCOV(data []byte) (*Profile, error) {
+	p := New()
+	var file string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			file = strings.TrimPrefix(line, "SF:")
+		case line == "end_of_record":
+			file = ""
+		case strings.HasPrefix(line, "DA:"):
+			if file == "" {
+				return nil, fmt.Errorf("line %d: DA outside of a file record", n)
+			}
+			fields := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("line %d: malformed DA %q", n, line)
+			}
+			l, err1 := strconv.Atoi(fields[0])
+			count, err2 := strconv.Atoi(fields[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("line %d: malformed DA %q", n, line)
+			}
+			p.hit(file, l, count)
+		}
+	}
+	return p, sc.Err()
+}
+
+type coberturaReport struct {
+	XMLName xml.Name `xml:"coverage"`
+	Classes []struct {
+		Filename string `xml:"filename,attr"`
+		Lines    []struct {
+			Number int `xml:"number,attr"`
+			Hits   int `xml:"hits,attr"`
+		} `xml:"lines>line"`
+	} `xml:"packages>package>classes>class"`
+}
+
+// parseCobertura reads a Cobertura XML report. Class file names are
+// relative to one of the report's sources, which suffix matching in Lines
+// makes unnecessary to resolve.
+func parseCobertura(data []byte) (*Profile, error) {
+	var rep coberturaReport
+	if err := xml.Unmarshal(data, &rep); err != nil {
+		return nil, fmt.Errorf("parse Cobertura XML: %w", err)
+	}
+	p := New()
+	for _, c := range rep.Classes {
+		for _, l := range c.Lines {
+			p.hit(c.Filename, l.Number, l.Hits)
+		}
+	}
+	return p, nil
+}
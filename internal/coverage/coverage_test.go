@@ -0,0 +1,83 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package coverage
+
+import "testing"
+
+const goProfile = `mode: set
+go.devnw.com/canary/internal/a/a.go:4.20,6.2 1 1
+go.devnw.com/canary/internal/a/a.go:8.20,10.16 1 0
+go.devnw.com/canary/internal/a/a.go:10.16,12.3 1 1
+go.devnw.com/canary/internal/a/a.go:13.2,13.10 0 0
+go.devnw.com/canary/internal/a/a.go:15.3,17.1 1 0
+`
+
+const lcov = `TN:
+SF:/home/ci/repo/src/a.ts
+DA:4,1
+DA:5,0
+DA:6,3
+end_of_record
+`
+
+const cobertura = `<?xml version="1.0" ?>
+<coverage line-rate="0.5">
+  <sources><source>/home/ci/repo</source></sources>
+  <packages><package name="src"><classes>
+    <class name="a.py" filename="src/a.py">
+      <lines>
+        <line number="2" hits="1"/>
+        <line number="3" hits="0"/>
+      </lines>
+    </class>
+  </classes></package></packages>
+</coverage>
+`
+
+// TestCANARY_CBIN_166_Engine_ParseProfiles verifies Go, LCOV and Cobertura
+// reports are read and matched to repository paths by suffix.
+func TestCANARY_CBIN_166_Engine_ParseProfiles(t *testing.T) {
+	tests := []struct {
+		name, src, file string
+		from, to        int
+		want            Counts
+	}{
+		{"go covered func", goProfile, "internal/a/a.go", 4, 6, Counts{3, 3}},
+		// line 10 ends an uncovered block and starts a covered one
+		{"go partial", goProfile, "./internal/a/a.go", 8, 13, Counts{3, 5}},
+		{"go block ending at column 1", goProfile, "internal/a/a.go", 15, 17, Counts{0, 2}},
+		{"go other file", goProfile, "internal/b/a.go", 1, 20, Counts{}},
+		{"lcov absolute", lcov, "src/a.ts", 1, 10, Counts{2, 3}},
+		{"cobertura", cobertura, "src/a.py", 1, 3, Counts{1, 2}},
+	}
+	for _, tt := range tests {
+		p, err := Parse([]byte(tt.src))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := p.Lines(tt.file, tt.from, tt.to); got != tt.want {
+			t.Errorf("%s: Lines = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	p, _ := Parse([]byte(goProfile))
+	if got := p.Span("internal/a/a.go", 8, 12, 9, 9); got != (Counts{3, 4}) {
+		t.Errorf("Span without token line = %+v, want {3 4}", got)
+	}
+	if !p.Has("internal/a/a.go") || p.Has("internal/b/a.go") {
+		t.Error("Has doesn't match profile files by suffix")
+	}
+
+	if _, err := Parse([]byte("not a profile")); err == nil {
+		t.Error("unknown format accepted")
+	}
+	if _, err := Parse([]byte("mode: set\nbroken")); err == nil {
+		t.Error("malformed Go profile accepted")
+	}
+	if got := (Counts{33, 40}).String(); got != "82.5% (33/40 lines)" {
+		t.Errorf("String = %q", got)
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package evidence resolves the TEST= and BENCH= names on CANARY tokens
+// against the test functions that actually exist in a repository.
+package evidence
+
+// CANARY: REQ=CBIN-159; FEATURE="TestEvidence"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_159_Engine_ResolveEvidence
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/token"
+)
+
+// Kind is the kind of evidence a symbol provides.
+type Kind int
+
+const (
+	Test  Kind = iota // a test function, TEST=
+	Bench             // a benchmark, BENCH=
+)
+
+func (k Kind) String() string {
+	if k == Bench {
+		return "BENCH"
+	}
+	return "TEST"
+}
+
+// Symbol is a test or benchmark found in a source file.
+type Symbol struct {
+	Name string
+	Kind Kind
+	File string
+	Line int
+}
+
+// Resolver finds the test symbols of one language or framework.
+type Resolver interface {
+	// Name identifies the resolver in diagnostics, e.g. "go" or "pytest".
+	Name() string
+	// Match reports whether path is a test file the resolver understands.
+	Match(path string) bool
+	// Symbols returns every test and benchmark declared in src.
+	Symbols(path string, src []byte) ([]Symbol, error)
+}
+
+var (
+	mu        sync.RWMutex
+	resolvers = []Resolver{Go{}, Pytest{}, Jest{}, Rust{}}
+)
+
+// Register adds r to the resolvers consulted by Build. Resolvers registered
+// later see files before the built-in ones.
+func Register(r Resolver) {
+	mu.Lock()
+	defer mu.Unlock()
+	resolvers = append([]Resolver{r}, resolvers...)
+}
+
+// Resolvers returns the registered resolvers in the order they are tried.
+func Resolvers() []Resolver {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Resolver(nil), resolvers...)
+}
+
+// Index holds the test symbols found in a set of files.
+type Index struct {
+	symbols map[Kind]map[string][]Symbol
+	files   int
+}
+
+// Build reads every path handled by a registered resolver and indexes its
+// symbols. The first matching resolver wins. Files that can't be read or
+// parsed are returned as errors after the rest have been indexed, so one
+// broken test file doesn't hide the evidence in the others.
+func Build(paths []string) (*Index, error) {
+	rs := Resolvers()
+	ix := NewIndex()
+	var errs []string
+	for _, p := range paths {
+		for _, r := range rs {
+			if !r.Match(p) {
+				continue
+			}
+			src, err := os.ReadFile(p)
+			if err != nil {
+				errs = append(errs, err.Error())
+				break
+			}
+			syms, err := r.Symbols(p, src)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", r.Name(), err))
+			}
+			ix.Add(syms...)
+			ix.files++
+			break
+		}
+	}
+	if len(errs) > 0 {
+		return ix, fmt.Errorf("resolve test symbols: %s", strings.Join(errs, "; "))
+	}
+	return ix, nil
+}
+
+// skipDirs are directory names Files never descends into.
+var skipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "target": true, "dist": true,
+	"build": true, "__pycache__": true, "testdata": true,
+}
+
+// Files lists the candidate test files under root. Unlike the token walk it
+// ignores .canaryignore, which usually excludes test files to keep fixture
+// tokens out of the index. Hidden directories and dependency or build
+// output directories are skipped.
+func Files(root string) ([]string, error) {
+	rs := Resolvers()
+	var out []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (skipDirs[name] || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, r := range rs {
+			if r.Match(path) {
+				out = append(out, path)
+				break
+			}
+		}
+		return nil
+	})
+	return out, err
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{symbols: map[Kind]map[string][]Symbol{Test: {}, Bench: {}}}
+}
+
+// Add indexes syms.
+func (ix *Index) Add(syms ...Symbol) {
+	for _, s := range syms {
+		ix.symbols[s.Kind][s.Name] = append(ix.symbols[s.Kind][s.Name], s)
+	}
+}
+
+// Files is the number of test files indexed by Build.
+func (ix *Index) Files() int { return ix.files }
+
+// Lookup returns the symbols of kind that name refers to. Besides exact
+// matches it accepts the qualified forms test runners print: a Go subtest
+// ("TestX/case"), a pytest node ID ("tests/test_x.py::TestC::test_y") and a
+// Rust module path ("tests::it_works").
+func (ix *Index) Lookup(kind Kind, name string) []Symbol {
+	for _, c := range candidates(name) {
+		if s := ix.symbols[kind][c]; len(s) > 0 {
+			return s
+		}
+	}
+	return nil
+}
+
+// Span returns the lines s covers: from its own line to the line before the
+// next symbol in the same file. to is 0 when s is the last symbol, meaning
+// it runs to the end of the file.
+func (ix *Index) Span(s Symbol) (from, to int) {
+	for _, byName := range ix.symbols {
+		for _, syms := range byName {
+			for _, o := range syms {
+				if o.File == s.File && o.Line > s.Line && (to == 0 || o.Line-1 < to) {
+					to = o.Line - 1
+				}
+			}
+		}
+	}
+	return s.Line, to
+}
+
+// Has reports whether name refers to a known symbol of kind.
+func (ix *Index) Has(kind Kind, name string) bool {
+	return len(ix.Lookup(kind, name)) > 0
+}
+
+func candidates(name string) []string {
+	name = strings.TrimSpace(name)
+	out := []string{name}
+	if before, _, ok := strings.Cut(name, "/"); ok && !strings.Contains(name, "::") {
+		out = append(out, before)
+	}
+	for rest := name; ; {
+		_, after, ok := strings.Cut(rest, "::")
+		if !ok {
+			break
+		}
+		out = append(out, after)
+		rest = after
+	}
+	return out
+}
+
+// Missing lists the names of kind that don't resolve, sorted.
+func (ix *Index) Missing(kind Kind, names []string) []string {
+	var out []string
+	for _, n := range names {
+		if !ix.Has(kind, n) {
+			out = append(out, n)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Finding reports the evidence a token names that doesn't exist, didn't
+// run or failed.
+type Finding struct {
+	Token          *token.Token
+	MissingTests   []string
+	MissingBenches []string
+	Failing        []string // set when checked against test results
+	NotRunTests    []string // set when checked against test results
+	NotRunBenches  []string // set when checked against test results
+	Status         string   // declared or promoted status
+	Effective      string   // status once only resolved evidence counts
+}
+
+// Check resolves the TEST and BENCH names of every token and returns a
+// finding for each token with missing evidence. Statuses are promoted by lc
+// the same way the scanner promotes them, then demoted by lc to what the
+// resolved evidence supports: by default BENCHED without a benchmark drops
+// to TESTED and TESTED without a test to IMPL.
+//
+// When res is not nil, names that resolve must also have passed in res: a
+// failing test makes a token in a satisfying status REGRESSED, and tests
+// that didn't run count as missing.
+func Check(toks []*token.Token, ix *Index, res *Results, lc *lifecycle.Lifecycle) []Finding {
+	var out []Finding
+	for _, tok := range toks {
+		tests, benches := tok.Tests(), tok.Benches()
+		f := Finding{
+			Token:          tok,
+			MissingTests:   ix.Missing(Test, tests),
+			MissingBenches: ix.Missing(Bench, benches),
+			Status:         lc.Promote(tok.Status(), tok.Aspect(), len(tests) > 0, len(benches) > 0),
+		}
+		f.Effective = lc.Demote(f.Status, tok.Aspect(), len(tests) > len(f.MissingTests), len(benches) > len(f.MissingBenches))
+		if res != nil {
+			ev := res.Evaluate(f.Status, tok.Aspect(), without(tests, f.MissingTests), without(benches, f.MissingBenches), lc)
+			f.Failing, f.NotRunTests, f.NotRunBenches = ev.Failing, ev.NotRunTests, ev.NotRunBenches
+			if ev.Status == Regressed || lc.Rank(ev.Status) < lc.Rank(f.Effective) {
+				f.Effective = ev.Status
+			}
+		}
+		if len(f.MissingTests)+len(f.MissingBenches)+len(f.Failing)+len(f.NotRunTests)+len(f.NotRunBenches) == 0 {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// without returns names minus the names in drop.
+func without(names, drop []string) []string {
+	if len(drop) == 0 {
+		return names
+	}
+	skip := map[string]bool{}
+	for _, n := range drop {
+		skip[n] = true
+	}
+	var out []string
+	for _, n := range names {
+		if !skip[n] {
+			out = append(out, n)
+		}
+	}
+	return out
+}
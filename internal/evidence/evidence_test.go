@@ -0,0 +1,175 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package evidence
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.devnw.com/canary/internal/token"
+)
+
+var fixtures = map[string]string{
+	"pkg/auth_test.go": `package auth
+
+import tt "testing"
+
+func TestLogin(t *tt.T)             {}
+func TestLogin_Expired(t *tt.T)     {}
+func Testlower(t *tt.T)             {}
+func FuzzParse(f *tt.F)             {}
+func BenchmarkLogin(b *tt.B)        {}
+func helper(t *tt.T)                {}
+func (s *suite) TestMethod(t *tt.T) {}
+`,
+	"tests/test_auth.py": `import pytest
+
+def test_login():
+    pass
+
+class TestSession:
+    def test_refresh(self):
+        pass
+
+    async def test_expire(self, benchmark):
+        pass
+
+def helper():
+    pass
+`,
+	"web/auth.test.ts":  "describe('Auth', () => {\n  it(\"logs in\", () => {})\n  test.skip('don\\'t log out', () => {})\n  it(`times out`, () => {})\n})\n",
+	"web/auth.bench.ts": "bench('hash password', () => {})\n",
+	"src/lib.rs": `pub fn add() {}
+
+#[cfg(test)]
+mod tests {
+    #[test]
+    fn it_adds() {}
+
+    #[tokio::test]
+    #[ignore]
+    async fn it_adds_async() {}
+
+    #[bench]
+    fn bench_add(b: &mut Bencher) {}
+
+    fn not_a_test() {}
+}
+`,
+	"benches/add.rs":           `c.bench_function("add 20", |b| b.iter(|| add(20)));`,
+	"node_modules/x/x.test.js": "it('vendored', () => {})\n",
+	"pkg/auth.go":              "package auth\n\nfunc TestNotATestFile(t *testing.T) {}\n",
+}
+
+func buildIndex(t *testing.T) *Index {
+	t.Helper()
+	root := t.TempDir()
+	for name, src := range fixtures {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths, err := Files(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ix, err := Build(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ix.Files() != 6 {
+		t.Errorf("indexed %d files, want 6", ix.Files())
+	}
+	return ix
+}
+
+// TestCANARY_CBIN_159_Engine_ResolveEvidence verifies each built-in resolver
+// finds its tests and benchmarks and nothing else.
+func TestCANARY_CBIN_159_Engine_ResolveEvidence(t *testing.T) {
+	ix := buildIndex(t)
+
+	tests := []struct {
+		kind Kind
+		name string
+		want bool
+	}{
+		{Test, "TestLogin", true},
+		{Test, "TestLogin_Expired", true},
+		{Test, "TestLogin/expired_token", true},
+		{Test, "FuzzParse", true},
+		{Bench, "BenchmarkLogin", true},
+		{Test, "BenchmarkLogin", false},
+		{Test, "Testlower", false},
+		{Test, "helper", false},
+		{Test, "TestMethod", false},
+		{Test, "TestNotATestFile", false},
+
+		{Test, "test_login", true},
+		{Test, "TestSession", true},
+		{Test, "TestSession::test_refresh", true},
+		{Test, "tests/test_auth.py::TestSession::test_expire", true},
+		{Bench, "test_expire", true},
+		{Bench, "test_refresh", false},
+
+		{Test, "Auth", true},
+		{Test, "logs in", true},
+		{Test, "don't log out", true},
+		{Test, "times out", true},
+		{Bench, "hash password", true},
+		{Test, "vendored", false},
+
+		{Test, "it_adds", true},
+		{Test, "tests::it_adds_async", true},
+		{Bench, "bench_add", true},
+		{Bench, "add 20", true},
+		{Test, "not_a_test", false},
+		{Test, "add", false},
+	}
+	for _, tt := range tests {
+		if got := ix.Has(tt.kind, tt.name); got != tt.want {
+			t.Errorf("Has(%s, %q) = %v, want %v", tt.kind, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheck_Downgrades(t *testing.T) {
+	ix := NewIndex()
+	ix.Add(Symbol{Name: "TestA", Kind: Test}, Symbol{Name: "BenchmarkA", Kind: Bench})
+
+	src := `// CANARY: REQ=CBIN-200; FEATURE="Ok"; ASPECT=API; STATUS=IMPL; TEST=TestA; BENCH=BenchmarkA; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-200; FEATURE="NoBench"; ASPECT=API; STATUS=IMPL; TEST=TestA; BENCH=BenchmarkGone; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-200; FEATURE="NoTest"; ASPECT=API; STATUS=TESTED; TEST=TestGone; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-200; FEATURE="Partial"; ASPECT=API; STATUS=TESTED; TEST=TestA, TestGone; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-200; FEATURE="Stub"; ASPECT=API; STATUS=STUB; TEST=TestGone; UPDATED=2025-10-15
+`
+	toks, diags := token.Parse("a.go", []byte(src), nil)
+	if len(diags) != 0 {
+		t.Fatal(diags)
+	}
+
+	want := map[string][2]string{
+		"NoBench": {"BENCHED", "TESTED"},
+		"NoTest":  {"TESTED", "IMPL"},
+		"Partial": {"TESTED", "TESTED"},
+		"Stub":    {"STUB", "STUB"},
+	}
+	findings := Check(toks, ix, nil, nil)
+	if len(findings) != len(want) {
+		t.Fatalf("got %d findings, want %d", len(findings), len(want))
+	}
+	for _, f := range findings {
+		w := want[f.Token.Feature()]
+		if f.Status != w[0] || f.Effective != w[1] {
+			t.Errorf("%s: %s -> %s, want %s -> %s", f.Token.Feature(), f.Status, f.Effective, w[0], w[1])
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package evidence
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Go resolves `func TestX(t *testing.T)`, `func FuzzX(f *testing.F)` and
+// `func BenchmarkX(b *testing.B)` in _test.go files using go/parser.
+type Go struct{}
+
+func (Go) Name() string { return "go" }
+
+func (Go) Match(path string) bool { return strings.HasSuffix(path, "_test.go") }
+
+func (Go) Symbols(path string, src []byte) ([]Symbol, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	// The testing package may be imported under another name
+	testing := ""
+	for _, imp := range f.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p != "testing" {
+			continue
+		}
+		testing = "testing"
+		if imp.Name != nil {
+			testing = imp.Name.Name
+		}
+	}
+	if testing == "" {
+		return nil, nil
+	}
+
+	var out []Symbol
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || len(fn.Type.Params.List) != 1 {
+			continue
+		}
+		star, ok := fn.Type.Params.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != testing {
+			continue
+		}
+
+		name := fn.Name.Name
+		var kind Kind
+		switch {
+		case sel.Sel.Name == "T" && goTestName(name, "Test"),
+			sel.Sel.Name == "F" && goTestName(name, "Fuzz"):
+			kind = Test
+		case sel.Sel.Name == "B" && goTestName(name, "Benchmark"):
+			kind = Bench
+		default:
+			continue
+		}
+		out = append(out, Symbol{Name: name, Kind: kind, File: path, Line: fset.Position(fn.Pos()).Line})
+	}
+	return out, nil
+}
+
+// goTestName applies the go test rule: the name is prefix followed by
+// nothing or by a character that isn't a lower-case letter.
+func goTestName(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	rest := name[len(prefix):]
+	return rest == "" || rest[0] < 'a' || rest[0] > 'z'
+}
+
+// Pytest resolves test functions and Test classes in test_*.py and *_test.py
+// files. Methods are indexed both bare and as "TestClass::test_method". A
+// test taking the pytest-benchmark `benchmark` fixture counts as a benchmark
+// too.
+type Pytest struct{}
+
+func (Pytest) Name() string { return "pytest" }
+
+func (Pytest) Match(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, ".py") &&
+		(strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py"))
+}
+
+var (
+	pyDefRe   = regexp.MustCompile(`^(\s*)(?:async\s+)?def\s+(test\w*)\s*\(([^)]*)`)
+	pyClassRe = regexp.MustCompile(`^(\s*)class\s+(Test\w*)`)
+	pyBenchRe = regexp.MustCompile(`\bbenchmark\b`)
+)
+
+func (Pytest) Symbols(path string, src []byte) ([]Symbol, error) {
+	var (
+		out         []Symbol
+		class       string
+		classIndent = -1
+	)
+	err := eachLine(src, func(n int, line string) {
+		if strings.TrimSpace(line) == "" {
+			return
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if class != "" && indent <= classIndent {
+			class, classIndent = "", -1
+		}
+		if m := pyClassRe.FindStringSubmatch(line); m != nil {
+			class, classIndent = m[2], len(m[1])
+			out = append(out, Symbol{Name: class, Kind: Test, File: path, Line: n})
+			return
+		}
+		m := pyDefRe.FindStringSubmatch(line)
+		if m == nil {
+			return
+		}
+		names := []string{m[2]}
+		if class != "" {
+			names = append(names, class+"::"+m[2])
+		}
+		bench := pyBenchRe.MatchString(m[3])
+		for _, name := range names {
+			out = append(out, Symbol{Name: name, Kind: Test, File: path, Line: n})
+			if bench {
+				out = append(out, Symbol{Name: name, Kind: Bench, File: path, Line: n})
+			}
+		}
+	})
+	return out, err
+}
+
+// Jest resolves describe, it and test titles in Jest and Vitest spec files
+// (*.test.*, *.spec.*, __tests__/), and Vitest bench titles.
+type Jest struct{}
+
+func (Jest) Name() string { return "jest" }
+
+var jsExts = map[string]bool{".js": true, ".jsx": true, ".ts": true, ".tsx": true, ".mjs": true, ".cjs": true, ".mts": true, ".cts": true}
+
+func (Jest) Match(path string) bool {
+	if !jsExts[filepath.Ext(path)] {
+		return false
+	}
+	base := filepath.Base(path)
+	return strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") || strings.Contains(base, ".bench.") ||
+		strings.Contains(filepath.ToSlash(path), "__tests__/")
+}
+
+// jestCallRe matches a call such as `it("title"` or `describe.skip('title'`.
+// Titles built at run time, e.g. by test.each, are not resolved.
+var jestCallRe = regexp.MustCompile(`\b(describe|it|test|bench)(?:\.(?:only|skip|todo|concurrent|fails))*\s*\(\s*` +
+	`(?:"((?:\\.|[^"\\])*)"|'((?:\\.|[^'\\])*)'|` + "`([^`]*)`)")
+
+var jsUnquote = strings.NewReplacer(`\'`, `'`, `\"`, `"`, `\\`, `\`)
+
+func (Jest) Symbols(path string, src []byte) ([]Symbol, error) {
+	var out []Symbol
+	err := eachLine(src, func(n int, line string) {
+		for _, m := range jestCallRe.FindAllStringSubmatch(line, -1) {
+			kind := Test
+			if m[1] == "bench" {
+				kind = Bench
+			}
+			out = append(out, Symbol{Name: jsUnquote.Replace(m[2] + m[3] + m[4]), Kind: kind, File: path, Line: n})
+		}
+	})
+	return out, err
+}
+
+// Rust resolves functions marked #[test] (including framework attributes
+// such as #[tokio::test]) and #[bench], plus Criterion bench_function names.
+type Rust struct{}
+
+func (Rust) Name() string { return "rust" }
+
+func (Rust) Match(path string) bool { return strings.HasSuffix(path, ".rs") }
+
+var (
+	rustAttrRe      = regexp.MustCompile(`^\s*#\[(?:[\w:]+::)?(test|bench)\b`)
+	rustFnRe        = regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:unsafe\s+)?fn\s+(\w+)`)
+	rustCriterionRe = regexp.MustCompile(`\bbench_function\s*\(\s*"([^"]+)"`)
+)
+
+func (Rust) Symbols(path string, src []byte) ([]Symbol, error) {
+	var (
+		out     []Symbol
+		pending = -1 // kind from the last attribute awaiting its fn
+	)
+	err := eachLine(src, func(n int, line string) {
+		if m := rustCriterionRe.FindStringSubmatch(line); m != nil {
+			out = append(out, Symbol{Name: m[1], Kind: Bench, File: path, Line: n})
+		}
+		if m := rustAttrRe.FindStringSubmatch(line); m != nil {
+			pending = int(Test)
+			if m[1] == "bench" {
+				pending = int(Bench)
+			}
+			return
+		}
+		if pending < 0 {
+			return
+		}
+		if m := rustFnRe.FindStringSubmatch(line); m != nil {
+			out = append(out, Symbol{Name: m[1], Kind: Kind(pending), File: path, Line: n})
+			pending = -1
+			return
+		}
+		// Other attributes, comments and blank lines may sit between the
+		// test attribute and its function
+		t := strings.TrimSpace(line)
+		if t != "" && !strings.HasPrefix(t, "#[") && !strings.HasPrefix(t, "//") {
+			pending = -1
+		}
+	})
+	return out, err
+}
+
+// eachLine calls fn with every line of src and its 1-based number.
+func eachLine(src []byte, fn func(n int, line string)) error {
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		fn(n, sc.Text())
+	}
+	return sc.Err()
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package evidence
+
+// CANARY: REQ=CBIN-160; FEATURE="TestResults"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_160_Engine_ParseResults
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.devnw.com/canary/internal/lifecycle"
+)
+
+// Regressed is the effective status of a token in a satisfying status
+// whose named tests ran and failed.
+const Regressed = "REGRESSED"
+
+// Outcome is the result of one test in a run.
+type Outcome string
+
+const (
+	Pass Outcome = "pass"
+	Fail Outcome = "fail"
+	Skip Outcome = "skip"
+)
+
+// rank orders outcomes when a name is reported more than once: a failure
+// anywhere wins, and a pass beats a skip.
+func (o Outcome) rank() int {
+	switch o {
+	case Fail:
+		return 2
+	case Pass:
+		return 1
+	}
+	return 0
+}
+
+// Results holds the outcome of every test in one or more test runs.
+type Results struct {
+	raw   map[string]Outcome // names as reported
+	index map[string]Outcome // names plus their qualified suffixes
+}
+
+// NewResults returns results holding outcomes, e.g. as loaded from the
+// database.
+func NewResults(outcomes map[string]Outcome) *Results {
+	r := &Results{raw: map[string]Outcome{}, index: map[string]Outcome{}}
+	for name, o := range outcomes {
+		r.Record(name, o)
+	}
+	return r
+}
+
+// Record adds the outcome of the test name. Reporting the same name twice
+// keeps the worse outcome.
+func (r *Results) Record(name string, o Outcome) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	if prev, ok := r.raw[name]; !ok || o.rank() > prev.rank() {
+		r.raw[name] = o
+	}
+	// "tests::it_works" also answers for "it_works" and a failing
+	// "TestX/case" fails "TestX"
+	for _, c := range candidates(name) {
+		if prev, ok := r.index[c]; !ok || o.rank() > prev.rank() {
+			r.index[c] = o
+		}
+	}
+}
+
+// Merge adds every outcome in o to r.
+func (r *Results) Merge(o *Results) {
+	for name, out := range o.raw {
+		r.Record(name, out)
+	}
+}
+
+// Outcomes returns the recorded outcomes keyed by the reported name.
+func (r *Results) Outcomes() map[string]Outcome {
+	out := make(map[string]Outcome, len(r.raw))
+	for k, v := range r.raw {
+		out[k] = v
+	}
+	return out
+}
+
+// Len is the number of distinct tests recorded.
+func (r *Results) Len() int { return len(r.raw) }
+
+// Outcome returns the outcome of the test a TEST= or BENCH= name refers to.
+func (r *Results) Outcome(name string) (Outcome, bool) {
+	for _, c := range candidates(name) {
+		if o, ok := r.index[c]; ok {
+			return o, true
+		}
+	}
+	return "", false
+}
+
+// Evaluation is the status a token earns from a test run.
+type Evaluation struct {
+	Status        string   // effective status
+	Failing       []string // named tests and benchmarks that failed
+	NotRunTests   []string // named tests with no result, or skipped
+	NotRunBenches []string // named benchmarks with no result, or skipped
+}
+
+// Evaluate returns the effective status of a token of aspect with the
+// given status and evidence under lc. The status is first promoted by the
+// lifecycle. A token in a satisfying status with a failing test is
+// REGRESSED; otherwise it only keeps the status its passing tests support.
+func (r *Results) Evaluate(status, aspect string, tests, benches []string, lc *lifecycle.Lifecycle) Evaluation {
+	status = lc.Promote(status, aspect, len(tests) > 0, len(benches) > 0)
+	var ev Evaluation
+	passed := func(names []string, notRun *[]string) bool {
+		ok := false
+		for _, n := range names {
+			switch o, _ := r.Outcome(n); o {
+			case Pass:
+				ok = true
+			case Fail:
+				ev.Failing = append(ev.Failing, n)
+			default:
+				*notRun = append(*notRun, n)
+			}
+		}
+		return ok
+	}
+	hasTests, hasBenches := passed(tests, &ev.NotRunTests), passed(benches, &ev.NotRunBenches)
+
+	ev.Status = lc.Demote(status, aspect, hasTests, hasBenches)
+	if len(ev.Failing) > 0 && lc.Satisfies(status, aspect) {
+		ev.Status = Regressed
+	}
+	return ev
+}
+
+// ReadResults parses a test report file. See ParseResults.
+func ReadResults(path string) (*Results, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := ParseResults(src)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+// ParseResults parses `go test -json` output, a JUnit XML report or TAP.
+// The format is detected from the first non-blank character: '{' for go
+// test, '<' for JUnit and anything else for TAP.
+func ParseResults(src []byte) (*Results, error) {
+	r := NewResults(nil)
+	var err error
+	switch t := bytes.TrimSpace(src); {
+	case len(t) == 0:
+		return nil, fmt.Errorf("empty test report")
+	case t[0] == '{':
+		err = r.parseGoTest(src)
+	case t[0] == '<':
+		err = r.parseJUnit(src)
+	default:
+		err = r.parseTAP(src)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() == 0 {
+		return nil, fmt.Errorf("no test results found")
+	}
+	return r, nil
+}
+
+// goBenchRe matches a benchmark result line, e.g.
+// "BenchmarkX-8   1000   1234 ns/op".
+var (
+	goBenchRe     = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+\d+\s+`)
+	goBenchFailRe = regexp.MustCompile(`^--- FAIL: (Benchmark\S+)`)
+)
+
+func (r *Results) parseGoTest(src []byte) error {
+	return eachLine(src, func(n int, line string) {
+		var ev struct {
+			Action string
+			Test   string
+			Output string
+		}
+		if json.Unmarshal([]byte(line), &ev) != nil {
+			return // build output and other noise between events
+		}
+		switch ev.Action {
+		case "pass", "fail", "skip":
+			if ev.Test != "" {
+				r.Record(ev.Test, Outcome(ev.Action))
+			}
+		case "output", "bench":
+			// Benchmarks only report through their output lines
+			out := strings.TrimSpace(ev.Output)
+			if m := goBenchRe.FindStringSubmatch(out); m != nil {
+				r.Record(m[1], Pass)
+			} else if m := goBenchFailRe.FindStringSubmatch(out); m != nil {
+				r.Record(m[1], Fail)
+			}
+		}
+	})
+}
+
+type junitSuite struct {
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string    `xml:"name,attr"`
+	Classname string    `xml:"classname,attr"`
+	Failure   *struct{} `xml:"failure"`
+	Error     *struct{} `xml:"error"`
+	Skipped   *struct{} `xml:"skipped"`
+}
+
+// parseJUnit accepts a <testsuites> or <testsuite> root. A case is recorded
+// by name and, when it has a classname, as "Class::name" using the last
+// dotted segment of the classname, which is how pytest names test methods.
+func (r *Results) parseJUnit(src []byte) error {
+	var root junitSuite
+	if err := xml.Unmarshal(src, &root); err != nil {
+		return fmt.Errorf("parse JUnit XML: %w", err)
+	}
+	var walk func(s junitSuite)
+	walk = func(s junitSuite) {
+		for _, c := range s.Cases {
+			o := Pass
+			switch {
+			case c.Failure != nil || c.Error != nil:
+				o = Fail
+			case c.Skipped != nil:
+				o = Skip
+			}
+			r.Record(c.Name, o)
+			if c.Classname != "" {
+				class := c.Classname[strings.LastIndex(c.Classname, ".")+1:]
+				r.Record(class+"::"+c.Name, o)
+			}
+		}
+		for _, sub := range s.Suites {
+			walk(sub)
+		}
+	}
+	walk(root)
+	return nil
+}
+
+// tapRe matches a TAP test line, e.g. "not ok 3 - name # SKIP reason".
+// Indented subtest lines are accepted too.
+var tapRe = regexp.MustCompile(`^\s*(not )?ok\b(?:\s+\d+)?(?:\s+-)?\s*(.*?)\s*(?:#\s*(\w+).*)?$`)
+
+func (r *Results) parseTAP(src []byte) error {
+	return eachLine(src, func(n int, line string) {
+		m := tapRe.FindStringSubmatch(line)
+		if m == nil {
+			return
+		}
+		o := Pass
+		if m[1] != "" {
+			o = Fail
+		}
+		switch strings.ToUpper(m[3]) {
+		case "SKIP":
+			o = Skip
+		case "TODO":
+			if o == Fail {
+				o = Skip // expected failure
+			}
+		}
+		r.Record(m[2], o)
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package evidence
+
+import (
+	"fmt"
+	"testing"
+)
+
+const goTestJSON = `{"Action":"start","Package":"example.com/p"}
+{"Action":"run","Package":"example.com/p","Test":"TestLogin"}
+{"Action":"output","Package":"example.com/p","Test":"TestLogin","Output":"=== RUN   TestLogin\n"}
+{"Action":"pass","Package":"example.com/p","Test":"TestLogin/valid"}
+{"Action":"fail","Package":"example.com/p","Test":"TestLogin/expired"}
+{"Action":"fail","Package":"example.com/p","Test":"TestLogin"}
+{"Action":"skip","Package":"example.com/p","Test":"TestSlow"}
+{"Action":"pass","Package":"example.com/p","Test":"TestLogout"}
+{"Action":"output","Package":"example.com/p","Output":"BenchmarkLogin-8   \t    1000\t   1234 ns/op\n"}
+{"Action":"output","Package":"example.com/p","Output":"--- FAIL: BenchmarkLogout\n"}
+# example.com/q [build failed]
+{"Action":"fail","Package":"example.com/p"}
+`
+
+const junitXML = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="pytest">
+    <testcase classname="tests.test_auth.TestSession" name="test_refresh"/>
+    <testcase classname="tests.test_auth" name="test_login"><failure message="boom"/></testcase>
+    <testcase classname="tests.test_auth" name="test_slow"><skipped/></testcase>
+    <testsuite name="nested">
+      <testcase classname="lib" name="tests::it_adds"><error/></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>
+`
+
+const tapOutput = `TAP version 13
+1..5
+ok 1 - logs in
+not ok 2 - logs out
+ok 3 - uploads # SKIP no network
+not ok 4 - retries # TODO flaky
+    ok 1 - nested case
+ok 5
+`
+
+// TestCANARY_CBIN_160_Engine_ParseResults verifies each report format is
+// detected and its outcomes recorded.
+func TestCANARY_CBIN_160_Engine_ParseResults(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		expect map[string]Outcome
+	}{
+		{"go test -json", goTestJSON, map[string]Outcome{
+			"TestLogin":          Fail,
+			"TestLogin/valid":    Pass,
+			"TestSlow":           Skip,
+			"TestLogout":         Pass,
+			"BenchmarkLogin":     Pass,
+			"BenchmarkLogout":    Fail,
+			"BenchmarkLogin-8":   "",
+			"TestLogin/unknown":  Fail, // falls back to the parent
+			"example.com/p.Test": "",
+		}},
+		{"junit", junitXML, map[string]Outcome{
+			"test_refresh":                                  Pass,
+			"TestSession::test_refresh":                     Pass,
+			"tests/test_auth.py::TestSession::test_refresh": Pass,
+			"test_login":                                    Fail,
+			"test_slow":                                     Skip,
+			"it_adds":                                       Fail,
+			"tests::it_adds":                                Fail,
+		}},
+		{"tap", tapOutput, map[string]Outcome{
+			"logs in":     Pass,
+			"logs out":    Fail,
+			"uploads":     Skip,
+			"retries":     Skip,
+			"nested case": Pass,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ParseResults([]byte(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for name, want := range tt.expect {
+				if got, _ := res.Outcome(name); got != want {
+					t.Errorf("Outcome(%q) = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+
+	if _, err := ParseResults([]byte("1..0\n")); err == nil {
+		t.Error("report without results was accepted")
+	}
+}
+
+func TestResults_Evaluate(t *testing.T) {
+	res := NewResults(map[string]Outcome{
+		"TestPass": Pass, "TestFail": Fail, "TestSkip": Skip, "BenchmarkPass": Pass,
+	})
+
+	tests := []struct {
+		name    string
+		status  string
+		tests   []string
+		benches []string
+		want    string
+	}{
+		{"passing", "IMPL", []string{"TestPass"}, []string{"BenchmarkPass"}, "BENCHED"},
+		{"failing test", "BENCHED", []string{"TestPass", "TestFail"}, []string{"BenchmarkPass"}, Regressed},
+		{"failing bench", "IMPL", []string{"TestPass"}, []string{"TestFail"}, Regressed},
+		{"not run", "TESTED", []string{"TestGone"}, nil, "IMPL"},
+		{"skipped", "IMPL", []string{"TestSkip"}, nil, "IMPL"},
+		{"bench not run", "BENCHED", []string{"TestPass"}, []string{"BenchmarkGone"}, "TESTED"},
+		{"failing stub", "STUB", []string{"TestFail"}, nil, "STUB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := res.Evaluate(tt.status, "API", tt.tests, tt.benches, nil).Status; got != tt.want {
+				t.Errorf("Evaluate = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	ev := res.Evaluate("BENCHED", "API", []string{"TestPass", "TestGone"}, []string{"BenchmarkGone"}, nil)
+	if fmt.Sprint(ev.NotRunTests) != "[TestGone]" || fmt.Sprint(ev.NotRunBenches) != "[BenchmarkGone]" {
+		t.Errorf("not run = tests %v, benches %v", ev.NotRunTests, ev.NotRunBenches)
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package gitstale derives token staleness from git history instead of the
+// hand-maintained UPDATED field. A token is stale when the code it covers, or
+// a test it names, changed in a later commit than the token's own lines.
+package gitstale
+
+// CANARY: REQ=CBIN-164; FEATURE="GitStaleness"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_164_Engine_GitStaleness
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/span"
+	"go.devnw.com/canary/internal/token"
+)
+
+// DefaultWindow is how many lines after a token count as the code it
+// covers when it annotates no declaration and the next token doesn't come
+// sooner.
+const DefaultWindow = 40
+
+// Blamer runs `git blame` on files of one repository and caches the time
+// every line last changed.
+type Blamer struct {
+	root  string
+	cache map[string][]time.Time
+}
+
+// NewBlamer returns a Blamer for the git work tree containing dir. It fails
+// when dir isn't inside a work tree or git isn't installed.
+func NewBlamer(dir string) (*Blamer, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s is not in a git work tree: %w", dir, err)
+	}
+	return &Blamer{root: strings.TrimSpace(string(out)), cache: map[string][]time.Time{}}, nil
+}
+
+// Lines returns the time each line of path last changed; index 0 is line 1.
+// Lines modified in the work tree but not yet committed count as changed
+// now. An untracked file has no history and returns nil.
+func (b *Blamer) Lines(path string) ([]time.Time, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if lines, ok := b.cache[abs]; ok {
+		return lines, nil
+	}
+
+	cmd := exec.Command("git", "-C", b.root, "blame", "-w", "--porcelain", "--", abs)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if strings.Contains(stderr.String(), "no such path") {
+			b.cache[abs] = nil
+			return nil, nil
+		}
+		return nil, fmt.Errorf("git blame %s: %s", path, strings.TrimSpace(stderr.String()))
+	}
+
+	lines, err := parsePorcelain(out)
+	if err != nil {
+		return nil, fmt.Errorf("git blame %s: %w", path, err)
+	}
+	b.cache[abs] = lines
+	return lines, nil
+}
+
+// LastChanged returns the latest change to lines from through to of path,
+// inclusive and 1-based. to <= 0 runs to the end of the file. The zero time
+// is returned when the range has no history.
+func (b *Blamer) LastChanged(path string, from, to int) (time.Time, error) {
+	lines, err := b.Lines(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if to <= 0 || to > len(lines) {
+		to = len(lines)
+	}
+	var last time.Time
+	for i := from; i <= to; i++ {
+		if i >= 1 && lines[i-1].After(last) {
+			last = lines[i-1]
+		}
+	}
+	return last, nil
+}
+
+var porcelainHeader = regexp.MustCompile(`^([0-9a-f]{40}) \d+ (\d+)`)
+
+// parsePorcelain reads `git blame --porcelain` output. Each line's commit
+// header is followed by the commit's details the first time it appears and
+// always ends with the line content prefixed by a tab.
+func parsePorcelain(out []byte) ([]time.Time, error) {
+	times := map[string]time.Time{}
+	var lines []time.Time
+	var sha string
+	var final int
+
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, "\t") {
+			for len(lines) < final {
+				lines = append(lines, time.Time{})
+			}
+			if final >= 1 {
+				lines[final-1] = times[sha]
+			}
+			continue
+		}
+		if m := porcelainHeader.FindStringSubmatch(line); m != nil {
+			sha = m[1]
+			final, _ = strconv.Atoi(m[2])
+			continue
+		}
+		if v, ok := strings.CutPrefix(line, "author-time "); ok {
+			sec, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("bad author-time %q", v)
+			}
+			times[sha] = time.Unix(sec, 0).UTC()
+		}
+	}
+	return lines, sc.Err()
+}
+
+// Finding reports a token whose code or tests changed after it did.
+type Finding struct {
+	Token     *token.Token
+	TokenTime time.Time // last change to the token's own lines
+	Changed   time.Time // the later change to its code or tests
+	File      string    // where the later change is
+	From, To  int       // lines of File that were checked; To 0 is end of file
+	Symbol    string    // the declaration the token annotates, if any
+	Test      string    // the TEST= name when the change is in a test
+}
+
+// Reason describes the finding for humans.
+func (f Finding) Reason() string {
+	switch {
+	case f.Test != "":
+		return fmt.Sprintf("test %s changed since token last touched", f.Test)
+	case f.Symbol != "":
+		return fmt.Sprintf("%s changed since token last touched", f.Symbol)
+	}
+	return "code changed since token last touched"
+}
+
+// Options tunes Check.
+type Options struct {
+	// Window caps the lines after a token that count as its code;
+	// DefaultWindow when 0.
+	Window int
+	// Index resolves TEST= names to the test functions to compare
+	// against. Tests are not checked when nil.
+	Index *evidence.Index
+}
+
+// Check returns a finding for every token in toks whose covered code or
+// named tests changed after the token's own lines. A token covers the
+// declaration it annotates; a token annotating none covers the lines after
+// it up to the next token in the same file, at most opts.Window lines.
+// Tokens in files without git history are skipped.
+func Check(b *Blamer, toks []*token.Token, opts Options) ([]Finding, error) {
+	if opts.Window <= 0 {
+		opts.Window = DefaultWindow
+	}
+
+	// the declaration a token annotates, or else the next token in the
+	// file, bounds the code it covers
+	next := map[*token.Token]int{}
+	spans := map[*token.Token]span.Span{}
+	byFile := map[string][]*token.Token{}
+	for _, t := range toks {
+		byFile[t.File] = append(byFile[t.File], t)
+	}
+	for path, ft := range byFile {
+		sort.Slice(ft, func(i, j int) bool { return ft[i].Line < ft[j].Line })
+		for i := 0; i+1 < len(ft); i++ {
+			next[ft[i]] = ft[i+1].Line
+		}
+		if src, err := os.ReadFile(path); err == nil {
+			for i, sp := range span.Tokens(path, src, ft) {
+				if sp.Symbol != "" {
+					spans[ft[i]] = sp
+				}
+			}
+		}
+	}
+
+	var out []Finding
+	for _, t := range toks {
+		end := t.EndLine
+		if end < t.Line {
+			end = t.Line
+		}
+		touched, err := b.LastChanged(t.File, t.Line, end)
+		if err != nil {
+			return out, err
+		}
+		if touched.IsZero() {
+			continue
+		}
+
+		from, to := end+1, end+opts.Window
+		if n, ok := next[t]; ok && n-1 < to {
+			to = n - 1
+		}
+		sp, hasSpan := spans[t]
+		if hasSpan {
+			from, to = sp.Start, sp.End
+		}
+		if from <= to {
+			changed, err := codeChanged(b, t.File, from, to, t.Line, end)
+			if err != nil {
+				return out, err
+			}
+			if changed.After(touched) {
+				out = append(out, Finding{Token: t, TokenTime: touched, Changed: changed, File: t.File, From: from, To: to, Symbol: sp.Symbol})
+				continue
+			}
+		}
+
+		if opts.Index == nil {
+			continue
+		}
+		for _, name := range t.Tests() {
+			f, ok, err := testChanged(b, opts.Index, name, touched)
+			if err != nil {
+				return out, err
+			}
+			if ok {
+				f.Token, f.TokenTime = t, touched
+				out = append(out, f)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// codeChanged returns the latest change to lines from through to of path,
+// leaving out the token's own lines, which a declaration can enclose.
+func codeChanged(b *Blamer, path string, from, to, tokFrom, tokTo int) (time.Time, error) {
+	if tokTo < from || tokFrom > to {
+		return b.LastChanged(path, from, to)
+	}
+	var before, after time.Time
+	var err error
+	if from < tokFrom {
+		if before, err = b.LastChanged(path, from, tokFrom-1); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if tokTo < to {
+		if after, err = b.LastChanged(path, tokTo+1, to); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if after.After(before) {
+		return after, nil
+	}
+	return before, nil
+}
+
+// testChanged reports the first definition of the test name that changed
+// after since.
+func testChanged(b *Blamer, ix *evidence.Index, name string, since time.Time) (Finding, bool, error) {
+	for _, s := range ix.Lookup(evidence.Test, name) {
+		from, to := ix.Span(s)
+		changed, err := b.LastChanged(s.File, from, to)
+		if err != nil {
+			return Finding{}, false, err
+		}
+		if changed.After(since) {
+			return Finding{Changed: changed, File: s.File, From: from, To: to, Test: name}, true, nil
+		}
+	}
+	return Finding{}, false, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package gitstale
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/token"
+)
+
+const src = `package a
+
+// CANARY: REQ=CBIN-300; FEATURE="Parse"; ASPECT=Engine; STATUS=TESTED; TEST=TestParse; UPDATED=2026-01-01
+func Parse() int {
+	return 1
+}
+
+// CANARY: REQ=CBIN-301; FEATURE="Render"; ASPECT=Engine; STATUS=TESTED; UPDATED=2026-01-01
+// CANARY+: TEST=TestRender
+func Render() int {
+	return 2
+}
+
+// CANARY: REQ=CBIN-302; FEATURE="Quiet"; ASPECT=Engine; STATUS=TESTED; UPDATED=2026-01-01
+func Quiet() int {
+	return 3
+}
+`
+
+const testSrc = `package a
+
+import "testing"
+
+func TestParse(t *testing.T) {}
+
+func TestRender(t *testing.T) {
+}
+`
+
+// TestCANARY_CBIN_164_Engine_GitStaleness verifies tokens are stale when the
+// function they annotate or their named tests change in a later commit.
+func TestCANARY_CBIN_164_Engine_GitStaleness(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, s string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit := func(date, msg string) {
+		git(date, "add", "-A")
+		git(date, "commit", "-q", "-m", msg)
+	}
+
+	git("", "init", "-q")
+	write("a.go", src)
+	write("a_test.go", testSrc)
+	commit("2026-01-01T00:00:00Z", "initial")
+
+	write("a.go", strings.Replace(src, "return 1", "return 10", 1))
+	commit("2026-02-01T00:00:00Z", "change Parse")
+
+	write("a_test.go", strings.Replace(testSrc, "TestRender(t *testing.T) {\n", "TestRender(t *testing.T) {\n\tt.Log(2)\n", 1))
+	commit("2026-03-01T00:00:00Z", "change TestRender")
+
+	path := filepath.Join(dir, "a.go")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toks, diags := token.Parse(path, b, nil)
+	if len(diags) != 0 {
+		t.Fatal(diags)
+	}
+	ix, err := evidence.Build([]string{filepath.Join(dir, "a_test.go")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blamer, err := NewBlamer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := Check(blamer, toks, Options{Index: ix})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range found {
+		got = append(got, f.Token.Feature()+": "+f.Reason()+" on "+f.Changed.Format("2006-01-02"))
+	}
+	want := []string{
+		"Parse: Parse changed since token last touched on 2026-02-01",
+		"Render: test TestRender changed since token last touched on 2026-03-01",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// touching the token clears the finding
+	write("a.go", strings.Replace(strings.Replace(src, "return 1", "return 10", 1), "FEATURE=\"Parse\"; ASPECT=Engine; STATUS=TESTED; TEST=TestParse; UPDATED=2026-01-01", "FEATURE=\"Parse\"; ASPECT=Engine; STATUS=TESTED; TEST=TestParse; UPDATED=2026-04-01", 1))
+	commit("2026-04-01T00:00:00Z", "touch Parse")
+	blamer, _ = NewBlamer(dir)
+	found, err = Check(blamer, toks[:1], Options{Index: ix})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 0 {
+		t.Errorf("touched token still stale: %s", found[0].Reason())
+	}
+
+	if _, err := NewBlamer(t.TempDir()); err == nil {
+		t.Error("NewBlamer outside a work tree succeeded")
+	}
+}
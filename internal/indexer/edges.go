@@ -0,0 +1,74 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+// CANARY: REQ=CBIN-169; FEATURE="IndexRequirementEdges"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_169_Engine_IndexRequirementEdges; TEST=TestCANARY_CBIN_169_Engine_SpecReqID; TEST=TestCANARY_CBIN_169_Engine_SpecEdgesOtherKeys
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"go.devnw.com/canary/internal/reqid"
+	"go.devnw.com/canary/internal/specs"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// specDirRe matches the requirement ID a spec directory is named after,
+// e.g. CBIN-147 in CBIN-147-specification-dependencies.
+var specDirRe = regexp.MustCompile(`^` + reqid.Pattern)
+
+// SpecReqID returns the requirement a spec.md at path specifies, when path
+// is specs/<REQ-ID>-<slug>/spec.md.
+func SpecReqID(path string) (string, bool) {
+	id, kind, ok := specDocument(path)
+	if !ok || kind != storage.SearchSpec {
+		return "", false
+	}
+	return id, true
+}
+
+// specDocument returns the requirement and kind (spec or plan) of a
+// specs/<REQ-ID>-<slug>/spec.md or plan.md at path.
+func specDocument(path string) (reqID, kind string, ok bool) {
+	switch filepath.Base(path) {
+	case "spec.md":
+		kind = storage.SearchSpec
+	case "plan.md":
+		kind = storage.SearchPlan
+	default:
+		return "", "", false
+	}
+	dir := filepath.Dir(path)
+	if filepath.Base(filepath.Dir(dir)) != "specs" {
+		return "", "", false
+	}
+	reqID = specDirRe.FindString(filepath.Base(dir))
+	return reqID, kind, reqID != ""
+}
+
+// specEdges returns the dependencies the Dependencies section of the spec
+// of reqID at path declares.
+func specEdges(reqID, path string) ([]storage.RequirementEdge, error) {
+	deps, err := specs.ParseDependenciesFromFile(reqID, path)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]storage.RequirementEdge, 0, len(deps))
+	for _, d := range deps {
+		edges = append(edges, storage.RequirementEdge{
+			Source:           d.Source,
+			Target:           d.Target,
+			Type:             storage.EdgeDependsOn,
+			RequiredFeatures: d.RequiredFeatures,
+			RequiredAspect:   d.RequiredAspect,
+			Description:      d.Description,
+		})
+	}
+
+	return edges, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+func TestCANARY_CBIN_169_Engine_IndexRequirementEdges(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	write(t, root, "a.go", `// CANARY: REQ=CBIN-001; FEATURE="F"; ASPECT=API; STATUS=IMPL; DEPENDS_ON=CBIN-002; UPDATED=2025-10-15`+"\n")
+	write(t, root, ".canary/specs/CBIN-002-parser/spec.md", `# Parser
+
+## Dependencies
+
+- CBIN-003 (Lexer)
+- CBIN-004:Storage
+
+## Features
+`)
+	write(t, root, "docs/specs/notes.md", "## Dependencies\n\n- CBIN-009\n")
+	opts := SyncOptions{Options: Options{Root: root}}
+
+	if _, err := Sync(db, opts); err != nil {
+		t.Fatal(err)
+	}
+	deps, err := db.Dependencies("", "CBIN-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range deps {
+		got = append(got, e.Source+">"+e.Target+":"+e.RequiredAspect)
+	}
+	want := []string{"CBIN-001>CBIN-002:", "CBIN-002>CBIN-003:", "CBIN-002>CBIN-004:Storage"}
+	if len(got) != len(want) {
+		t.Fatalf("dependencies = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("dependencies = %v, want %v", got, want)
+			break
+		}
+	}
+
+	// Deleting the spec and the token's field drops their edges
+	if err := os.Remove(filepath.Join(root, ".canary/specs/CBIN-002-parser/spec.md")); err != nil {
+		t.Fatal(err)
+	}
+	write(t, root, "a.go", "// "+tokenLine("CBIN-001"))
+	if _, err := Sync(db, opts); err != nil {
+		t.Fatal(err)
+	}
+	all, err := db.DependencyEdges("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 0 {
+		t.Errorf("edges left after removal: %+v", all)
+	}
+}
+
+// TestCANARY_CBIN_169_Engine_SpecEdgesOtherKeys verifies spec dependencies
+// are indexed for projects whose requirement IDs don't use the CBIN key.
+func TestCANARY_CBIN_169_Engine_SpecEdgesOtherKeys(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	write(t, root, ".canary/specs/ACME-002-block/spec.md", "# Block\n\n## Dependencies\n\n- ACME-001 (Accounts)\n")
+	if _, err := Sync(db, SyncOptions{Options: Options{Root: root}}); err != nil {
+		t.Fatal(err)
+	}
+	deps, err := db.Dependencies("", "ACME-002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 1 || deps[0].Source != "ACME-002" || deps[0].Target != "ACME-001" {
+		t.Errorf("dependencies = %+v, want ACME-002 on ACME-001", deps)
+	}
+}
+
+func TestCANARY_CBIN_169_Engine_SpecReqID(t *testing.T) {
+	for path, want := range map[string]string{
+		".canary/specs/CBIN-147-specification-dependencies/spec.md": "CBIN-147",
+		"specs/CBIN-CLI-001-cmd/spec.md":                            "CBIN-CLI-001",
+		".canary/specs/CBIN-147-x/plan.md":                          "",
+		"docs/CBIN-147-x/spec.md":                                   "",
+		".canary/specs/notes/spec.md":                               "",
+	} {
+		if got, _ := SpecReqID(filepath.FromSlash(path)); got != want {
+			t.Errorf("SpecReqID(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
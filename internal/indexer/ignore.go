@@ -0,0 +1,108 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	ignore "github.com/sabhiram/go-gitignore"
+	"go.devnw.com/canary/internal/scanner"
+)
+
+// Ignorer decides which paths under a root are skipped. It combines every
+// .gitignore in the tree, the root .canaryignore and the
+// scanner.exclude_paths patterns from project.yaml.
+type Ignorer struct {
+	root    string
+	canary  *ignore.GitIgnore
+	exclude *ignore.GitIgnore
+
+	mu  sync.Mutex
+	git map[string]*ignore.GitIgnore // dir (slash separated, relative) -> rules
+}
+
+// NewIgnorer loads the ignore rules for root. Nested .gitignore files are
+// loaded lazily as directories are visited.
+func NewIgnorer(root string, excludePaths []string) (*Ignorer, error) {
+	canary, err := scanner.LoadCanaryIgnore(root)
+	if err != nil {
+		return nil, err
+	}
+
+	ig := &Ignorer{
+		root:   root,
+		canary: canary,
+		git:    map[string]*ignore.GitIgnore{},
+	}
+	if len(excludePaths) > 0 {
+		ig.exclude = ignore.CompileIgnoreLines(excludePaths...)
+	}
+
+	return ig, nil
+}
+
+// Ignored reports whether rel (relative to the root) should be skipped.
+func (ig *Ignorer) Ignored(rel string, isDir bool) bool {
+	rel = filepath.ToSlash(rel)
+	if rel == "." || rel == "" {
+		return false
+	}
+	if path.Base(rel) == ".git" {
+		return true
+	}
+
+	match := rel
+	if isDir {
+		match += "/"
+	}
+	if ig.canary != nil && ig.canary.MatchesPath(match) {
+		return true
+	}
+	if ig.exclude != nil && ig.exclude.MatchesPath(match) {
+		return true
+	}
+
+	// Each .gitignore applies to paths relative to its own directory.
+	dir := path.Dir(rel)
+	for {
+		if gi := ig.gitignore(dir); gi != nil {
+			sub := match
+			if dir != "." {
+				sub = strings.TrimPrefix(match, dir+"/")
+			}
+			if gi.MatchesPath(sub) {
+				return true
+			}
+		}
+		if dir == "." {
+			return false
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// gitignore returns the compiled .gitignore for dir, or nil if it has none.
+func (ig *Ignorer) gitignore(dir string) *ignore.GitIgnore {
+	ig.mu.Lock()
+	defer ig.mu.Unlock()
+
+	if gi, ok := ig.git[dir]; ok {
+		return gi
+	}
+
+	var gi *ignore.GitIgnore
+	p := filepath.Join(ig.root, filepath.FromSlash(dir), ".gitignore")
+	if _, err := os.Stat(p); err == nil {
+		gi, _ = ignore.CompileIgnoreFile(p)
+	}
+	ig.git[dir] = gi
+
+	return gi
+}
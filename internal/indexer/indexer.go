@@ -0,0 +1,189 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package indexer walks a source tree in parallel and extracts CANARY tokens
+// with the shared token parser.
+package indexer
+
+// CANARY: REQ=CBIN-151; FEATURE="ParallelIndexer"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_151_Engine_WalkRespectsIgnores; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/span"
+	"go.devnw.com/canary/internal/token"
+)
+
+// sniffLen is the number of leading bytes inspected to detect binary files.
+const sniffLen = 8000
+
+// Options configures a Walk.
+type Options struct {
+	Root         string
+	ExcludePaths []string // gitignore-style patterns, e.g. scanner.exclude_paths
+	Workers      int      // defaults to runtime.NumCPU()
+	// Lifecycle is the project's status lifecycle, which tokens' STATUS is
+	// checked against; nil is the default lifecycle.
+	Lifecycle *lifecycle.Lifecycle
+}
+
+// Result holds every token and diagnostic found by a Walk, ordered by file
+// and line.
+type Result struct {
+	Tokens      []*token.Token
+	Diagnostics []token.Diagnostic
+	Files       int // number of text files parsed
+}
+
+// IsBinary reports whether data looks like a binary file. Like git, a NUL
+// byte in the first few kilobytes marks the file as binary.
+func IsBinary(data []byte) bool {
+	if len(data) > sniffLen {
+		data = data[:sniffLen]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// Walk parses every non-ignored text file under opts.Root. Results are
+// sorted by file and line so output is deterministic.
+func Walk(opts Options) (*Result, error) {
+	paths, err := Files(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	for _, f := range ParseFiles(paths, opts.Workers, opts.Lifecycle) {
+		if f.Binary {
+			continue
+		}
+		res.Files++
+		res.Tokens = append(res.Tokens, f.Tokens...)
+		res.Diagnostics = append(res.Diagnostics, f.Diagnostics...)
+	}
+
+	return res, nil
+}
+
+// Files lists every regular file under opts.Root that isn't ignored, in
+// lexical order.
+func Files(opts Options) ([]string, error) {
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+
+	ig, err := NewIgnorer(opts.Root, opts.ExcludePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return ig.files(opts.Root)
+}
+
+// files lists the non-ignored regular files beneath dir, which must be the
+// root or a directory inside it.
+func (ig *Ignorer) files(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == dir {
+				return err
+			}
+			return nil // skip unreadable entries
+		}
+		rel, err := filepath.Rel(ig.root, p)
+		if err != nil {
+			rel = p
+		}
+		if ig.Ignored(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// FileResult is the outcome of parsing one file.
+type FileResult struct {
+	Path        string
+	Hash        string // hex SHA256 of the contents
+	Size        int64
+	Binary      bool
+	Err         error // read error; the file is otherwise empty
+	Tokens      []*token.Token
+	Spans       []span.Span // code each of Tokens annotates, same order
+	Diagnostics []token.Diagnostic
+}
+
+// ParseFiles reads and parses paths concurrently, checking tokens against
+// lc. Results are returned in the same order as paths.
+func ParseFiles(paths []string, workers int, lc *lifecycle.Lifecycle) []FileResult {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]FileResult, len(paths))
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				results[i] = ParseFile(paths[i], lc)
+			}
+		}()
+	}
+	for i := range paths {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+
+	return results
+}
+
+// ParseFile reads, hashes and parses a single file and finds the code each
+// token annotates, checking tokens against lc. Binary files are hashed but
+// not parsed.
+func ParseFile(path string, lc *lifecycle.Lifecycle) FileResult {
+	res := FileResult{Path: path}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	sum := sha256.Sum256(b)
+	res.Hash = hex.EncodeToString(sum[:])
+	res.Size = int64(len(b))
+
+	if IsBinary(b) {
+		res.Binary = true
+		return res
+	}
+	res.Tokens, res.Diagnostics = token.Parse(path, b, lc)
+	res.Spans = span.Tokens(path, b, res.Tokens)
+
+	return res
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func tokenLine(req string) string {
+	return "CANARY: REQ=" + req + `; FEATURE="F"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15` + "\n"
+}
+
+func write(t *testing.T, root, rel, body string) {
+	t.Helper()
+	p := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestCANARY_CBIN_151_Engine_WalkRespectsIgnores verifies the walker honours
+// .gitignore (including nested files), .canaryignore, exclude_paths and
+// binary sniffing while picking up extensions grep used to miss.
+func TestCANARY_CBIN_151_Engine_WalkRespectsIgnores(t *testing.T) {
+	root := t.TempDir()
+	write(t, root, ".gitignore", "build/\n*.gen.go\n")
+	write(t, root, ".canaryignore", "secret/\n")
+	write(t, root, "sub/.gitignore", "local.ts\n")
+
+	write(t, root, "app.tsx", "// "+tokenLine("CBIN-001"))
+	write(t, root, "deploy.tf", "# "+tokenLine("CBIN-002"))
+	write(t, root, "run.sh", "# "+tokenLine("CBIN-003"))
+	write(t, root, "weird:name.go", "// "+tokenLine("CBIN-004"))
+	write(t, root, "sub/keep.kt", "// "+tokenLine("CBIN-005"))
+
+	write(t, root, "build/out.go", "// "+tokenLine("CBIN-900"))
+	write(t, root, "x.gen.go", "// "+tokenLine("CBIN-901"))
+	write(t, root, "secret/a.go", "// "+tokenLine("CBIN-902"))
+	write(t, root, "sub/local.ts", "// "+tokenLine("CBIN-903"))
+	write(t, root, "vendor/dep/lib.go", "// "+tokenLine("CBIN-904"))
+	write(t, root, "blob.dat", "\x00\x01// "+tokenLine("CBIN-905"))
+	write(t, root, ".git/config", tokenLine("CBIN-906"))
+
+	res, err := Walk(Options{Root: root, ExcludePaths: []string{"vendor/"}, Workers: 4})
+	if err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+
+	var got []string
+	for _, tok := range res.Tokens {
+		got = append(got, tok.ReqID())
+	}
+	sort.Strings(got)
+	want := []string{"CBIN-001", "CBIN-002", "CBIN-003", "CBIN-004", "CBIN-005"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	if IsBinary([]byte("plain text\n")) {
+		t.Error("text detected as binary")
+	}
+	if !IsBinary([]byte("PNG\x00\x00")) {
+		t.Error("NUL bytes not detected as binary")
+	}
+}
+
+func TestWalk_Deterministic(t *testing.T) {
+	root := t.TempDir()
+	write(t, root, "b.go", "// "+tokenLine("CBIN-002")+"// "+tokenLine("CBIN-003"))
+	write(t, root, "a.go", "// "+tokenLine("CBIN-001"))
+
+	res, err := Walk(Options{Root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Tokens) != 3 || res.Files != 2 {
+		t.Fatalf("tokens=%d files=%d", len(res.Tokens), res.Files)
+	}
+	for i, want := range []string{"CBIN-001", "CBIN-002", "CBIN-003"} {
+		if res.Tokens[i].ReqID() != want {
+			t.Errorf("token %d = %s, want %s", i, res.Tokens[i].ReqID(), want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+// CANARY: REQ=CBIN-170; FEATURE="IndexRequirements"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_170_Engine_IndexRequirements; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.devnw.com/canary/internal/specs"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// SyncRequirements rebuilds the requirements table and the spec search index
+// from the tokens already indexed and the specs under opts.Root, without
+// parsing any source files.
+func SyncRequirements(db *storage.DB, opts SyncOptions) error {
+	tx, err := db.BeginIndex()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after Commit
+
+	if err := syncRequirements(tx, opts); err != nil {
+		return err
+	}
+	if err := syncSpecSections(tx, opts); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit requirements: %w", err)
+	}
+	return nil
+}
+
+// syncRequirements derives one requirement per spec directory and per
+// requirement ID with tokens. The spec supplies the title, aspect and
+// creation date; tokens fill in whatever the spec doesn't, and a
+// requirement closes once every token has a completed status.
+func syncRequirements(tx *storage.IndexTx, opts SyncOptions) error {
+	root := opts.Root
+	if root == "" {
+		root = "."
+	}
+
+	toks, err := tx.RequirementTokens(opts.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	byID := map[string]*storage.Requirement{}
+	get := func(id string) *storage.Requirement {
+		r := byID[id]
+		if r == nil {
+			r = &storage.Requirement{ReqID: id, ProjectID: opts.ProjectID}
+			byID[id] = r
+		}
+		return r
+	}
+
+	open := map[string]bool{}
+	for _, t := range toks {
+		r := get(t.ReqID)
+		r.Tokens++
+		if r.Aspect == "" {
+			r.Aspect = t.Aspect
+		}
+		if r.Owner == "" {
+			r.Owner = t.Owner
+		}
+		if t.CreatedAt != "" && (r.CreatedAt == "" || t.CreatedAt < r.CreatedAt) {
+			r.CreatedAt = t.CreatedAt
+		}
+		if !opts.Lifecycle.Satisfies(t.Status, t.Aspect) {
+			open[t.ReqID] = true
+		}
+	}
+
+	dirs, err := filepath.Glob(filepath.Join(root, ".canary", "specs", "*", "spec.md"))
+	if err != nil {
+		return fmt.Errorf("find specs: %w", err)
+	}
+	for _, path := range dirs {
+		id, ok := SpecReqID(path)
+		if !ok {
+			continue
+		}
+		h, err := specs.ReadHeader(path)
+		if err != nil {
+			return err
+		}
+
+		r := get(id)
+		r.SpecPath = path
+		r.Title = h.Title
+		if h.Aspect != "" {
+			r.Aspect = h.Aspect
+		}
+		if h.Created != "" {
+			r.CreatedAt = h.Created
+		}
+		if plan := filepath.Join(filepath.Dir(path), "plan.md"); fileExists(plan) {
+			r.PlanPath = plan
+		}
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	reqs := make([]*storage.Requirement, 0, len(byID))
+	for id, r := range byID {
+		if r.Tokens > 0 && !open[id] {
+			r.ClosedAt = today
+		}
+		reqs = append(reqs, r)
+	}
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].ReqID < reqs[j].ReqID })
+
+	return tx.SyncRequirements(opts.ProjectID, reqs)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+func TestCANARY_CBIN_170_Engine_IndexRequirements(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	write(t, root, "a.go", `// CANARY: REQ=CBIN-001; FEATURE="F"; ASPECT=API; STATUS=TESTED; TEST=TestF; OWNER=ana; UPDATED=2025-10-15`+"\n")
+	write(t, root, "b.go", `// CANARY: REQ=CBIN-003; FEATURE="G"; ASPECT=CLI; STATUS=IMPL; UPDATED=2025-10-15`+"\n")
+	write(t, root, ".canary/specs/CBIN-002-parser/spec.md", `# Feature Specification: Parser
+
+**Aspect:** Engine
+**Created:** 2026-10-01
+
+## Overview
+`)
+	write(t, root, ".canary/specs/CBIN-002-parser/plan.md", "# Plan\n")
+	opts := SyncOptions{Options: Options{Root: root}}
+
+	if _, err := Sync(db, opts); err != nil {
+		t.Fatal(err)
+	}
+	reqs, err := db.ListRequirements("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]*storage.Requirement{}
+	for _, r := range reqs {
+		got[r.ReqID] = r
+	}
+	if len(got) != 3 {
+		t.Fatalf("requirements = %d, want 3", len(got))
+	}
+	if r := got["CBIN-001"]; r.Owner != "ana" || r.Aspect != "API" || r.ClosedAt == "" || r.Tokens != 1 {
+		t.Errorf("CBIN-001 = %+v", r)
+	}
+	if r := got["CBIN-002"]; r.Title != "Parser" || r.Aspect != "Engine" || r.CreatedAt != "2026-10-01" ||
+		r.Tokens != 0 || filepath.Base(r.PlanPath) != "plan.md" || r.SpecPath == "" {
+		t.Errorf("CBIN-002 = %+v", r)
+	}
+	if r := got["CBIN-003"]; r.ClosedAt != "" {
+		t.Errorf("CBIN-003 closed with an IMPL token: %+v", r)
+	}
+
+	// Removing a requirement's last token and spec removes the requirement
+	if err := os.Remove(filepath.Join(root, "b.go")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, ".canary/specs/CBIN-002-parser")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sync(db, opts); err != nil {
+		t.Fatal(err)
+	}
+	reqs, err = db.ListRequirements("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 1 || reqs[0].ReqID != "CBIN-001" {
+		t.Errorf("requirements after removal = %+v", reqs)
+	}
+}
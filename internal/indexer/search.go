@@ -0,0 +1,100 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+// CANARY: REQ=CBIN-171; FEATURE="IndexSpecSections"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_171_Engine_IndexSpecSections; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+// syncSpecSections rebuilds the search index of spec and plan sections from
+// .canary/specs under opts.Root. Specs are read directly rather than through
+// the file walk because the default .canaryignore skips .canary/.
+func syncSpecSections(tx *storage.IndexTx, opts SyncOptions) error {
+	root := opts.Root
+	if root == "" {
+		root = "."
+	}
+
+	if err := tx.ClearSpecSections(opts.ProjectID); err != nil {
+		return err
+	}
+	for _, name := range []string{"spec.md", "plan.md"} {
+		paths, err := filepath.Glob(filepath.Join(root, ".canary", "specs", "*", name))
+		if err != nil {
+			return fmt.Errorf("find %s files: %w", name, err)
+		}
+		for _, path := range paths {
+			reqID, kind, ok := specDocument(path)
+			if !ok {
+				continue
+			}
+			sections, err := specSections(path)
+			if err != nil {
+				return err
+			}
+			if err := tx.ReplaceSpecSections(path, opts.ProjectID, reqID, kind, sections); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// specSections splits the markdown at path into sections at each # or ##
+// heading outside code fences. Text before the first heading is a section
+// without a heading; deeper headings stay in their section's body.
+func specSections(path string) ([]storage.SpecSection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var (
+		sections []storage.SpecSection
+		cur      = storage.SpecSection{Line: 1}
+		body     strings.Builder
+		fenced   bool
+	)
+	flush := func() {
+		cur.Body = strings.TrimSpace(body.String())
+		if cur.Heading != "" || cur.Body != "" {
+			sections = append(sections, cur)
+		}
+		body.Reset()
+	}
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		trimmed := strings.TrimSpace(text)
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = !fenced
+		}
+		if !fenced && (strings.HasPrefix(trimmed, "# ") || strings.HasPrefix(trimmed, "## ")) {
+			flush()
+			cur = storage.SpecSection{Heading: strings.TrimSpace(strings.TrimLeft(trimmed, "#")), Line: line}
+			continue
+		}
+		body.WriteString(text)
+		body.WriteByte('\n')
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	flush()
+
+	return sections, nil
+}
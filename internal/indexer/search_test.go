@@ -0,0 +1,85 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+func TestCANARY_CBIN_171_Engine_IndexSpecSections(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	write(t, root, ".canary/specs/CBIN-002-parser/spec.md", "# Feature Specification: Parser\n\n"+
+		"## Overview\n\nReads expressions with operator precedence.\n\n"+
+		"```markdown\n## Example\n```\n\n"+
+		"## Requirements\n\nReports syntax errors with line numbers.\n")
+	write(t, root, ".canary/specs/CBIN-002-parser/plan.md", "# Plan\n\n## Approach\n\nA Pratt parser.\n")
+	write(t, root, "docs/notes.md", "## Overview\n\nOperator precedence notes.\n")
+	// As written by canary init: specs are indexed even though .canary/ is not walked
+	write(t, root, ".canaryignore", ".canary/\n")
+	opts := SyncOptions{Options: Options{Root: root}}
+
+	if _, err := Sync(db, opts); err != nil {
+		t.Fatal(err)
+	}
+	specOnly := storage.SearchOptions{Kinds: []string{storage.SearchSpec, storage.SearchPlan}}
+
+	results, err := db.Search("precedence", specOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Search(precedence) = %+v, want the spec's Overview", results)
+	}
+	if r := results[0]; r.ReqID != "CBIN-002" || r.Kind != storage.SearchSpec || r.Title != "Overview" || r.Line != 3 {
+		t.Errorf("Search(precedence) = %+v", r)
+	}
+
+	// A heading inside a code fence stays in its section
+	results, err = db.Search("example", specOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Title != "Overview" {
+		t.Errorf("Search(example) = %+v", results)
+	}
+
+	results, err = db.Search("pratt", specOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Kind != storage.SearchPlan || results[0].Title != "Approach" {
+		t.Errorf("Search(pratt) = %+v", results)
+	}
+
+	// Removing the plan removes its sections
+	if err := os.Remove(filepath.Join(root, ".canary/specs/CBIN-002-parser/plan.md")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sync(db, opts); err != nil {
+		t.Fatal(err)
+	}
+	results, err = db.Search("pratt", specOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Search(pratt) after removing the plan = %+v", results)
+	}
+}
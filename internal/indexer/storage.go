@@ -0,0 +1,65 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+import (
+	"strconv"
+	"strings"
+
+	"go.devnw.com/canary/internal/storage"
+	"go.devnw.com/canary/internal/token"
+)
+
+// StorageToken converts a parsed token into its database representation.
+func StorageToken(tok *token.Token) *storage.Token {
+	docPath := tok.Get("DOC")
+	docType := tok.Get("DOC_TYPE")
+
+	// Auto-infer DOC_TYPE from type prefix if not explicitly set
+	if docPath != "" && docType == "" {
+		// Extract type from first doc path (e.g., "user:docs/file.md" -> "user")
+		firstPath := strings.Split(docPath, ",")[0]
+		if strings.Contains(firstPath, ":") {
+			docType = strings.Split(firstPath, ":")[0]
+		}
+	}
+
+	st := &storage.Token{
+		ReqID:       tok.ReqID(),
+		Feature:     tok.Feature(),
+		Aspect:      tok.Aspect(),
+		Status:      tok.Status(),
+		FilePath:    tok.File,
+		LineNumber:  tok.Line,
+		Test:        tok.Get("TEST"),
+		Bench:       tok.Get("BENCH"),
+		Owner:       tok.Get("OWNER"),
+		Phase:       tok.Get("PHASE"),
+		Keywords:    tok.Get("KEYWORDS"),
+		SpecStatus:  tok.Get("SPEC_STATUS"),
+		UpdatedAt:   tok.Get("UPDATED"),
+		CreatedAt:   tok.Get("CREATED"),
+		StartedAt:   tok.Get("STARTED"),
+		CompletedAt: tok.Get("COMPLETED"),
+		DependsOn:   tok.Get("DEPENDS_ON"),
+		Blocks:      tok.Get("BLOCKS"),
+		RelatedTo:   tok.Get("RELATED_TO"),
+		DocPath:     docPath,
+		DocHash:     tok.Get("DOC_HASH"),
+		DocType:     docType,
+		RawToken:    strings.TrimSpace(tok.Raw),
+		Priority:    5, // default
+	}
+
+	if p, err := strconv.Atoi(tok.Get("PRIORITY")); err == nil {
+		st.Priority = p
+	}
+	if st.SpecStatus == "" {
+		st.SpecStatus = "draft"
+	}
+
+	return st
+}
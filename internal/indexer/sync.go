@@ -0,0 +1,296 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+// CANARY: REQ=CBIN-152; FEATURE="IncrementalSync"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_152_Engine_IncrementalSync; OWNER=canary; UPDATED=2026-10-17
+// CANARY: REQ=CBIN-153; FEATURE="IndexReconcile"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_153_Engine_ReconcileNoDoubleCount; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.devnw.com/canary/internal/storage"
+	"go.devnw.com/canary/internal/token"
+)
+
+// SyncOptions configures an incremental index pass.
+type SyncOptions struct {
+	Options
+
+	ProjectID  string
+	CommitHash string
+	Branch     string
+
+	// Full re-parses every file even when its size and mtime are unchanged.
+	Full bool
+	// Reconcile implies Full and also deletes every indexed row whose file
+	// was not seen in this pass, including rows from older indexers.
+	Reconcile bool
+}
+
+// SyncStats summarizes an incremental index pass.
+type SyncStats struct {
+	storage.TokenChanges
+
+	Files        int // files under the root after ignore rules
+	Parsed       int // files read and parsed this pass
+	Unchanged    int // files skipped because they were unchanged
+	RemovedFiles int // previously indexed files that no longer exist
+	Tokens       int // tokens stored for parsed files
+
+	// Events appended to the token history by this pass
+	Events []storage.TokenEvent
+
+	Diagnostics []token.Diagnostic
+}
+
+// Sync brings the database in line with the tree under opts.Root. Files whose
+// size and mtime match the last pass are skipped, files whose content hash
+// matches are only re-stamped, and everything else has its tokens replaced.
+// Files that disappeared have their tokens removed, and tokens that only
+// changed location are reported as moves. The requirements table and the
+// spec search index are then rebuilt from the tokens and specs, and every
+// token change is appended to the token history. All writes happen in a
+// single transaction.
+func Sync(db *storage.DB, opts SyncOptions) (*SyncStats, error) {
+	if opts.Reconcile {
+		opts.Full = true
+	}
+
+	prev, err := db.GetIndexedFiles(opts.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := Files(opts.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &SyncStats{Files: len(paths)}
+	seen := make(map[string]bool, len(paths))
+	infos := make(map[string]os.FileInfo, len(paths))
+	var candidates []string
+	for _, p := range paths {
+		seen[p] = true
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		infos[p] = info
+		if f := prev[p]; f != nil && !opts.Full &&
+			f.Size == info.Size() && f.ModTime == info.ModTime().UnixNano() {
+			stats.Unchanged++
+			continue
+		}
+		candidates = append(candidates, p)
+	}
+
+	tx, err := db.BeginIndex()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after Commit
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, res := range ParseFiles(candidates, opts.Workers, opts.Lifecycle) {
+		if err := apply(tx, opts, prev[res.Path], res, infos[res.Path], now, stats); err != nil {
+			return nil, err
+		}
+	}
+
+	for p := range prev {
+		if seen[p] {
+			continue
+		}
+		if _, err := tx.RemoveFile(p, opts.ProjectID); err != nil {
+			return nil, err
+		}
+		stats.RemovedFiles++
+	}
+
+	if opts.Reconcile {
+		if _, err := tx.PruneUnseen(seen, opts.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	if err := syncRequirements(tx, opts); err != nil {
+		return nil, err
+	}
+	if err := syncSpecSections(tx, opts); err != nil {
+		return nil, err
+	}
+	if stats.Events, err = tx.RecordEvents(opts.CommitHash, opts.Branch, now); err != nil {
+		return nil, err
+	}
+	stats.TokenChanges = tx.Changes()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit index: %w", err)
+	}
+
+	return stats, nil
+}
+
+// SyncPaths re-indexes only paths, typically files reported by a watcher.
+// Directories are walked with the usual ignore rules. Paths that no longer
+// exist or are now ignored have their tokens removed, along with every
+// tracked file beneath them.
+func SyncPaths(db *storage.DB, opts SyncOptions, paths []string) (*SyncStats, error) {
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+
+	ig, err := NewIgnorer(opts.Root, opts.ExcludePaths)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := db.GetIndexedFiles(opts.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &SyncStats{}
+	infos := map[string]os.FileInfo{}
+	gone := map[string]bool{}
+	var candidates []string
+	for _, p := range paths {
+		p = filepath.Clean(p)
+		rel, err := filepath.Rel(opts.Root, p)
+		if err != nil {
+			continue
+		}
+
+		info, statErr := os.Stat(p)
+		switch {
+		case statErr == nil && info.IsDir() && !ig.Ignored(rel, true):
+			files, err := ig.files(p)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range files {
+				if fi, err := os.Stat(f); err == nil && infos[f] == nil {
+					infos[f] = fi
+					candidates = append(candidates, f)
+				}
+			}
+		case statErr == nil && info.Mode().IsRegular() && !ig.Ignored(rel, false):
+			if infos[p] == nil {
+				infos[p] = info
+				candidates = append(candidates, p)
+			}
+		default:
+			prefix := p + string(filepath.Separator)
+			for tracked := range prev {
+				if tracked == p || strings.HasPrefix(tracked, prefix) {
+					gone[tracked] = true
+				}
+			}
+		}
+	}
+	stats.Files = len(candidates)
+
+	tx, err := db.BeginIndex()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after Commit
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, res := range ParseFiles(candidates, opts.Workers, opts.Lifecycle) {
+		if err := apply(tx, opts, prev[res.Path], res, infos[res.Path], now, stats); err != nil {
+			return nil, err
+		}
+	}
+	for p := range gone {
+		if infos[p] != nil {
+			continue
+		}
+		if _, err := tx.RemoveFile(p, opts.ProjectID); err != nil {
+			return nil, err
+		}
+		stats.RemovedFiles++
+	}
+	if err := syncRequirements(tx, opts); err != nil {
+		return nil, err
+	}
+	if err := syncSpecSections(tx, opts); err != nil {
+		return nil, err
+	}
+	if stats.Events, err = tx.RecordEvents(opts.CommitHash, opts.Branch, now); err != nil {
+		return nil, err
+	}
+	stats.TokenChanges = tx.Changes()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit index: %w", err)
+	}
+
+	return stats, nil
+}
+
+// apply stores one parsed file. Files whose content hash matches prev are
+// only re-stamped.
+func apply(tx *storage.IndexTx, opts SyncOptions, prev *storage.IndexedFile, res FileResult, info os.FileInfo, now string, stats *SyncStats) error {
+	if res.Err != nil || info == nil {
+		return nil
+	}
+
+	file := &storage.IndexedFile{
+		Path:        res.Path,
+		ProjectID:   opts.ProjectID,
+		ContentHash: res.Hash,
+		ModTime:     info.ModTime().UnixNano(),
+		Size:        res.Size,
+		IndexedAt:   now,
+	}
+
+	// Content unchanged (e.g. touched or checked out again): only refresh
+	// the stat fields so the next pass can skip it cheaply.
+	if prev != nil && !opts.Full && prev.ContentHash == res.Hash {
+		file.TokenCount = prev.TokenCount
+		stats.Unchanged++
+		return tx.TouchFile(file)
+	}
+
+	stats.Parsed++
+	stats.Diagnostics = append(stats.Diagnostics, res.Diagnostics...)
+
+	toks := make([]*storage.Token, 0, len(res.Tokens))
+	for i, t := range res.Tokens {
+		st := StorageToken(t)
+		if i < len(res.Spans) {
+			sp := res.Spans[i]
+			st.Symbol, st.SymbolKind, st.SpanStart, st.SpanEnd = sp.Symbol, sp.Kind, sp.Start, sp.End
+		}
+		st.CommitHash = opts.CommitHash
+		st.Branch = opts.Branch
+		st.IndexedAt = now
+		toks = append(toks, st)
+	}
+
+	if _, err := tx.ReplaceFile(file, toks); err != nil {
+		return err
+	}
+	stats.Tokens += len(toks)
+
+	// A spec's Dependencies section joins the token relationships in the
+	// requirement graph
+	if reqID, ok := SpecReqID(res.Path); ok {
+		edges, err := specEdges(reqID, res.Path)
+		if err != nil {
+			return err
+		}
+		if err := tx.ReplaceSpecEdges(res.Path, opts.ProjectID, edges); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
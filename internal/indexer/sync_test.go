@@ -0,0 +1,173 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+func TestCANARY_CBIN_152_Engine_IncrementalSync(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	write(t, root, "a.go", "// "+tokenLine("CBIN-001"))
+	write(t, root, "b.go", "// "+tokenLine("CBIN-002"))
+	opts := SyncOptions{Options: Options{Root: root}}
+
+	stats, err := Sync(db, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Parsed != 2 || stats.Added != 2 {
+		t.Fatalf("first sync = %+v", stats)
+	}
+
+	// Nothing changed: nothing parsed
+	stats, err = Sync(db, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Parsed != 0 || stats.Unchanged != 2 || stats.Added+stats.Changed+stats.Moved+stats.Removed != 0 {
+		t.Fatalf("no-op sync = %+v", stats)
+	}
+
+	// Edit a.go, delete b.go, add c.go
+	write(t, root, "a.go", "package a\n\n// "+tokenLine("CBIN-001")+"// "+tokenLine("CBIN-003"))
+	if err := os.Remove(filepath.Join(root, "b.go")); err != nil {
+		t.Fatal(err)
+	}
+	write(t, root, "c.go", "// "+tokenLine("CBIN-004"))
+
+	stats, err = Sync(db, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// CBIN-001 moved down two lines; CBIN-003 and CBIN-004 are new
+	if stats.Added != 2 || stats.Moved != 1 || stats.Changed != 0 || stats.Removed != 1 ||
+		stats.RemovedFiles != 1 || stats.Parsed != 2 {
+		t.Fatalf("edit sync = %+v", stats)
+	}
+
+	toks, err := db.ListTokens(nil, "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, tok := range toks {
+		got[tok.ReqID] = true
+	}
+	if len(toks) != 3 || got["CBIN-002"] || !got["CBIN-003"] || !got["CBIN-004"] {
+		t.Errorf("unexpected tokens after edit: %v", got)
+	}
+
+	// Touching without changing content only refreshes the stat fields
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(root, "c.go"), future, future); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = Sync(db, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Parsed != 0 || stats.Unchanged != 2 {
+		t.Errorf("touch sync = %+v", stats)
+	}
+}
+
+func TestCANARY_CBIN_153_Engine_ReconcileNoDoubleCount(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	path := filepath.Join(root, "a.go")
+	write(t, root, "a.go", "// "+tokenLine("CBIN-001"))
+
+	// Simulate a row left by the old line-keyed indexer one line up
+	stale := &storage.Token{
+		ReqID: "CBIN-001", Feature: "F", Aspect: "API", Status: "IMPL",
+		FilePath: path, LineNumber: 7, UpdatedAt: "2025-10-15",
+		RawToken: "// CANARY: old", IndexedAt: "then",
+	}
+	if err := db.UpsertToken(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := SyncOptions{Options: Options{Root: root}, Reconcile: true}
+	stats, err := Sync(db, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Added != 0 || stats.Changed != 1 || stats.Removed != 0 {
+		t.Errorf("reconcile = %+v, want the stale row to be replaced", stats)
+	}
+
+	// Move the token down one line; it must be reported as a move
+	write(t, root, "a.go", "\n// "+tokenLine("CBIN-001"))
+	stats, err = Sync(db, SyncOptions{Options: Options{Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Moved != 1 || stats.Added != 0 || stats.Removed != 0 {
+		t.Errorf("move = %+v, want a single move", stats)
+	}
+
+	toks, err := db.ListTokens(nil, "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(toks) != 1 || toks[0].LineNumber != 2 {
+		t.Fatalf("expected a single token on line 2, got %d rows", len(toks))
+	}
+}
+
+func TestSync_StoresCodeSpans(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	write(t, root, "a.go", "package a\n\n// "+tokenLine("CBIN-001")+"func (s *Server) Run() {\n\treturn\n}\n")
+	if _, err := Sync(db, SyncOptions{Options: Options{Root: root}}); err != nil {
+		t.Fatal(err)
+	}
+
+	toks, err := db.GetTokensByReqID("CBIN-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(toks) != 1 {
+		t.Fatalf("got %d tokens", len(toks))
+	}
+	if tok := toks[0]; tok.Symbol != "Server.Run" || tok.SymbolKind != "method" || tok.SpanStart != 4 || tok.SpanEnd != 6 {
+		t.Errorf("span = %s %s %d-%d, want method Server.Run 4-6", tok.SymbolKind, tok.Symbol, tok.SpanStart, tok.SpanEnd)
+	}
+}
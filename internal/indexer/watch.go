@@ -0,0 +1,161 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+// CANARY: REQ=CBIN-154; FEATURE="WatchIndex"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_154_Engine_WatchDebounce; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.devnw.com/canary/internal/storage"
+)
+
+// DefaultDebounce is how long Watch waits for a burst of events to settle
+// before re-indexing.
+const DefaultDebounce = 300 * time.Millisecond
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	SyncOptions
+
+	// Debounce is the quiet period after the last event before the changed
+	// paths are re-indexed. Defaults to DefaultDebounce.
+	Debounce time.Duration
+
+	// OnSync is called after every batch with the changed paths and the
+	// result of re-indexing them.
+	OnSync func(paths []string, stats *SyncStats)
+	// OnError is called for watcher and indexing errors. Watch keeps running
+	// after an error.
+	OnError func(error)
+}
+
+// Watch re-indexes files under opts.Root into db as they change until ctx
+// is cancelled. Events are batched so a save that touches several files, or
+// an editor writing through a temp file, results in a single pass.
+func Watch(ctx context.Context, db *storage.DB, opts WatchOptions) error {
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultDebounce
+	}
+	report := func(err error) {
+		if opts.OnError != nil {
+			opts.OnError(err)
+		}
+	}
+
+	ig, err := NewIgnorer(opts.Root, opts.ExcludePaths)
+	if err != nil {
+		return err
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close() //nolint:errcheck // nothing to do on shutdown
+
+	if err := addTree(w, ig, opts.Root); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(opts.Debounce)
+	timer.Stop()
+	pending := map[string]bool{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Op == fsnotify.Chmod {
+				continue
+			}
+			p := filepath.Clean(ev.Name)
+			rel, err := filepath.Rel(opts.Root, p)
+			if err != nil {
+				continue
+			}
+
+			info, statErr := os.Stat(p)
+			isDir := statErr == nil && info.IsDir()
+			if ig.Ignored(rel, isDir) {
+				// A path that became ignored still has to be removed if it
+				// was indexed, which SyncPaths handles; anything else ignored
+				// is noise.
+				if statErr == nil {
+					continue
+				}
+			}
+			if isDir && ev.Has(fsnotify.Create) {
+				if err := addTree(w, ig, p); err != nil {
+					report(err)
+				}
+			}
+
+			pending[p] = true
+			timer.Reset(opts.Debounce)
+
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			report(err)
+
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			paths := make([]string, 0, len(pending))
+			for p := range pending {
+				paths = append(paths, p)
+			}
+			sort.Strings(paths)
+			pending = map[string]bool{}
+
+			stats, err := SyncPaths(db, opts.SyncOptions, paths)
+			if err != nil {
+				report(err)
+				continue
+			}
+			if opts.OnSync != nil {
+				opts.OnSync(paths, stats)
+			}
+		}
+	}
+}
+
+// addTree watches dir and every non-ignored directory beneath it.
+func addTree(w *fsnotify.Watcher, ig *Ignorer, dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == dir && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if rel, err := filepath.Rel(ig.root, p); err == nil && ig.Ignored(rel, true) {
+			return filepath.SkipDir
+		}
+		return w.Add(p)
+	})
+}
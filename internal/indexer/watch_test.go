@@ -0,0 +1,92 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package indexer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.devnw.com/canary/internal/storage"
+)
+
+func TestCANARY_CBIN_154_Engine_WatchDebounce(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "canary.db")
+	if err := storage.MigrateDB(dbPath, "all"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	write(t, root, "a.go", "// "+tokenLine("CBIN-001"))
+	opts := SyncOptions{Options: Options{Root: root}}
+	if _, err := Sync(db, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	batches := make(chan *SyncStats, 8)
+	done := make(chan error, 1)
+	ready := make(chan struct{})
+	go func() {
+		close(ready)
+		done <- Watch(ctx, db, WatchOptions{
+			SyncOptions: opts,
+			Debounce:    50 * time.Millisecond,
+			OnSync:      func(_ []string, s *SyncStats) { batches <- s },
+			OnError:     func(err error) { t.Error(err) },
+		})
+	}()
+	<-ready
+	time.Sleep(100 * time.Millisecond) // let the watcher register
+
+	// A burst of writes settles into one batch
+	write(t, root, "a.go", "// "+tokenLine("CBIN-001")+"// "+tokenLine("CBIN-002"))
+	write(t, root, "sub/b.go", "// "+tokenLine("CBIN-003"))
+	if err := os.Remove(filepath.Join(root, "a.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	var added, removed int
+	deadline := time.After(5 * time.Second)
+	for added < 1 || removed < 1 {
+		select {
+		case s := <-batches:
+			added += s.Added + s.Moved
+			removed += s.Removed + s.Moved
+		case <-deadline:
+			t.Fatalf("timed out: added=%d removed=%d", added, removed)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	toks, err := db.GetTokensByReqID("CBIN-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(toks) != 0 {
+		t.Errorf("CBIN-001 still indexed: %v", toks)
+	}
+	files, err := db.GetIndexedFiles("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := files[filepath.Join(root, "sub", "b.go")]; !ok || len(files) != 1 {
+		t.Errorf("indexed files = %v", files)
+	}
+}
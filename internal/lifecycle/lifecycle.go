@@ -0,0 +1,311 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package lifecycle defines the STATUS values a CANARY token can take, their
+// order, which of them satisfy dependencies and how tokens are promoted by
+// their TEST= and BENCH= evidence. The default lifecycle is
+// MISSING → STUB → IMPL → TESTED → BENCHED → REMOVED; projects replace it
+// with the lifecycle section of .canary/project.yaml.
+package lifecycle
+
+// CANARY: REQ=CBIN-162; FEATURE="StatusLifecycle"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_162_Engine_Lifecycle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Evidence conditions a promotion can require.
+const (
+	WhenTests   = "tests"   // the token names at least one TEST=
+	WhenBenches = "benches" // the token names at least one BENCH=
+)
+
+// State is one STATUS value.
+type State struct {
+	Name string `yaml:"name"`
+	// Satisfies marks the state as complete: it satisfies dependencies
+	// and counts towards progress.
+	Satisfies bool `yaml:"satisfies"`
+	// Pending marks work `canary next` may pick up. Earlier pending states
+	// are picked first.
+	Pending bool `yaml:"pending"`
+}
+
+// Promotion moves a token in one of the From states to To when it has all
+// of the evidence in When.
+type Promotion struct {
+	From []string `yaml:"from"`
+	To   string   `yaml:"to"`
+	When []string `yaml:"when"`
+}
+
+// AspectRules overrides the lifecycle for the tokens of one aspect. A nil
+// field keeps the project-wide rule, so an empty Promotions list (rather
+// than a missing one) turns promotion off.
+type AspectRules struct {
+	Satisfies  []string    `yaml:"satisfies"`
+	Promotions []Promotion `yaml:"promotions"`
+}
+
+// Lifecycle is the full set of states and rules. States are listed in
+// lifecycle order and promotions are applied in the order listed, each at
+// most once. A nil *Lifecycle is the default lifecycle.
+type Lifecycle struct {
+	States     []State                `yaml:"states"`
+	Promotions []Promotion            `yaml:"promotions"`
+	Aspects    map[string]AspectRules `yaml:"aspects"`
+}
+
+// Default returns the built-in lifecycle.
+func Default() *Lifecycle {
+	return &Lifecycle{
+		States: []State{
+			{Name: "MISSING"},
+			{Name: "STUB", Pending: true},
+			{Name: "IMPL", Pending: true},
+			{Name: "TESTED", Satisfies: true},
+			{Name: "BENCHED", Satisfies: true},
+			{Name: "REMOVED"},
+		},
+		Promotions: []Promotion{
+			{From: []string{"IMPL"}, To: "TESTED", When: []string{WhenTests}},
+			{From: []string{"IMPL", "TESTED"}, To: "BENCHED", When: []string{WhenBenches}},
+		},
+	}
+}
+
+// builtin is the lifecycle a nil *Lifecycle stands for.
+var builtin = Default()
+
+// or returns l, or the default lifecycle when l is nil.
+func (l *Lifecycle) or() *Lifecycle {
+	if l == nil {
+		return builtin
+	}
+	return l
+}
+
+// Validate checks that every state is named once and that every rule only
+// refers to known states and conditions.
+func (l *Lifecycle) Validate() error {
+	l = l.or()
+	if len(l.States) == 0 {
+		return fmt.Errorf("lifecycle has no states")
+	}
+	seen := map[string]bool{}
+	for _, s := range l.States {
+		switch {
+		case s.Name == "":
+			return fmt.Errorf("lifecycle state without a name")
+		case s.Name != strings.ToUpper(s.Name):
+			return fmt.Errorf("lifecycle state %q must be upper case", s.Name)
+		case seen[s.Name]:
+			return fmt.Errorf("lifecycle state %s listed twice", s.Name)
+		}
+		seen[s.Name] = true
+	}
+
+	known := func(where, name string) error {
+		if !seen[name] {
+			return fmt.Errorf("%s: unknown state %q", where, name)
+		}
+		return nil
+	}
+	checkPromotions := func(where string, ps []Promotion) error {
+		for i, p := range ps {
+			at := fmt.Sprintf("%s promotion %d", where, i+1)
+			if len(p.From) == 0 {
+				return fmt.Errorf("%s: no from states", at)
+			}
+			for _, f := range p.From {
+				if err := known(at, f); err != nil {
+					return err
+				}
+			}
+			if err := known(at, p.To); err != nil {
+				return err
+			}
+			for _, w := range p.When {
+				if w != WhenTests && w != WhenBenches {
+					return fmt.Errorf("%s: unknown condition %q (want %s or %s)", at, w, WhenTests, WhenBenches)
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := checkPromotions("lifecycle", l.Promotions); err != nil {
+		return err
+	}
+	for aspect, r := range l.Aspects {
+		where := "lifecycle aspect " + aspect
+		for _, s := range r.Satisfies {
+			if err := known(where, s); err != nil {
+				return err
+			}
+		}
+		if err := checkPromotions(where, r.Promotions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Names returns the state names in lifecycle order.
+func (l *Lifecycle) Names() []string {
+	l = l.or()
+	out := make([]string, len(l.States))
+	for i, s := range l.States {
+		out[i] = s.Name
+	}
+	return out
+}
+
+// Has reports whether status is a state of the lifecycle.
+func (l *Lifecycle) Has(status string) bool {
+	return l.Rank(status) >= 0
+}
+
+// Rank returns the position of status in the lifecycle, or -1 when it
+// isn't a state.
+func (l *Lifecycle) Rank(status string) int {
+	l = l.or()
+	for i, s := range l.States {
+		if s.Name == status {
+			return i
+		}
+	}
+	return -1
+}
+
+// Pending returns the states `canary next` picks work from, in order.
+func (l *Lifecycle) Pending() []string {
+	l = l.or()
+	var out []string
+	for _, s := range l.States {
+		if s.Pending {
+			out = append(out, s.Name)
+		}
+	}
+	return out
+}
+
+// IsPending reports whether status is a pending state.
+func (l *Lifecycle) IsPending(status string) bool {
+	l = l.or()
+	for _, s := range l.States {
+		if s.Name == status {
+			return s.Pending
+		}
+	}
+	return false
+}
+
+// Satisfies reports whether a token of aspect in status is complete and
+// satisfies the dependencies on it.
+func (l *Lifecycle) Satisfies(status, aspect string) bool {
+	l = l.or()
+	if r, ok := l.aspect(aspect); ok && r.Satisfies != nil {
+		for _, s := range r.Satisfies {
+			if s == status {
+				return true
+			}
+		}
+		return false
+	}
+	for _, s := range l.States {
+		if s.Name == status {
+			return s.Satisfies
+		}
+	}
+	return false
+}
+
+// Promote returns the status a token of aspect earns from its evidence.
+func (l *Lifecycle) Promote(status, aspect string, hasTests, hasBenches bool) string {
+	for _, p := range l.promotions(aspect) {
+		if !contains(p.From, status) {
+			continue
+		}
+		met := true
+		for _, w := range p.When {
+			switch w {
+			case WhenTests:
+				met = met && hasTests
+			case WhenBenches:
+				met = met && hasBenches
+			}
+		}
+		if met {
+			status = p.To
+		}
+	}
+	return status
+}
+
+// Requires reports whether a token of aspect needs the evidence (WhenTests
+// or WhenBenches) to hold status, because a promotion into status asks for
+// it.
+func (l *Lifecycle) Requires(status, aspect, evidence string) bool {
+	for _, p := range l.promotions(aspect) {
+		if p.To == status && contains(p.When, evidence) {
+			return true
+		}
+	}
+	return false
+}
+
+// Demote returns the status a token of aspect keeps with the evidence it
+// has: while it sits in a state requiring evidence it lacks, it steps down
+// one state in lifecycle order. Unknown statuses are returned unchanged.
+func (l *Lifecycle) Demote(status, aspect string, hasTests, hasBenches bool) string {
+	l = l.or()
+	for r := l.Rank(status); r > 0; r-- {
+		status = l.States[r].Name
+		if (hasTests || !l.Requires(status, aspect, WhenTests)) &&
+			(hasBenches || !l.Requires(status, aspect, WhenBenches)) {
+			break
+		}
+		status = l.States[r-1].Name
+	}
+	return status
+}
+
+// promotions returns the promotion rules for tokens of aspect.
+func (l *Lifecycle) promotions(aspect string) []Promotion {
+	l = l.or()
+	if r, ok := l.aspect(aspect); ok && r.Promotions != nil {
+		return r.Promotions
+	}
+	return l.Promotions
+}
+
+// aspect returns the rules for aspect, matched case-insensitively.
+func (l *Lifecycle) aspect(aspect string) (AspectRules, bool) {
+	l = l.or()
+	if aspect == "" {
+		return AspectRules{}, false
+	}
+	if r, ok := l.Aspects[aspect]; ok {
+		return r, true
+	}
+	for k, r := range l.Aspects {
+		if strings.EqualFold(k, aspect) {
+			return r, true
+		}
+	}
+	return AspectRules{}, false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
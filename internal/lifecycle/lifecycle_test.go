@@ -0,0 +1,139 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package lifecycle
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const custom = `
+states:
+  - {name: STUB, pending: true}
+  - {name: IMPL, pending: true}
+  - {name: REVIEWED, pending: true}
+  - {name: TESTED, satisfies: true}
+  - {name: BENCHED, satisfies: true}
+  - {name: DEPRECATED}
+promotions:
+  - {from: [IMPL, REVIEWED], to: TESTED, when: [tests]}
+  - {from: [TESTED], to: BENCHED, when: [tests, benches]}
+aspects:
+  docs:
+    satisfies: [REVIEWED, TESTED]
+    promotions:
+      - {from: [IMPL], to: REVIEWED, when: [tests]}
+`
+
+// TestCANARY_CBIN_162_Engine_Lifecycle verifies the default lifecycle keeps
+// the built-in rules and a configured one adds states and per-aspect rules.
+func TestCANARY_CBIN_162_Engine_Lifecycle(t *testing.T) {
+	def := Default()
+	if err := def.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	type promotion struct {
+		lc             *Lifecycle
+		status, aspect string
+		tests, benches bool
+		want           string
+	}
+	promotions := []promotion{
+		{def, "IMPL", "API", true, false, "TESTED"},
+		{def, "IMPL", "API", true, true, "BENCHED"},
+		{def, "IMPL", "API", false, true, "BENCHED"},
+		{def, "STUB", "API", true, true, "STUB"},
+		{def, "TESTED", "API", false, false, "TESTED"},
+		{nil, "IMPL", "API", true, false, "TESTED"},
+	}
+
+	var lc Lifecycle
+	if err := yaml.Unmarshal([]byte(custom), &lc); err != nil {
+		t.Fatal(err)
+	}
+	if err := lc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	promotions = append(promotions, []promotion{
+		{&lc, "REVIEWED", "API", true, false, "TESTED"},
+		{&lc, "IMPL", "API", false, true, "IMPL"},
+		{&lc, "IMPL", "API", true, true, "BENCHED"},
+		{&lc, "IMPL", "Docs", true, true, "REVIEWED"},
+	}...)
+	for _, tt := range promotions {
+		if got := tt.lc.Promote(tt.status, tt.aspect, tt.tests, tt.benches); got != tt.want {
+			t.Errorf("Promote(%s, %s, %v, %v) = %s, want %s", tt.status, tt.aspect, tt.tests, tt.benches, got, tt.want)
+		}
+	}
+
+	if !lc.Has("DEPRECATED") || lc.Has("MISSING") {
+		t.Errorf("states = %v", lc.Names())
+	}
+	if got := strings.Join(lc.Pending(), ","); got != "STUB,IMPL,REVIEWED" {
+		t.Errorf("Pending() = %s", got)
+	}
+	satisfies := []struct {
+		status, aspect string
+		want           bool
+	}{
+		{"TESTED", "API", true},
+		{"REVIEWED", "API", false},
+		{"REVIEWED", "Docs", true},
+		{"BENCHED", "Docs", false},
+		{"DEPRECATED", "API", false},
+	}
+	for _, tt := range satisfies {
+		if got := lc.Satisfies(tt.status, tt.aspect); got != tt.want {
+			t.Errorf("Satisfies(%s, %s) = %v, want %v", tt.status, tt.aspect, got, tt.want)
+		}
+	}
+
+	demotions := []promotion{
+		{def, "BENCHED", "API", true, false, "TESTED"},
+		{def, "BENCHED", "API", false, false, "IMPL"},
+		{def, "TESTED", "API", false, true, "IMPL"},
+		{def, "REMOVED", "API", false, false, "REMOVED"},
+		{def, "DONE", "API", false, false, "DONE"},
+		{&lc, "BENCHED", "API", false, false, "REVIEWED"},
+		{&lc, "TESTED", "API", false, false, "REVIEWED"},
+		{&lc, "REVIEWED", "Docs", false, false, "IMPL"},
+		{&lc, "TESTED", "Docs", false, false, "TESTED"},
+	}
+	for _, tt := range demotions {
+		if got := tt.lc.Demote(tt.status, tt.aspect, tt.tests, tt.benches); got != tt.want {
+			t.Errorf("Demote(%s, %s, %v, %v) = %s, want %s", tt.status, tt.aspect, tt.tests, tt.benches, got, tt.want)
+		}
+	}
+	var none *Lifecycle
+	if !none.Has("MISSING") || !none.Satisfies("BENCHED", "API") || none.Demote("TESTED", "API", false, false) != "IMPL" {
+		t.Error("a nil lifecycle does not act as the default")
+	}
+	if !def.Requires("BENCHED", "API", WhenBenches) || def.Requires("TESTED", "API", WhenBenches) || !lc.Requires("BENCHED", "API", WhenTests) {
+		t.Error("Requires does not follow the promotion rules")
+	}
+}
+
+func TestLifecycle_Validate(t *testing.T) {
+	tests := map[string]string{
+		"no states":         `states: []`,
+		"duplicate":         `states: [{name: IMPL}, {name: IMPL}]`,
+		"lower case":        `states: [{name: impl}]`,
+		"unknown target":    "states: [{name: IMPL}]\npromotions: [{from: [IMPL], to: DONE}]",
+		"unknown condition": "states: [{name: IMPL}, {name: DONE}]\npromotions: [{from: [IMPL], to: DONE, when: [docs]}]",
+		"aspect state":      "states: [{name: IMPL}]\naspects: {Docs: {satisfies: [DONE]}}",
+	}
+	for name, src := range tests {
+		var lc Lifecycle
+		if err := yaml.Unmarshal([]byte(src), &lc); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := lc.Validate(); err == nil {
+			t.Errorf("%s: invalid lifecycle accepted", name)
+		}
+	}
+}
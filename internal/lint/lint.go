@@ -0,0 +1,232 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package lint checks CANARY tokens against a set of rules and reports every
+// problem it finds instead of stopping at the first bad token.
+package lint
+
+// CANARY: REQ=CBIN-158; FEATURE="TokenLint"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_158_Engine_LintRules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/token"
+)
+
+// Rule IDs reported by Lint. They are stable and safe to suppress or match
+// on in CI.
+const (
+	RuleBadSegment        = token.CodeBadSegment
+	RuleMissingField      = token.CodeMissingField
+	RuleInvalidStatus     = token.CodeInvalidStatus
+	RuleInvalidAspect     = token.CodeInvalidAspect
+	RuleUnknownKey        = "unknown-key"
+	RuleMalformedDate     = "malformed-date"
+	RuleTestedWithoutTest = "tested-without-test"
+	RuleMissingDoc        = "missing-doc"
+	RuleDuplicateFeature  = "duplicate-feature"
+)
+
+// Rule describes one lint check.
+type Rule struct {
+	ID          string
+	Severity    token.Severity
+	Description string
+}
+
+// Rules lists every rule in the order they are documented.
+var Rules = []Rule{
+	{RuleBadSegment, token.SeverityError, "Token segment is not a KEY=value pair"},
+	{RuleMissingField, token.SeverityError, "Token is missing a required field"},
+	{RuleInvalidStatus, token.SeverityError, "STATUS is not a known lifecycle state"},
+	{RuleInvalidAspect, token.SeverityError, "ASPECT is not a known aspect"},
+	{RuleUnknownKey, token.SeverityWarning, "Token has a field CANARY does not recognize"},
+	{RuleMalformedDate, token.SeverityError, "Date field is not in YYYY-MM-DD form"},
+	{RuleTestedWithoutTest, token.SeverityError, "Token in a satisfying status does not name a TEST"},
+	{RuleMissingDoc, token.SeverityError, "DOC path does not exist"},
+	{RuleDuplicateFeature, token.SeverityWarning, "Same REQ and FEATURE appear under different aspects"},
+}
+
+// dateFields are the token fields that hold dates.
+var dateFields = []string{"CREATED", "STARTED", "COMPLETED", "UPDATED"}
+
+// Options configures Lint.
+type Options struct {
+	Root         string
+	ExcludePaths []string
+	// Lifecycle is the project's status lifecycle; nil is the default.
+	Lifecycle *lifecycle.Lifecycle
+}
+
+// Result holds every diagnostic found by Lint, ordered by file and position.
+// Paths are relative to the root.
+type Result struct {
+	Files       int
+	Tokens      int
+	Diagnostics []token.Diagnostic
+}
+
+// Errors counts error diagnostics.
+func (r *Result) Errors() int {
+	n := 0
+	for _, d := range r.Diagnostics {
+		if d.Severity == token.SeverityError {
+			n++
+		}
+	}
+	return n
+}
+
+// Warnings counts warning diagnostics.
+func (r *Result) Warnings() int {
+	return len(r.Diagnostics) - r.Errors()
+}
+
+// Lint checks every token under opts.Root, honouring the same ignore rules
+// as the indexer.
+func Lint(opts Options) (*Result, error) {
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+
+	paths, err := indexer.Files(indexer.Options{Root: opts.Root, ExcludePaths: opts.ExcludePaths})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Files: len(paths)}
+	var all []*token.Token
+	for _, p := range paths {
+		src, err := os.ReadFile(p)
+		if err != nil || indexer.IsBinary(src) {
+			continue
+		}
+
+		rel, err := filepath.Rel(opts.Root, p)
+		if err != nil {
+			rel = p
+		}
+		toks, diags := token.ParseAll(filepath.ToSlash(rel), src, opts.Lifecycle)
+		res.Diagnostics = append(res.Diagnostics, diags...)
+		for _, tok := range toks {
+			res.Diagnostics = append(res.Diagnostics, Check(tok, opts.Root, opts.Lifecycle)...)
+		}
+		all = append(all, toks...)
+	}
+	res.Tokens = len(all)
+	res.Diagnostics = append(res.Diagnostics, Duplicates(all)...)
+
+	sort.SliceStable(res.Diagnostics, func(i, j int) bool {
+		a, b := res.Diagnostics[i], res.Diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return res, nil
+}
+
+// Check runs the per-token rules that the parser doesn't already cover.
+// DOC paths are resolved against root and statuses judged by lc.
+func Check(tok *token.Token, root string, lc *lifecycle.Lifecycle) []token.Diagnostic {
+	var diags []token.Diagnostic
+	report := func(rule, format string, args ...any) {
+		diags = append(diags, newDiagnostic(tok, rule, fmt.Sprintf(format, args...)))
+	}
+
+	known := map[string]bool{}
+	for _, k := range token.FieldOrder {
+		known[k] = true
+	}
+	for _, k := range tok.Keys {
+		if !known[k] {
+			report(RuleUnknownKey, "unknown key %s", k)
+		}
+	}
+
+	for _, k := range dateFields {
+		if !tok.Has(k) {
+			continue
+		}
+		if v := tok.Get(k); v != "" {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				report(RuleMalformedDate, "%s=%s is not a YYYY-MM-DD date", k, v)
+			}
+		}
+	}
+
+	if s := tok.Status(); lc.Satisfies(s, tok.Aspect()) && len(tok.Tests()) == 0 {
+		report(RuleTestedWithoutTest, "STATUS=%s without TEST=", s)
+	}
+
+	for _, ref := range token.SplitList(tok.Get("DOC")) {
+		// DOC entries are "type:path"; the type prefix is optional
+		path := ref
+		if typ, p, ok := strings.Cut(ref, ":"); ok && !strings.ContainsAny(typ, `/\.`) {
+			path = p
+		}
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(path))); err != nil {
+			report(RuleMissingDoc, "DOC path %s does not exist", path)
+		}
+	}
+
+	return diags
+}
+
+// Duplicates reports tokens whose REQ and FEATURE were already used with a
+// different ASPECT. Each duplicate points back at the first occurrence.
+func Duplicates(toks []*token.Token) []token.Diagnostic {
+	first := map[string]*token.Token{}
+	var diags []token.Diagnostic
+	for _, tok := range toks {
+		if tok.ReqID() == "" || tok.Feature() == "" {
+			continue
+		}
+		key := tok.ReqID() + "\x00" + tok.Feature()
+		f, ok := first[key]
+		if !ok {
+			first[key] = tok
+			continue
+		}
+		if f.Aspect() != tok.Aspect() {
+			diags = append(diags, newDiagnostic(tok, RuleDuplicateFeature,
+				fmt.Sprintf("%s %q is also declared with ASPECT=%s at %s", tok.ReqID(), tok.Feature(), f.Aspect(), f.Pos())))
+		}
+	}
+	return diags
+}
+
+func newDiagnostic(tok *token.Token, rule, msg string) token.Diagnostic {
+	return token.Diagnostic{
+		File:     tok.File,
+		Line:     tok.Line,
+		Column:   tok.Column,
+		Severity: severity(rule),
+		Code:     rule,
+		Message:  msg,
+	}
+}
+
+// severity returns the default severity of rule.
+func severity(rule string) token.Severity {
+	for _, r := range Rules {
+		if r.ID == rule {
+			return r.Severity
+		}
+	}
+	return token.SeverityError
+}
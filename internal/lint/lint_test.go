@@ -0,0 +1,153 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, root, rel, body string) {
+	t.Helper()
+	p := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCANARY_CBIN_158_Engine_LintRules(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "docs/ok.md", "# ok\n")
+	writeFile(t, root, "a.go", `package a
+
+// CANARY: REQ=CBIN-200; FEATURE="Good"; ASPECT=API; STATUS=TESTED; TEST=TestGood; DOC=user:docs/ok.md; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-201; FEATURE="Colour"; ASPECT=API; STATUS=IMPL; COLOR=blue; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-202; FEATURE="Status"; ASPECT=API; STATUS=DONE; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-203; FEATURE="Aspect"; ASPECT=Engnie; STATUS=IMPL; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-204; FEATURE="Date"; ASPECT=API; STATUS=IMPL; UPDATED=2025/10/15
+// CANARY: REQ=CBIN-205; FEATURE="Untested"; ASPECT=API; STATUS=TESTED; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-206; FEATURE="Doc"; ASPECT=API; STATUS=IMPL; DOC=user:docs/missing.md; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-207; FEATURE="Dup"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15
+`)
+	writeFile(t, root, "b.go", `package a
+
+// CANARY: REQ=CBIN-207; FEATURE="Dup"; ASPECT=CLI; STATUS=IMPL; UPDATED=2025-10-15
+// CANARY: REQ=CBIN-208; junk; FEATURE="Seg"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15
+`)
+
+	res, err := Lint(Options{Root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		RuleUnknownKey:        "a.go:4",
+		RuleInvalidStatus:     "a.go:5",
+		RuleInvalidAspect:     "a.go:6",
+		RuleMalformedDate:     "a.go:7",
+		RuleTestedWithoutTest: "a.go:8",
+		RuleMissingDoc:        "a.go:9",
+		RuleDuplicateFeature:  "b.go:3",
+		RuleBadSegment:        "b.go:4",
+	}
+	got := map[string]string{}
+	for _, d := range res.Diagnostics {
+		if _, dup := got[d.Code]; dup {
+			t.Errorf("rule %s reported twice: %v", d.Code, d)
+		}
+		got[d.Code] = fmt.Sprintf("%s:%d", d.File, d.Line)
+		if d.Code == RuleInvalidAspect && !strings.Contains(d.Message, "did you mean Engine") {
+			t.Errorf("missing aspect hint: %s", d.Message)
+		}
+	}
+	for rule, pos := range want {
+		if got[rule] != pos {
+			t.Errorf("rule %s at %q, want %q", rule, got[rule], pos)
+		}
+	}
+	if len(res.Diagnostics) != len(want) {
+		t.Errorf("got %d diagnostics, want %d: %v", len(res.Diagnostics), len(want), res.Diagnostics)
+	}
+	if res.Tokens != 10 || res.Warnings() != 2 || res.Errors() != 6 {
+		t.Errorf("tokens=%d errors=%d warnings=%d", res.Tokens, res.Errors(), res.Warnings())
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "src/a.py", "# CANARY: REQ=CBIN-200; FEATURE=\"X\"; ASPECT=API; STATUS=TESTED; UPDATED=2025-10-15\n")
+
+	res, err := Lint(Options{Root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, res, "1.2.3"); err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name    string `json:"name"`
+					Version string `json:"version"`
+					Rules   []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("bad log header: %s", buf.String())
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "canary" || run.Tool.Driver.Version != "1.2.3" || len(run.Tool.Driver.Rules) != len(Rules) {
+		t.Errorf("bad driver: %+v", run.Tool.Driver)
+	}
+	if len(run.Results) != 1 {
+		t.Fatalf("got %d results, want 1", len(run.Results))
+	}
+	r := run.Results[0]
+	if r.RuleID != RuleTestedWithoutTest || run.Tool.Driver.Rules[r.RuleIndex].ID != r.RuleID || r.Level != "error" {
+		t.Errorf("bad result: %+v", r)
+	}
+	loc := r.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "src/a.py" || loc.Region.StartLine != 1 || loc.Region.StartColumn != 3 {
+		t.Errorf("bad location: %+v", loc)
+	}
+}
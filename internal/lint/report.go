@@ -0,0 +1,167 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"go.devnw.com/canary/internal/token"
+)
+
+// WriteText writes one "file:line:col: severity rule: message" line per
+// diagnostic followed by a summary line.
+func WriteText(w io.Writer, res *Result) error {
+	for _, d := range res.Diagnostics {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s %s: %s\n", d.File, d.Line, d.Column, d.Severity, d.Code, d.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d tokens in %d files: %d errors, %d warnings\n", res.Tokens, res.Files, res.Errors(), res.Warnings())
+	return err
+}
+
+type jsonDiagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+type jsonReport struct {
+	Files       int              `json:"files"`
+	Tokens      int              `json:"tokens"`
+	Errors      int              `json:"errors"`
+	Warnings    int              `json:"warnings"`
+	Diagnostics []jsonDiagnostic `json:"diagnostics"`
+}
+
+// WriteJSON writes the result as a JSON document.
+func WriteJSON(w io.Writer, res *Result) error {
+	rep := jsonReport{
+		Files:       res.Files,
+		Tokens:      res.Tokens,
+		Errors:      res.Errors(),
+		Warnings:    res.Warnings(),
+		Diagnostics: []jsonDiagnostic{},
+	}
+	for _, d := range res.Diagnostics {
+		rep.Diagnostics = append(rep.Diagnostics, jsonDiagnostic{
+			File: d.File, Line: d.Line, Column: d.Column,
+			Severity: d.Severity.String(), Rule: d.Code, Message: d.Message,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
+
+// SARIF 2.1.0 subset used by code-scanning UIs.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version,omitempty"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID                   string        `json:"id"`
+		ShortDescription     sarifText     `json:"shortDescription"`
+		DefaultConfiguration sarifRuleConf `json:"defaultConfiguration"`
+	}
+	sarifRuleConf struct {
+		Level string `json:"level"`
+	}
+	sarifText struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     string          `json:"level"`
+		Message   sarifText       `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysical `json:"physicalLocation"`
+	}
+	sarifPhysical struct {
+		ArtifactLocation sarifArtifact `json:"artifactLocation"`
+		Region           sarifRegion   `json:"region"`
+	}
+	sarifArtifact struct {
+		URI       string `json:"uri"`
+		URIBaseID string `json:"uriBaseId"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+// WriteSARIF writes the result as a SARIF 2.1.0 log produced by the given
+// tool version. File URIs are relative to %SRCROOT%.
+func WriteSARIF(w io.Writer, res *Result, version string) error {
+	driver := sarifDriver{
+		Name:           "canary",
+		Version:        version,
+		InformationURI: "https://github.com/devnw/canary",
+	}
+	index := map[string]int{}
+	for i, r := range Rules {
+		index[r.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   r.ID,
+			ShortDescription:     sarifText{r.Description},
+			DefaultConfiguration: sarifRuleConf{level(r.Severity)},
+		})
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, d := range res.Diagnostics {
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    d.Code,
+			RuleIndex: index[d.Code],
+			Level:     level(d.Severity),
+			Message:   sarifText{d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysical{
+				ArtifactLocation: sarifArtifact{URI: d.File, URIBaseID: "%SRCROOT%"},
+				Region:           sarifRegion{StartLine: d.Line, StartColumn: d.Column},
+			}}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+func level(s token.Severity) string {
+	if s == token.SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
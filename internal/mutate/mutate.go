@@ -0,0 +1,276 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package mutate grades Go tests by mutation: it makes small changes to the
+// code a token annotates and checks that the token's tests notice.
+package mutate
+
+// CANARY: REQ=CBIN-168; FEATURE="MutationTesting"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_168_Engine_Mutants; TEST=TestCANARY_CBIN_168_Engine_RunMutants
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+)
+
+// Mutation kinds.
+const (
+	Conditional = "conditional" // a comparison or boolean condition flipped
+	Return      = "return"      // returned values replaced by zero values
+	Constant    = "constant"    // a literal or boolean constant changed
+)
+
+// Mutant is one small change to a Go source file.
+type Mutant struct {
+	Line        int    `json:"line"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"` // e.g. `"==" -> "!="`
+
+	offset, end int // byte range replaced
+	repl        string
+}
+
+// Apply returns src with the mutation made.
+func (m Mutant) Apply(src []byte) []byte {
+	out := make([]byte, 0, len(src)+len(m.repl))
+	out = append(out, src[:m.offset]...)
+	out = append(out, m.repl...)
+	return append(out, src[m.end:]...)
+}
+
+// Score counts what the tests did with the mutants of some code.
+type Score struct {
+	Killed   int `json:"killed"`   // a test failed or timed out
+	Survived int `json:"survived"` // every test still passed
+	Invalid  int `json:"invalid"`  // the mutant didn't compile
+}
+
+// Add returns the sum of s and o.
+func (s Score) Add(o Score) Score {
+	return Score{Killed: s.Killed + o.Killed, Survived: s.Survived + o.Survived, Invalid: s.Invalid + o.Invalid}
+}
+
+// Total is the number of mutants that compiled.
+func (s Score) Total() int { return s.Killed + s.Survived }
+
+// Percent returns the share of compiling mutants the tests killed, 0 when
+// there were none.
+func (s Score) Percent() float64 {
+	if s.Total() == 0 {
+		return 0
+	}
+	return float64(s.Killed) * 100 / float64(s.Total())
+}
+
+// String formats s as e.g. "75.0% (3/4 mutants killed)".
+func (s Score) String() string {
+	return fmt.Sprintf("%.1f%% (%d/%d mutants killed)", s.Percent(), s.Killed, s.Total())
+}
+
+var flips = map[token.Token]token.Token{
+	token.EQL:  token.NEQ,
+	token.NEQ:  token.EQL,
+	token.LSS:  token.GEQ,
+	token.GEQ:  token.LSS,
+	token.GTR:  token.LEQ,
+	token.LEQ:  token.GTR,
+	token.LAND: token.LOR,
+	token.LOR:  token.LAND,
+}
+
+// Generate returns the mutants of the Go source src on lines from through
+// to, in source order: comparisons and && / || flipped, if conditions
+// negated, returned values replaced by zero values, and integer, float,
+// string and boolean constants changed.
+func Generate(path string, src []byte, from, to int) ([]Mutant, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	g := &generator{fset: fset, src: src, from: from, to: to, tags: map[*ast.BasicLit]bool{}}
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if d.Body != nil {
+				g.walk(d.Body, d.Type)
+			}
+		case *ast.GenDecl:
+			if d.Tok != token.IMPORT {
+				g.walk(d, nil)
+			}
+		}
+	}
+	sort.SliceStable(g.out, func(i, j int) bool { return g.out[i].offset < g.out[j].offset })
+	return g.out, nil
+}
+
+type generator struct {
+	fset     *token.FileSet
+	src      []byte
+	from, to int
+	tags     map[*ast.BasicLit]bool // struct tags, which are left alone
+	out      []Mutant
+}
+
+// walk collects the mutants of n, whose returns belong to a function of
+// type fn.
+func (g *generator) walk(n ast.Node, fn *ast.FuncType) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			g.walk(n.Body, n.Type)
+			return false
+		case *ast.Field:
+			if n.Tag != nil {
+				g.tags[n.Tag] = true
+			}
+		case *ast.BinaryExpr:
+			if to, ok := flips[n.Op]; ok {
+				g.add(n.OpPos, n.OpPos+token.Pos(len(n.Op.String())), Conditional, to.String())
+			}
+		case *ast.IfStmt:
+			g.negate(n.Cond)
+		case *ast.ReturnStmt:
+			g.zeroReturn(n, fn)
+		case *ast.BasicLit:
+			if !g.tags[n] {
+				g.constant(n)
+			}
+		case *ast.Ident:
+			switch n.Name {
+			case "true":
+				g.add(n.Pos(), n.End(), Constant, "false")
+			case "false":
+				g.add(n.Pos(), n.End(), Constant, "true")
+			}
+		}
+		return true
+	})
+}
+
+// add records replacing pos..end with repl when it lies within the lines
+// mutated and changes the source.
+func (g *generator) add(pos, end token.Pos, kind, repl string) {
+	line := g.fset.Position(pos).Line
+	if line < g.from || line > g.to {
+		return
+	}
+	off, endOff := g.fset.Position(pos).Offset, g.fset.Position(end).Offset
+	orig := string(g.src[off:endOff])
+	if orig == repl {
+		return
+	}
+	g.out = append(g.out, Mutant{
+		Line: line, Kind: kind, Description: fmt.Sprintf("%q -> %q", orig, repl),
+		offset: off, end: endOff, repl: repl,
+	})
+}
+
+// negate inverts a condition that isn't a comparison flipped on its own.
+func (g *generator) negate(cond ast.Expr) {
+	switch c := cond.(type) {
+	case *ast.BinaryExpr:
+		if _, ok := flips[c.Op]; ok {
+			return
+		}
+	case *ast.UnaryExpr:
+		if c.Op == token.NOT {
+			off := g.fset.Position(c.X.Pos()).Offset
+			end := g.fset.Position(c.X.End()).Offset
+			g.add(c.Pos(), c.End(), Conditional, string(g.src[off:end]))
+			return
+		}
+	}
+	off, end := g.fset.Position(cond.Pos()).Offset, g.fset.Position(cond.End()).Offset
+	g.add(cond.Pos(), cond.End(), Conditional, "!("+string(g.src[off:end])+")")
+}
+
+// zeroReturn replaces the values ret returns with the zero values of fn's
+// results, when every result type has a literal zero value.
+func (g *generator) zeroReturn(ret *ast.ReturnStmt, fn *ast.FuncType) {
+	if fn == nil || fn.Results == nil || len(ret.Results) == 0 {
+		return
+	}
+	var zeros []string
+	for _, field := range fn.Results.List {
+		z, ok := zeroValue(field.Type)
+		if !ok {
+			return
+		}
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			zeros = append(zeros, z)
+		}
+	}
+	if len(zeros) != len(ret.Results) {
+		return // return f() of a multi-value call
+	}
+	repl := zeros[0]
+	for _, z := range zeros[1:] {
+		repl += ", " + z
+	}
+	g.add(ret.Results[0].Pos(), ret.Results[len(ret.Results)-1].End(), Return, repl)
+}
+
+// zeroValue returns the literal zero value of a type, if it has one that
+// doesn't need type information.
+func zeroValue(t ast.Expr) (string, bool) {
+	switch t := t.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "bool":
+			return "false", true
+		case "string":
+			return `""`, true
+		case "error", "any":
+			return "nil", true
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"byte", "rune", "float32", "float64", "complex64", "complex128":
+			return "0", true
+		}
+	case *ast.StarExpr, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType:
+		return "nil", true
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "nil", true
+		}
+	}
+	return "", false
+}
+
+// constant changes a literal: integers and floats become 1 when 0 and are
+// incremented otherwise, strings are emptied or filled.
+func (g *generator) constant(lit *ast.BasicLit) {
+	switch lit.Kind {
+	case token.INT:
+		v, err := strconv.ParseInt(lit.Value, 0, 64)
+		if err != nil {
+			return
+		}
+		g.add(lit.Pos(), lit.End(), Constant, strconv.FormatInt(v+1, 10))
+	case token.FLOAT:
+		v, err := strconv.ParseFloat(lit.Value, 64)
+		if err != nil {
+			return
+		}
+		g.add(lit.Pos(), lit.End(), Constant, strconv.FormatFloat(v+1, 'g', -1, 64))
+	case token.STRING:
+		if s, err := strconv.Unquote(lit.Value); err == nil && s == "" {
+			g.add(lit.Pos(), lit.End(), Constant, `"mutant"`)
+		} else {
+			g.add(lit.Pos(), lit.End(), Constant, `""`)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package mutate
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const src = `package a
+
+type T struct {
+	N int ` + "`json:\"n\"`" + `
+}
+
+// CANARY: REQ=CBIN-300; FEATURE="Clamp"; ASPECT=Engine; STATUS=TESTED; TEST=TestClamp; UPDATED=2026-01-01
+func Clamp(n int) int {
+	if n < 0 {
+		return 0
+	}
+	if !valid(n) {
+		return -1
+	}
+	return n
+}
+
+func valid(n int) bool { return n != 7 && true }
+
+func name() (string, error) { return "x", nil }
+`
+
+// TestCANARY_CBIN_168_Engine_Mutants verifies conditionals, returns and
+// constants are mutated within the given lines only, and every mutant still
+// parses.
+func TestCANARY_CBIN_168_Engine_Mutants(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		want     []string
+	}{
+		{"Clamp", 8, 16, []string{
+			`9 conditional "<" -> ">="`,
+			`9 constant "0" -> "1"`,
+			`10 constant "0" -> "1"`, // return 0 already returns the zero value
+			`12 conditional "!valid(n)" -> "valid(n)"`,
+			`13 return "-1" -> "0"`,
+			`13 constant "1" -> "2"`,
+			`15 return "n" -> "0"`,
+		}},
+		{"helpers", 18, 20, []string{
+			`18 return "n != 7 && true" -> "false"`,
+			`18 conditional "!=" -> "=="`,
+			`18 constant "7" -> "8"`,
+			`18 conditional "&&" -> "||"`,
+			`18 constant "true" -> "false"`,
+			`20 return "\"x\", nil" -> "\"\", nil"`,
+			`20 constant "\"x\"" -> "\"\""`,
+		}},
+		{"struct tag", 3, 5, nil},
+	}
+	for _, tt := range tests {
+		mutants, err := Generate("a.go", []byte(src), tt.from, tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, m := range mutants {
+			got = append(got, fmt.Sprintf("%d %s %s", m.Line, m.Kind, m.Description))
+			if _, err := parser.ParseFile(token.NewFileSet(), "a.go", m.Apply([]byte(src)), 0); err != nil {
+				t.Errorf("%s: mutant %s does not parse: %v", tt.name, m.Description, err)
+			}
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s mutants:\n%s\nwant:\n%s", tt.name, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+
+	if s := (Score{Killed: 3, Survived: 1, Invalid: 2}); s.String() != "75.0% (3/4 mutants killed)" {
+		t.Errorf("Score = %s", s)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package mutate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Outcome is what the tests did with one mutant.
+type Outcome int
+
+const (
+	Killed   Outcome = iota // a test failed or timed out
+	Survived                // every test passed
+	Invalid                 // the mutant didn't compile
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Killed:
+		return "killed"
+	case Survived:
+		return "survived"
+	}
+	return "invalid"
+}
+
+// MarshalText reports the outcome by name.
+func (o Outcome) MarshalText() ([]byte, error) { return []byte(o.String()), nil }
+
+// DefaultTimeout bounds one test run of a mutant, which may loop forever.
+const DefaultTimeout = 2 * time.Minute
+
+// Runner runs Go tests against mutants. Mutated files are handed to
+// `go test -overlay`, so the sources on disk are never changed.
+type Runner struct {
+	Dir     string        // directory go test runs in, inside the module
+	Timeout time.Duration // per test run; DefaultTimeout when 0
+}
+
+// Run runs tests in pkgs (directories relative to Dir) with file replaced
+// by m applied to src. A nil m runs the tests unchanged, which must pass
+// for the mutants of the code to mean anything.
+func (r *Runner) Run(ctx context.Context, file string, src []byte, m *Mutant, pkgs, tests []string) (Outcome, error) {
+	args := []string{"test", "-count=1", "-run", runPattern(tests)}
+	if m != nil {
+		overlay, cleanup, err := writeOverlay(file, m.Apply(src))
+		if err != nil {
+			return Invalid, err
+		}
+		defer cleanup()
+		args = append(args, "-overlay="+overlay)
+	}
+	args = append(args, pkgs...)
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = r.Dir
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	err := cmd.Run()
+	switch {
+	case err == nil:
+		return Survived, nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return Killed, nil
+	case bytes.Contains(out.Bytes(), []byte("[build failed]")) || bytes.Contains(out.Bytes(), []byte("[setup failed]")):
+		return Invalid, nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return Invalid, fmt.Errorf("run go test: %w", err)
+	}
+	return Killed, nil
+}
+
+// ErrTestsFail is returned by Test when the tests fail without a mutation.
+var ErrTestsFail = errors.New("tests fail on the unmutated code")
+
+// Result is the outcome of one mutant.
+type Result struct {
+	Mutant
+	Outcome Outcome `json:"outcome"`
+}
+
+// Test runs tests in pkgs against up to max mutants (all when max <= 0) of
+// lines from through to of file, whose contents are src. The tests first run
+// unmutated and must pass.
+func (r *Runner) Test(ctx context.Context, file string, src []byte, from, to int, pkgs, tests []string, max int) (Score, []Result, error) {
+	mutants, err := Generate(file, src, from, to)
+	if err != nil {
+		return Score{}, nil, err
+	}
+	if max > 0 && len(mutants) > max {
+		mutants = mutants[:max]
+	}
+	if len(mutants) == 0 {
+		return Score{}, nil, nil
+	}
+
+	base, err := r.Run(ctx, file, src, nil, pkgs, tests)
+	if err != nil {
+		return Score{}, nil, err
+	}
+	if base != Survived {
+		return Score{}, nil, ErrTestsFail
+	}
+
+	var score Score
+	results := make([]Result, 0, len(mutants))
+	for i := range mutants {
+		o, err := r.Run(ctx, file, src, &mutants[i], pkgs, tests)
+		if err != nil {
+			return score, results, err
+		}
+		switch o {
+		case Killed:
+			score.Killed++
+		case Survived:
+			score.Survived++
+		default:
+			score.Invalid++
+		}
+		results = append(results, Result{Mutant: mutants[i], Outcome: o})
+	}
+	return score, results, nil
+}
+
+// runPattern matches the top-level tests of names, so a TEST= naming a
+// subtest runs its parent.
+func runPattern(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, n := range names {
+		n, _, _ = strings.Cut(n, "/")
+		quoted = append(quoted, regexp.QuoteMeta(n))
+	}
+	return "^(" + strings.Join(quoted, "|") + ")$"
+}
+
+// writeOverlay writes src and a go build overlay replacing file with it to
+// a temporary directory.
+func writeOverlay(file string, src []byte) (string, func(), error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", nil, err
+	}
+	dir, err := os.MkdirTemp("", "canary-mutant-")
+	if err != nil {
+		return "", nil, fmt.Errorf("create overlay: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	mutated := filepath.Join(dir, filepath.Base(file))
+	if err := os.WriteFile(mutated, src, 0o644); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("write mutant: %w", err)
+	}
+	b, err := json.Marshal(map[string]map[string]string{"Replace": {abs: mutated}})
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	overlay := filepath.Join(dir, "overlay.json")
+	if err := os.WriteFile(overlay, b, 0o644); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("write overlay: %w", err)
+	}
+	return overlay, cleanup, nil
+}
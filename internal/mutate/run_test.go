@@ -0,0 +1,88 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package mutate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const absSrc = `package abs
+
+func Abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+`
+
+const absTest = `package abs
+
+import "testing"
+
+// TestWeak runs Abs but asserts nothing
+func TestWeak(t *testing.T) { Abs(-2) }
+
+func TestStrong(t *testing.T) {
+	if Abs(-2) != 2 || Abs(3) != 3 || Abs(0) != 0 {
+		t.Fatal("wrong")
+	}
+}
+
+func TestBroken(t *testing.T) { t.Fatal("broken") }
+`
+
+// TestCANARY_CBIN_168_Engine_RunMutants verifies tests that assert nothing
+// let every mutant survive while real assertions kill them, and that the
+// source on disk is left alone.
+func TestCANARY_CBIN_168_Engine_RunMutants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test for every mutant")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not installed")
+	}
+	dir := t.TempDir()
+	files := map[string]string{"go.mod": "module example.com/abs\n\ngo 1.21\n", "abs.go": absSrc, "abs_test.go": absTest}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file := filepath.Join(dir, "abs.go")
+	r := &Runner{Dir: dir}
+	ctx := context.Background()
+
+	weak, _, err := r.Test(ctx, file, []byte(absSrc), 3, 8, []string{"."}, []string{"TestWeak"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weak.Killed != 0 || weak.Survived != 4 {
+		t.Errorf("weak test score = %+v, want every mutant to survive", weak)
+	}
+
+	strong, results, err := r.Test(ctx, file, []byte(absSrc), 3, 8, []string{"."}, []string{"TestStrong/case"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// n < 1 is an equivalent mutant: Abs(0) is 0 either way
+	if strong.Killed != 3 || strong.Survived != 1 {
+		t.Errorf("strong test score = %+v, results %+v", strong, results)
+	}
+
+	if _, _, err := r.Test(ctx, file, []byte(absSrc), 3, 8, []string{"."}, []string{"TestBroken"}, 0); !errors.Is(err, ErrTestsFail) {
+		t.Errorf("failing tests: err = %v, want ErrTestsFail", err)
+	}
+
+	if b, _ := os.ReadFile(file); string(b) != absSrc {
+		t.Error("source changed on disk")
+	}
+}
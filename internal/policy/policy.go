@@ -0,0 +1,165 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package policy enforces the verification section of .canary/project.yaml:
+// which fields tokens must carry, how long completed tokens may go without
+// an UPDATED bump, how much of their code tests must cover and how much
+// slower their benchmarks may get. Rules are set project-wide and can be
+// overridden per aspect and per requirement, the most specific override
+// winning.
+package policy
+
+// CANARY: REQ=CBIN-163; FEATURE="EvidencePolicy"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_163_Engine_PolicyOverrides
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/token"
+)
+
+// Rule IDs reported in violations.
+const (
+	RuleRequireTest  = "require-test"  // satisfying token without TEST=
+	RuleRequireBench = "require-bench" // token promoted on benches without BENCH=
+	RuleRequireField = "require-field" // token without a field listed in require_fields
+	RuleStale        = "stale"         // completed token not updated within staleness_days
+	RuleCoverage     = "coverage"      // completed feature covered less than min_coverage
+	RuleBench        = "bench"         // benchmark slower than bench_regression allows
+)
+
+// Rule is one level of policy. Unset fields inherit from the level above,
+// so an override only lists what it changes.
+type Rule struct {
+	RequireTestField  *bool    `yaml:"require_test_field"`
+	RequireBenchField *bool    `yaml:"require_bench_field"`
+	RequireFields     []string `yaml:"require_fields"`
+	StalenessDays     *int     `yaml:"staleness_days"`
+	MinCoverage       *float64 `yaml:"min_coverage"`
+	BenchRegression   *float64 `yaml:"bench_regression"`
+}
+
+// Policy holds the project-wide rule and its overrides. Aspect keys match
+// case-insensitively; requirement keys are requirement IDs.
+type Policy struct {
+	Project      Rule
+	Aspects      map[string]Rule
+	Requirements map[string]Rule
+}
+
+// Resolved is the rule in force for one token. Scope records where each
+// rule ID was last set, e.g. "project", "aspect Security" or
+// "requirement CBIN-105".
+type Resolved struct {
+	RequireTestField  bool
+	RequireBenchField bool
+	RequireFields     []string
+	StalenessDays     int     // 0 disables the staleness check
+	MinCoverage       float64 // line coverage percentage; 0 disables the check
+	BenchRegression   float64 // allowed benchmark slowdown in percent; 0 uses the default
+	Scope             map[string]string
+}
+
+// For returns the rule in force for a token of req and aspect.
+func (p *Policy) For(req, aspect string) Resolved {
+	r := Resolved{Scope: map[string]string{}}
+	r.apply(p.Project, "project")
+	for k, rule := range p.Aspects {
+		if strings.EqualFold(k, aspect) {
+			r.apply(rule, "aspect "+k)
+			break
+		}
+	}
+	if rule, ok := p.Requirements[req]; ok {
+		r.apply(rule, "requirement "+req)
+	}
+	return r
+}
+
+func (r *Resolved) apply(rule Rule, scope string) {
+	if rule.RequireTestField != nil {
+		r.RequireTestField = *rule.RequireTestField
+		r.Scope[RuleRequireTest] = scope
+	}
+	if rule.RequireBenchField != nil {
+		r.RequireBenchField = *rule.RequireBenchField
+		r.Scope[RuleRequireBench] = scope
+	}
+	if rule.RequireFields != nil {
+		r.RequireFields = nil
+		for _, f := range rule.RequireFields {
+			r.RequireFields = append(r.RequireFields, strings.ToUpper(strings.TrimSpace(f)))
+		}
+		r.Scope[RuleRequireField] = scope
+	}
+	if rule.StalenessDays != nil {
+		r.StalenessDays = *rule.StalenessDays
+		r.Scope[RuleStale] = scope
+	}
+	if rule.MinCoverage != nil {
+		r.MinCoverage = *rule.MinCoverage
+		r.Scope[RuleCoverage] = scope
+	}
+	if rule.BenchRegression != nil {
+		r.BenchRegression = *rule.BenchRegression
+		r.Scope[RuleBench] = scope
+	}
+}
+
+// Violation is a token breaking a rule.
+type Violation struct {
+	Token   *token.Token
+	Rule    string
+	Scope   string // where the rule was set
+	Message string
+}
+
+// Check returns every violation in toks, ordered by file and line. Tokens
+// are judged by their declared status under lc; staleness is measured
+// against now.
+func (p *Policy) Check(toks []*token.Token, lc *lifecycle.Lifecycle, now time.Time) []Violation {
+	var out []Violation
+	for _, tok := range toks {
+		status, aspect := tok.Status(), tok.Aspect()
+		r := p.For(tok.ReqID(), aspect)
+		add := func(rule, format string, args ...any) {
+			out = append(out, Violation{Token: tok, Rule: rule, Scope: r.Scope[rule], Message: fmt.Sprintf(format, args...)})
+		}
+
+		if r.RequireTestField && lc.Satisfies(status, aspect) && len(tok.Tests()) == 0 {
+			add(RuleRequireTest, "%s token does not name a TEST", status)
+		}
+		if r.RequireBenchField && lc.Requires(status, aspect, lifecycle.WhenBenches) && len(tok.Benches()) == 0 {
+			add(RuleRequireBench, "%s token does not name a BENCH", status)
+		}
+		for _, f := range r.RequireFields {
+			if tok.Get(f) == "" {
+				add(RuleRequireField, "token has no %s=", f)
+			}
+		}
+		if r.StalenessDays > 0 && lc.Satisfies(status, aspect) {
+			updated, err := time.Parse("2006-01-02", tok.Get("UPDATED"))
+			if err != nil {
+				continue // malformed dates are reported by lint
+			}
+			if age := int(now.Sub(updated).Hours() / 24); age > r.StalenessDays {
+				add(RuleStale, "%s token last UPDATED %s, %d days ago (limit %d)", status, tok.Get("UPDATED"), age, r.StalenessDays)
+			}
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i].Token, out[j].Token
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return out
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.devnw.com/canary/internal/token"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+// TestCANARY_CBIN_163_Engine_PolicyOverrides verifies project rules are
+// enforced and overridden per aspect and per requirement.
+func TestCANARY_CBIN_163_Engine_PolicyOverrides(t *testing.T) {
+	p := &Policy{
+		Project: Rule{RequireTestField: ptr(true), RequireBenchField: ptr(true), StalenessDays: ptr(30)},
+		Aspects: map[string]Rule{
+			"security": {RequireFields: []string{"bench"}},
+			"API":      {RequireFields: []string{"OWNER"}},
+			"Docs":     {StalenessDays: ptr(180)},
+		},
+		Requirements: map[string]Rule{
+			"CBIN-204": {RequireTestField: ptr(false), StalenessDays: ptr(0)},
+		},
+	}
+
+	src := `// CANARY: REQ=CBIN-200; FEATURE="Ok"; ASPECT=Engine; STATUS=TESTED; TEST=TestOk; UPDATED=2026-10-01
+// CANARY: REQ=CBIN-200; FEATURE="NoTest"; ASPECT=Engine; STATUS=TESTED; UPDATED=2026-10-01
+// CANARY: REQ=CBIN-200; FEATURE="NoBench"; ASPECT=Engine; STATUS=BENCHED; TEST=TestB; UPDATED=2026-10-01
+// CANARY: REQ=CBIN-201; FEATURE="Crypto"; ASPECT=Security; STATUS=IMPL; UPDATED=2026-10-01
+// CANARY: REQ=CBIN-202; FEATURE="Login"; ASPECT=API; STATUS=STUB; UPDATED=2026-10-01
+// CANARY: REQ=CBIN-202; FEATURE="Logout"; ASPECT=API; STATUS=STUB; OWNER=auth; UPDATED=2026-10-01
+// CANARY: REQ=CBIN-203; FEATURE="Old"; ASPECT=Engine; STATUS=TESTED; TEST=TestOld; UPDATED=2026-01-01
+// CANARY: REQ=CBIN-203; FEATURE="Guide"; ASPECT=Docs; STATUS=TESTED; TEST=TestGuide; UPDATED=2026-06-01
+// CANARY: REQ=CBIN-203; FEATURE="OldGuide"; ASPECT=Docs; STATUS=TESTED; TEST=TestGuide; UPDATED=2026-01-01
+// CANARY: REQ=CBIN-204; FEATURE="Legacy"; ASPECT=Engine; STATUS=TESTED; UPDATED=2025-01-01
+`
+	toks, diags := token.Parse("a.go", []byte(src), nil)
+	if len(diags) != 0 {
+		t.Fatal(diags)
+	}
+
+	type violation struct{ Feature, Rule, Scope string }
+	want := []violation{
+		{"NoTest", RuleRequireTest, "project"},
+		{"NoBench", RuleRequireBench, "project"},
+		{"Crypto", RuleRequireField, "aspect security"},
+		{"Login", RuleRequireField, "aspect API"},
+		{"Old", RuleStale, "project"},
+		{"OldGuide", RuleStale, "aspect Docs"},
+	}
+	var got []violation
+	for _, v := range p.Check(toks, nil, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)) {
+		got = append(got, violation{v.Token.Feature(), v.Rule, v.Scope})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations:\n got %v\nwant %v", got, want)
+	}
+
+	cov := &Policy{
+		Project:      Rule{MinCoverage: ptr(80.0), BenchRegression: ptr(10.0)},
+		Aspects:      map[string]Rule{"Docs": {MinCoverage: ptr(0.0)}},
+		Requirements: map[string]Rule{"CBIN-203": {MinCoverage: ptr(90.0), BenchRegression: ptr(25.0)}},
+	}
+	for _, tt := range []struct {
+		req, aspect string
+		min         float64
+		scope       string
+	}{
+		{"CBIN-200", "Engine", 80, "project"},
+		{"CBIN-200", "Docs", 0, "aspect Docs"},
+		{"CBIN-203", "Docs", 90, "requirement CBIN-203"},
+	} {
+		if r := cov.For(tt.req, tt.aspect); r.MinCoverage != tt.min || r.Scope[RuleCoverage] != tt.scope {
+			t.Errorf("%s %s: min_coverage %v from %q, want %v from %q", tt.req, tt.aspect, r.MinCoverage, r.Scope[RuleCoverage], tt.min, tt.scope)
+		}
+	}
+	if r := cov.For("CBIN-203", "Engine"); r.BenchRegression != 25 || r.Scope[RuleBench] != "requirement CBIN-203" {
+		t.Errorf("CBIN-203 bench_regression %v from %q, want 25 from the requirement", r.BenchRegression, r.Scope[RuleBench])
+	}
+
+	if r := (&Policy{}).For("CBIN-200", "API"); r.RequireTestField || r.StalenessDays != 0 || len(r.RequireFields) != 0 || r.MinCoverage != 0 {
+		t.Errorf("empty policy resolved to %+v", r)
+	}
+}
@@ -19,6 +19,11 @@ type RequirementID struct {
 	Format string // "v1" (CBIN-XXX) or "v2" (CBIN-<ASPECT>-XXX)
 }
 
+// Pattern matches a requirement ID in text: a key, any aspect segments and
+// a number, e.g. CBIN-147, CBIN-CLI-001 or ACME-1042. Unlike
+// ParseRequirementID it accepts any key and any number of digits.
+const Pattern = `[A-Za-z][A-Za-z0-9]*(?:-[A-Za-z]+)*-\d+`
+
 var (
 	// Pattern for new format: CBIN-CLI-001
 	v2Pattern = regexp.MustCompile(`^([A-Z]+)-([A-Za-z]+)-(\d{3})$`)
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-161; FEATURE="ClaimPatterns"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_161_Engine_ParseClaims
+package scanner
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.devnw.com/canary/internal/config"
+	"go.devnw.com/canary/internal/reqid"
+)
+
+// Default markers for a GAP line that claims a requirement is done, or
+// explicitly says it isn't. Markers match case-insensitively and words
+// only match whole words, so "Complete" doesn't match "Incomplete".
+var (
+	DefaultImplementedMarkers    = []string{"✅", "[x]", "Implemented", "Complete"}
+	DefaultNotImplementedMarkers = []string{"❌", "◻", "[ ]", "STUB", "NOT IMPLEMENTED"}
+)
+
+// claimIDRe matches anything shaped like a requirement ID: KEY-NNN or the
+// aspect-scoped KEY-ASPECT-NNN.
+var claimIDRe = regexp.MustCompile(`\b[A-Z][A-Z0-9]*(?:-[A-Za-z]+)?-\d{3,}\b`)
+
+// ClaimOptions controls how requirement claims are recognized in a GAP file.
+type ClaimOptions struct {
+	// IDPattern is the project's requirements.id_pattern. An ID is
+	// accepted when the pattern matches all of it, or when it is an
+	// aspect-scoped ID whose KEY-NNN form matches. Any ID-shaped word is
+	// accepted when empty.
+	IDPattern string
+
+	// Implemented and NotImplemented are the line markers; the defaults
+	// are used when nil.
+	Implemented    []string
+	NotImplemented []string
+}
+
+// ClaimOptionsFor returns the claim options configured in cfg.
+func ClaimOptionsFor(cfg *config.ProjectConfig) ClaimOptions {
+	if cfg == nil {
+		return ClaimOptions{}
+	}
+	return ClaimOptions{
+		IDPattern:      cfg.Requirements.IDPattern,
+		Implemented:    cfg.Verification.Claims.Implemented,
+		NotImplemented: cfg.Verification.Claims.NotImplemented,
+	}
+}
+
+// Claim is a requirement mentioned on a GAP line carrying a marker.
+type Claim struct {
+	REQ         string
+	Implemented bool // has an implemented marker and no not-implemented marker
+	Line        int
+	Text        string
+}
+
+// ParseClaims returns the claims in a GAP file in the order they appear.
+// A line is a claim when it has at least one recognized requirement ID and
+// either kind of marker; every ID on the line shares the claim. It is an
+// error for src to contain no claims at all, since that almost always means
+// the ID pattern or markers don't match how the file is written.
+func ParseClaims(src []byte, opts ClaimOptions) ([]Claim, error) {
+	var idRe *regexp.Regexp
+	if opts.IDPattern != "" {
+		var err error
+		if idRe, err = regexp.Compile(`^(?:` + opts.IDPattern + `)$`); err != nil {
+			return nil, fmt.Errorf("invalid id_pattern %q: %w", opts.IDPattern, err)
+		}
+	}
+	if opts.Implemented == nil {
+		opts.Implemented = DefaultImplementedMarkers
+	}
+	if opts.NotImplemented == nil {
+		opts.NotImplemented = DefaultNotImplementedMarkers
+	}
+	done, err := markerRegexp(opts.Implemented)
+	if err != nil {
+		return nil, err
+	}
+	notDone, err := markerRegexp(opts.NotImplemented)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims []Claim
+	for n, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimRight(line, "\r")
+		isDone, isNotDone := done != nil && done.MatchString(line), notDone != nil && notDone.MatchString(line)
+		if !isDone && !isNotDone {
+			continue
+		}
+		for _, id := range claimIDRe.FindAllString(normalizeREQ(line), -1) {
+			if !acceptID(idRe, id) {
+				continue
+			}
+			claims = append(claims, Claim{
+				REQ:         normalizeREQ(id),
+				Implemented: isDone && !isNotDone,
+				Line:        n + 1,
+				Text:        strings.TrimSpace(line),
+			})
+		}
+	}
+
+	if len(claims) == 0 {
+		pattern := opts.IDPattern
+		if pattern == "" {
+			pattern = claimIDRe.String()
+		}
+		return nil, fmt.Errorf("no requirement claims recognized (IDs matching %s with markers %s or %s)",
+			pattern, strings.Join(opts.Implemented, ", "), strings.Join(opts.NotImplemented, ", "))
+	}
+	return claims, nil
+}
+
+// acceptID reports whether id belongs to the project. Aspect-scoped IDs
+// also match through their KEY-NNN form so a pattern written for v1 IDs
+// keeps working after a project moves to v2.
+func acceptID(idRe *regexp.Regexp, id string) bool {
+	if idRe == nil || idRe.MatchString(id) {
+		return true
+	}
+	rid, err := reqid.ParseRequirementID(id)
+	if err != nil || rid.Format != "v2" {
+		return false
+	}
+	return idRe.MatchString(rid.Key + "-" + rid.ID)
+}
+
+// markerRegexp compiles markers into one case-insensitive alternation.
+// Markers that start or end with a letter or digit only match at a word
+// boundary. It returns nil when there are no markers.
+func markerRegexp(markers []string) (*regexp.Regexp, error) {
+	var alts []string
+	for _, m := range markers {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		alt, r := regexp.QuoteMeta(m), []rune(m)
+		if isWord(r[0]) {
+			alt = `\b` + alt
+		}
+		if isWord(r[len(r)-1]) {
+			alt += `\b`
+		}
+		alts = append(alts, alt)
+	}
+	if len(alts) == 0 {
+		return nil, nil
+	}
+	re, err := regexp.Compile(`(?i)(?:` + strings.Join(alts, "|") + `)`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim markers %q: %w", markers, err)
+	}
+	return re, nil
+}
+
+func isWord(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCANARY_CBIN_161_Engine_ParseClaims verifies claims are recognized with
+// the project's ID pattern, aspect-scoped IDs and configured markers.
+func TestCANARY_CBIN_161_Engine_ParseClaims(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		opts ClaimOptions
+		want map[string]bool // REQ -> implemented
+	}{
+		{
+			name: "defaults",
+			src: `# Gap Analysis
+✅ CBIN-101 - Scanner Core
+| REQ‑GQL‑042 | Streaming | Implemented |
+- [x] CBIN-102 done
+- [ ] CBIN-103 - Status JSON (needs tests)
+❌ CBIN-104 - Incomplete
+CBIN-105 is mentioned without a marker
+✅ SHA-1 hashing
+`,
+			want: map[string]bool{
+				"CBIN-101": true, "REQ-GQL-042": true, "CBIN-102": true,
+				"CBIN-103": false, "CBIN-104": false,
+			},
+		},
+		{
+			name: "project pattern",
+			src: `✅ ACME-API-042 - Login
+✅ ACME-CLI-007 - Init
+◻ ACME-API-043 - Logout
+✅ OTHER-API-001 - Not ours
+`,
+			opts: ClaimOptions{IDPattern: `ACME-[A-Z]+-[0-9]{3}`},
+			want: map[string]bool{"ACME-API-042": true, "ACME-CLI-007": true, "ACME-API-043": false},
+		},
+		{
+			name: "v1 pattern accepts aspect-scoped IDs",
+			src: `✅ ACME-API-142
+✅ ACME-API-042
+✅ ACME-Bogus-150
+`,
+			opts: ClaimOptions{IDPattern: `ACME-[1-9][0-9]{2,}`},
+			want: map[string]bool{"ACME-API-142": true},
+		},
+		{
+			name: "custom markers",
+			src: `* ACME-101 :: shipped
+* ACME-102 :: shipped (pending review)
+* ACME-103 :: Implemented
+`,
+			opts: ClaimOptions{Implemented: []string{"shipped"}, NotImplemented: []string{"pending"}},
+			want: map[string]bool{"ACME-101": true, "ACME-102": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ParseClaims([]byte(tt.src), tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]bool{}
+			for _, c := range claims {
+				got[c.REQ] = c.Implemented
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("claims = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseClaims([]byte("✅ CBIN-101\n"), ClaimOptions{IDPattern: `ACME-[0-9]{3}`}); err == nil {
+		t.Error("GAP file without recognizable claims was accepted")
+	}
+	if _, err := ParseClaims([]byte("✅ CBIN-101\n"), ClaimOptions{IDPattern: `(`}); err == nil {
+		t.Error("invalid id_pattern was accepted")
+	}
+}
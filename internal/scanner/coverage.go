@@ -0,0 +1,101 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"go.devnw.com/canary/internal/coverage"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/policy"
+	"go.devnw.com/canary/internal/span"
+	"go.devnw.com/canary/internal/token"
+)
+
+// ApplyCoverage measures prof over the declaration each token under root
+// annotates and sets the coverage of every feature and requirement of rep
+// with instrumented code. It returns a CANARY_VERIFY_FAIL diagnostic for
+// every feature completed under lc covered less than pol requires, or whose
+// code is in a file prof has no data for; pol may be nil.
+func ApplyCoverage(rep *Report, root string, skip, projectFilter *regexp.Regexp, ignorePatterns *ignore.GitIgnore, prof *coverage.Profile, pol *policy.Policy, lc *lifecycle.Lifecycle) ([]string, error) {
+	if root == "" {
+		root = "."
+	}
+	if skip == nil {
+		skip = SkipDefault
+	}
+	if pol == nil {
+		pol = &policy.Policy{}
+	}
+
+	byFeature := map[aggregateKey]coverage.Counts{}
+	noData := map[aggregateKey]string{} // a file of the feature prof lacks
+	err := walk(root, skip, ignorePatterns, func(path string) error {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// profiles name files relative to the module or repository
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+		toks, _ := token.Parse(path, b, lc)
+		for i, sp := range span.Tokens(path, b, toks) {
+			if sp.Start == 0 {
+				continue
+			}
+			tok := toks[i]
+			req := normalizeREQ(tok.ReqID())
+			if projectFilter != nil && !projectFilter.MatchString(req) {
+				continue
+			}
+			k := aggregateKey{req: req, feature: tok.Feature(), aspect: tok.Aspect(), owner: tok.Get("OWNER"), updated: tok.Get("UPDATED")}
+			byFeature[k] = byFeature[k].Add(prof.Span(rel, sp.Start, sp.End, tok.Line, max(tok.EndLine, tok.Line)))
+			if !prof.Has(rel) {
+				noData[k] = filepath.ToSlash(rel)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var diags []string
+	for i := range rep.Requirements {
+		r := &rep.Requirements[i]
+		var total coverage.Counts
+		for j := range r.Features {
+			f := &r.Features[j]
+			k := aggregateKey{req: r.ID, feature: f.Feature, aspect: f.Aspect, owner: f.Owner, updated: f.Updated}
+			c := byFeature[k]
+			rule := pol.For(r.ID, f.Aspect)
+			gated := rule.MinCoverage > 0 && lc.Satisfies(f.Status, f.Aspect)
+			if file, ok := noData[k]; ok && gated {
+				diags = append(diags, fmt.Sprintf("CANARY_VERIFY_FAIL REQ=%s FEATURE=%q reason=no_coverage_data file=%s min=%.1f", r.ID, f.Feature, file, rule.MinCoverage))
+			}
+			if c.Total == 0 {
+				continue
+			}
+			f.Coverage = &c
+			total = total.Add(c)
+
+			if gated && c.Percent() < rule.MinCoverage {
+				diags = append(diags, fmt.Sprintf("CANARY_VERIFY_FAIL REQ=%s FEATURE=%q reason=coverage_below_min coverage=%.1f min=%.1f", r.ID, f.Feature, c.Percent(), rule.MinCoverage))
+			}
+		}
+		if total.Total > 0 {
+			r.Coverage = &total
+		}
+	}
+	return diags, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.devnw.com/canary/internal/coverage"
+	"go.devnw.com/canary/internal/policy"
+)
+
+const coveredSrc = `package a
+
+// CANARY: REQ=CBIN-300; FEATURE="Parse"; ASPECT=Engine; STATUS=TESTED; TEST=TestParse; UPDATED=2026-01-01
+func Parse(s string) int {
+	if s == "" {
+		return 0
+	}
+	return len(s)
+}
+
+// CANARY: REQ=CBIN-300; FEATURE="Render"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-01-01
+func Render() int {
+	return 2
+}
+`
+
+// TestCANARY_CBIN_166_Engine_ReportCoverage verifies status.json carries the
+// coverage of each feature's code and completed features below
+// min_coverage, or with code the profile has no data for, fail
+// verification.
+func TestCANARY_CBIN_166_Engine_ReportCoverage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "a"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a", "a.go"), []byte(coveredSrc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// b.go is missing from the profile, as when it covers other packages
+	if err := os.Mkdir(filepath.Join(dir, "b"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	uncovered := "package b\n\n// CANARY: REQ=CBIN-301; FEATURE=\"Export\"; ASPECT=Engine; STATUS=TESTED; TEST=TestExport; UPDATED=2026-01-01\nfunc Export() int {\n\treturn 1\n}\n\n" +
+		"// CANARY: REQ=CBIN-301; FEATURE=\"Draft\"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-01-01\nfunc Draft() int {\n\treturn 2\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "b", "b.go"), []byte(uncovered), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	prof, err := coverage.Parse([]byte(`mode: set
+example.com/a/a.go:4.25,5.13 1 1
+example.com/a/a.go:5.13,7.3 1 0
+example.com/a/a.go:8.2,8.15 1 1
+example.com/a/a.go:12.18,14.2 1 0
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rep, err := Scan(dir, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	minCoverage := 80.0
+	diags, err := ApplyCoverage(&rep, dir, nil, nil, nil, prof, &policy.Policy{Project: policy.Rule{MinCoverage: &minCoverage}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := rep.Requirements[0]
+	got := map[string]string{"requirement": r.Coverage.String()}
+	for _, f := range r.Features {
+		got[f.Feature] = f.Coverage.String()
+	}
+	want := map[string]string{
+		"requirement": "37.5% (3/8 lines)",
+		"Parse":       "60.0% (3/5 lines)",
+		"Render":      "0.0% (0/3 lines)",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s coverage = %s, want %s", k, got[k], v)
+		}
+	}
+
+	// Render and Draft are only IMPL, so just Parse and Export fall short
+	if len(diags) != 2 ||
+		!strings.Contains(diags[0], `FEATURE="Parse" reason=coverage_below_min coverage=60.0 min=80.0`) ||
+		!strings.Contains(diags[1], `REQ=CBIN-301 FEATURE="Export" reason=no_coverage_data file=b/b.go min=80.0`) {
+		t.Errorf("diags = %q", diags)
+	}
+	if c := rep.Requirements[1].Coverage; c != nil {
+		t.Errorf("CBIN-301 coverage = %s, want none", c)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-149; FEATURE="InProcessScan"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_149_Engine_RunExitCodes; OWNER=canary; UPDATED=2026-10-17
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"go.devnw.com/canary/internal/config"
+	"go.devnw.com/canary/internal/coverage"
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/policy"
+)
+
+// Exit codes reported by Run through ExitError
+const (
+	ExitOK         = 0
+	ExitVerifyFail = 2 // verify or staleness gate failed
+	ExitParseError = 3 // token, regex or I/O error
+)
+
+// ExitError carries the process exit code for a failed Run
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// Options mirrors the flags accepted by `canary scan`
+type Options struct {
+	Root        string   // repository root to scan
+	Out         string   // status.json output path
+	CSV         string   // optional status.csv output path
+	Verify      string   // optional GAP_ANALYSIS file to verify claims against
+	Strict      bool     // fail on stale TESTED/BENCHED tokens
+	UpdateStale bool     // rewrite UPDATED on stale TESTED/BENCHED tokens
+	GitStale    bool     // fail on completed tokens whose code or tests changed after them in git
+	Skip        string   // skip path regex (RE2), SkipDefault when empty
+	ProjectOnly bool     // filter by requirements.id_pattern from .canary/project.yaml
+	VerifyTests bool     // downgrade tokens whose TEST/BENCH functions don't exist
+	Results     []string // go test -json, JUnit or TAP reports to grade tokens against
+	Coverage    []string // Go cover profiles, LCOV or Cobertura reports to measure tokens' code with
+
+	// Lifecycle decides which statuses are valid and how tokens are
+	// promoted. When nil, the lifecycle configured in Root's
+	// .canary/project.yaml is used.
+	Lifecycle *lifecycle.Lifecycle
+}
+
+// Run performs a full scan using opts, writing the JSON/CSV reports and any
+// diagnostics to stderr. It returns an *ExitError when the scan should exit
+// non-zero: ExitVerifyFail for verify or staleness failures and
+// ExitParseError for parse errors, including a .canary/project.yaml that
+// can't be loaded when opts.Lifecycle is nil. Missing TEST/BENCH functions count as
+// verify failures when opts.VerifyTests is set, failing or missing test
+// results when opts.Results is set, code changed since its token in git
+// when opts.GitStale is set, and completed features below the policy's
+// min_coverage when opts.Coverage is set.
+func Run(opts Options, stderr io.Writer) error {
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+	if opts.Out == "" {
+		opts.Out = "status.json"
+	}
+
+	skip := SkipDefault
+	if opts.Skip != "" {
+		var err error
+		skip, err = regexp.Compile(opts.Skip)
+		if err != nil {
+			return failParse(stderr, fmt.Errorf("bad --skip regex: %w", err))
+		}
+	}
+
+	cfg, cfgErr := config.Load(opts.Root)
+	lc := opts.Lifecycle
+	if lc == nil {
+		if cfgErr != nil {
+			return failParse(stderr, cfgErr)
+		}
+		var err error
+		if lc, err = cfg.StatusLifecycle(); err != nil {
+			return failParse(stderr, err)
+		}
+	}
+
+	// Load project config if --project-only is set
+	var projectFilter *regexp.Regexp
+	if opts.ProjectOnly {
+		var err error
+		switch {
+		case cfgErr != nil:
+			fmt.Fprintf(stderr, "Warning: --project-only specified but failed to load .canary/project.yaml: %v\n", cfgErr)
+			fmt.Fprintf(stderr, "Scanning all requirements. Run 'canary init' to create project config.\n")
+		case cfg.Requirements.IDPattern == "":
+			fmt.Fprintf(stderr, "Warning: --project-only specified but .canary/project.yaml has no requirements.id_pattern\n")
+			fmt.Fprintf(stderr, "Scanning all requirements. Run 'canary init' to create project config.\n")
+		default:
+			projectFilter, err = regexp.Compile(cfg.Requirements.IDPattern)
+			if err != nil {
+				return failParse(stderr, fmt.Errorf("invalid project id_pattern %q: %w", cfg.Requirements.IDPattern, err))
+			}
+			fmt.Fprintf(stderr, "Filtering by project pattern: %s\n", cfg.Requirements.IDPattern)
+		}
+	}
+
+	// Load .canaryignore if it exists
+	ignorePatterns, err := LoadCanaryIgnore(opts.Root)
+	if err != nil {
+		fmt.Fprintf(stderr, "Warning: failed to load .canaryignore: %v\n", err)
+	}
+	if ignorePatterns != nil {
+		fmt.Fprintf(stderr, "Loaded .canaryignore patterns\n")
+	}
+
+	rep, err := Scan(opts.Root, skip, projectFilter, ignorePatterns, lc)
+	if err != nil {
+		return failParse(stderr, err)
+	}
+
+	// Handle --update-stale before writing output files
+	if opts.UpdateStale {
+		staleTokens := Stale(rep, DefaultStaleness, lc)
+		if len(staleTokens) > 0 {
+			updatedFiles, err := UpdateStaleTokens(opts.Root, skip, StaleReqs(rep, DefaultStaleness, lc), lc)
+			if err != nil {
+				fmt.Fprintf(stderr, "CANARY_UPDATE_ERROR: %v\n", err)
+				return &ExitError{Code: ExitParseError, Err: err}
+			}
+			fmt.Fprintf(stderr, "Updated %d stale tokens in %d files\n", len(staleTokens), len(updatedFiles))
+			// Re-scan after updates
+			rep, err = Scan(opts.Root, skip, projectFilter, ignorePatterns, lc)
+			if err != nil {
+				return failParse(stderr, err)
+			}
+		} else {
+			fmt.Fprintln(stderr, "No stale tokens found")
+		}
+	}
+
+	var diags []string
+	var ix *evidence.Index
+	if opts.VerifyTests || opts.GitStale {
+		ix, err = EvidenceIndex(opts.Root)
+		if ix == nil {
+			return failParse(stderr, err)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Warning: %v\n", err)
+		}
+	}
+	if opts.VerifyTests {
+		diags = append(diags, ApplyEvidence(&rep, ix, lc)...)
+	}
+	if len(opts.Results) > 0 {
+		res := evidence.NewResults(nil)
+		for _, path := range opts.Results {
+			r, err := evidence.ReadResults(path)
+			if err != nil {
+				return failParse(stderr, err)
+			}
+			res.Merge(r)
+		}
+		diags = append(diags, ApplyResults(&rep, res, lc)...)
+	}
+	if len(opts.Coverage) > 0 {
+		prof := coverage.New()
+		for _, path := range opts.Coverage {
+			p, err := coverage.Read(path)
+			if err != nil {
+				return failParse(stderr, err)
+			}
+			prof.Merge(p)
+		}
+		var pol *policy.Policy
+		if cfgErr == nil {
+			pol = cfg.Policy()
+		}
+		cov, err := ApplyCoverage(&rep, opts.Root, skip, projectFilter, ignorePatterns, prof, pol, lc)
+		if err != nil {
+			return failParse(stderr, err)
+		}
+		diags = append(diags, cov...)
+	}
+
+	if err := WriteJSON(opts.Out, rep); err != nil {
+		return failParse(stderr, err)
+	}
+	if opts.CSV != "" {
+		if err := WriteCSV(opts.CSV, rep); err != nil {
+			return failParse(stderr, err)
+		}
+	}
+	if opts.Verify != "" {
+		if cfgErr != nil {
+			return failParse(stderr, cfgErr)
+		}
+		diags = append(diags, VerifyClaims(rep, opts.Verify, ClaimOptionsFor(cfg), lc)...)
+	}
+	if opts.Strict && !opts.UpdateStale {
+		diags = append(diags, Stale(rep, DefaultStaleness, lc)...)
+	}
+	if opts.GitStale {
+		stale, err := GitStale(opts.Root, skip, projectFilter, ignorePatterns, ix, lc)
+		if err != nil {
+			return failParse(stderr, err)
+		}
+		diags = append(diags, stale...)
+	}
+	if len(diags) > 0 {
+		for _, d := range diags {
+			fmt.Fprintln(stderr, d)
+		}
+		return &ExitError{Code: ExitVerifyFail, Err: fmt.Errorf("%d verification diagnostics", len(diags))}
+	}
+
+	return nil
+}
+
+func failParse(stderr io.Writer, err error) error {
+	fmt.Fprintf(stderr, "CANARY_PARSE_ERROR err=%q\n", err)
+	return &ExitError{Code: ExitParseError, Err: err}
+}
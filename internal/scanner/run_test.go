@@ -0,0 +1,393 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package scanner
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.devnw.com/canary/internal/lifecycle"
+)
+
+// TestCANARY_CBIN_149_Engine_RunExitCodes validates that Run reports the same
+// exit codes the standalone scanner used: 0 on success, 2 for verify/stale
+// failures and 3 for parse errors.
+func TestCANARY_CBIN_149_Engine_RunExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		gap      string
+		strict   bool
+		skip     string
+		wantCode int
+		wantDiag string
+	}{
+		{
+			name:     "clean scan",
+			token:    `// CANARY: REQ=CBIN-200; FEATURE="Alpha"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15`,
+			wantCode: ExitOK,
+		},
+		{
+			name:     "overclaim",
+			token:    `// CANARY: REQ=CBIN-201; FEATURE="Bravo"; ASPECT=API; STATUS=STUB; UPDATED=2025-10-15`,
+			gap:      "✅ CBIN-201\n",
+			wantCode: ExitVerifyFail,
+			wantDiag: "CANARY_VERIFY_FAIL REQ=CBIN-201",
+		},
+		{
+			name:     "stale",
+			token:    `// CANARY: REQ=CBIN-202; FEATURE="Charlie"; ASPECT=API; STATUS=TESTED; TEST=TestC; UPDATED=2020-01-01`,
+			strict:   true,
+			wantCode: ExitVerifyFail,
+			wantDiag: "CANARY_STALE REQ=CBIN-202",
+		},
+		{
+			name:     "invalid status",
+			token:    `// CANARY: REQ=CBIN-203; FEATURE="Delta"; ASPECT=API; STATUS=DONE; UPDATED=2025-10-15`,
+			wantCode: ExitParseError,
+			wantDiag: "CANARY_PARSE_ERROR",
+		},
+		{
+			name:     "bad skip regex",
+			token:    `// CANARY: REQ=CBIN-204; FEATURE="Echo"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15`,
+			skip:     "(",
+			wantCode: ExitParseError,
+			wantDiag: "bad --skip regex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.WriteFile(filepath.Join(root, "code.go"), []byte("package p\n"+tt.token+"\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			out := t.TempDir()
+			opts := Options{
+				Root:   root,
+				Out:    filepath.Join(out, "status.json"),
+				Strict: tt.strict,
+				Skip:   tt.skip,
+			}
+			if tt.gap != "" {
+				opts.Verify = filepath.Join(out, "GAP.md")
+				if err := os.WriteFile(opts.Verify, []byte(tt.gap), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var stderr strings.Builder
+			err := Run(opts, &stderr)
+
+			code := ExitOK
+			var exitErr *ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.Code
+			} else if err != nil {
+				t.Fatalf("unexpected error type %T: %v", err, err)
+			}
+			if code != tt.wantCode {
+				t.Fatalf("exit=%d want %d stderr=%s", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantDiag != "" && !strings.Contains(stderr.String(), tt.wantDiag) {
+				t.Errorf("stderr missing %q: %s", tt.wantDiag, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_WritesCSV(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("CANARY: REQ=CBIN-300; FEATURE=\"Foo\"; ASPECT=CLI; STATUS=IMPL; UPDATED=2025-10-15\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := t.TempDir()
+	csvPath := filepath.Join(out, "status.csv")
+	if err := Run(Options{Root: root, Out: filepath.Join(out, "status.json"), CSV: csvPath}, io.Discard); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	b, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if !strings.Contains(string(b), "CBIN-300,Foo,CLI,IMPL") {
+		t.Errorf("csv missing row: %s", b)
+	}
+}
+
+func TestRun_VerifyTestsDowngrades(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"code.go": "package p\n" +
+			`// CANARY: REQ=CBIN-300; FEATURE="Real"; ASPECT=API; STATUS=IMPL; TEST=TestReal; BENCH=BenchmarkReal; UPDATED=2025-10-15` + "\n" +
+			`// CANARY: REQ=CBIN-301; FEATURE="Typo"; ASPECT=API; STATUS=IMPL; TEST=TestTypo; UPDATED=2025-10-15` + "\n",
+		"code_test.go": "package p\n\nimport \"testing\"\n\nfunc TestReal(t *testing.T) {}\nfunc BenchmarkReal(b *testing.B) {}\nfunc TestTpyo(t *testing.T) {}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out := filepath.Join(t.TempDir(), "status.json")
+
+	var stderr strings.Builder
+	err := Run(Options{Root: root, Out: out, VerifyTests: true}, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitVerifyFail {
+		t.Fatalf("err = %v, want exit %d", err, ExitVerifyFail)
+	}
+	if want := `CANARY_VERIFY_FAIL REQ=CBIN-301 FEATURE="Typo" reason=test_not_found TEST=TestTypo`; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr missing %q: %s", want, stderr.String())
+	}
+	if strings.Contains(stderr.String(), "CBIN-300") {
+		t.Errorf("resolved evidence reported: %s", stderr.String())
+	}
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"status":"BENCHED"`, `"status":"IMPL"`, `"missing_tests":["TestTypo"]`, `"TESTED":0`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("status.json missing %s: %s", want, b)
+		}
+	}
+}
+
+func TestRun_ResultsMarkRegressed(t *testing.T) {
+	root := t.TempDir()
+	src := "package p\n" +
+		`// CANARY: REQ=CBIN-302; FEATURE="Passing"; ASPECT=API; STATUS=TESTED; TEST=TestPass; UPDATED=2025-10-15` + "\n" +
+		`// CANARY: REQ=CBIN-303; FEATURE="Failing"; ASPECT=API; STATUS=BENCHED; TEST=TestFail; BENCH=BenchmarkFail; UPDATED=2025-10-15` + "\n" +
+		`// CANARY: REQ=CBIN-312; FEATURE="Slow"; ASPECT=API; STATUS=BENCHED; TEST=TestPass; BENCH=BenchmarkSlow; UPDATED=2025-10-15` + "\n"
+	if err := os.WriteFile(filepath.Join(root, "code.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	results := filepath.Join(dir, "results.tap")
+	if err := os.WriteFile(results, []byte("1..3\nok 1 - TestPass\nnot ok 2 - TestFail\nok 3 - BenchmarkFail\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "status.json")
+
+	var stderr strings.Builder
+	err := Run(Options{Root: root, Out: out, Results: []string{results}}, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitVerifyFail {
+		t.Fatalf("err = %v, want exit %d", err, ExitVerifyFail)
+	}
+	for _, want := range []string{
+		`CANARY_VERIFY_FAIL REQ=CBIN-303 FEATURE="Failing" reason=test_failed TEST=TestFail`,
+		`CANARY_VERIFY_FAIL REQ=CBIN-312 FEATURE="Slow" reason=bench_not_run BENCH=BenchmarkSlow`,
+	} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr missing %q: %s", want, stderr.String())
+		}
+	}
+	if strings.Contains(stderr.String(), "TEST=BenchmarkSlow") {
+		t.Errorf("benchmark reported as a test: %s", stderr.String())
+	}
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"status":"REGRESSED"`, `"failing_tests":["TestFail"]`, `"not_run_benches":["BenchmarkSlow"]`, `"REGRESSED":1`, `"TESTED":2`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("status.json missing %s: %s", want, b)
+		}
+	}
+}
+
+func TestRun_VerifyUsesProjectClaims(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		".canary/project.yaml": `requirements:
+  id_pattern: "ACME-[A-Z]+-[0-9]{3}"
+verification:
+  claims:
+    implemented: ["DONE"]
+`,
+		"GAP.md":  "ACME-API-042 DONE\nACME-API-043 DONE\n",
+		"code.go": "package p\n// CANARY: REQ=ACME-API-042; FEATURE=\"Login\"; ASPECT=API; STATUS=TESTED; TEST=TestLogin; UPDATED=2025-10-15\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stderr strings.Builder
+	err := Run(Options{Root: dir, Out: filepath.Join(dir, "status.json"), Verify: filepath.Join(dir, "GAP.md")}, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitVerifyFail {
+		t.Fatalf("err = %v, want exit %d", err, ExitVerifyFail)
+	}
+	if out := stderr.String(); !strings.Contains(out, "REQ=ACME-API-043") || strings.Contains(out, "REQ=ACME-API-042") {
+		t.Errorf("unexpected diagnostics:\n%s", out)
+	}
+}
+
+func TestRun_ProjectLifecycle(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	files := map[string]string{
+		".canary/project.yaml": `lifecycle:
+  states:
+    - {name: STUB, pending: true}
+    - {name: IMPL, pending: true}
+    - {name: REVIEWED, satisfies: true}
+    - {name: TESTED, satisfies: true}
+    - {name: BENCHED, satisfies: true}
+    - {name: DEPRECATED}
+  promotions:
+    - {from: [IMPL], to: TESTED, when: [tests]}
+    - {from: [IMPL, TESTED], to: BENCHED, when: [benches]}
+  aspects:
+    Docs:
+      promotions:
+        - {from: [IMPL], to: TESTED, when: [tests]}
+`,
+		"code.go": "package p\n" +
+			`// CANARY: REQ=CBIN-304; FEATURE="Reviewed"; ASPECT=API; STATUS=REVIEWED; UPDATED=2025-10-15` + "\n" +
+			`// CANARY: REQ=CBIN-305; FEATURE="Old"; ASPECT=API; STATUS=DEPRECATED; UPDATED=2025-10-15` + "\n" +
+			`// CANARY: REQ=CBIN-306; FEATURE="Guide"; ASPECT=Docs; STATUS=IMPL; TEST=TestGuide; BENCH=BenchmarkGuide; UPDATED=2025-10-15` + "\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out := filepath.Join(t.TempDir(), "status.json")
+
+	if err := Run(Options{Root: root, Out: out}, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"REVIEWED":1`, `"DEPRECATED":1`, `"TESTED":1`, `"BENCHED":0`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("status.json missing %s: %s", want, b)
+		}
+	}
+	if strings.Contains(string(b), `"MISSING"`) {
+		t.Errorf("status.json counts a state the lifecycle doesn't have: %s", b)
+	}
+}
+
+func TestRun_ProjectLifecycleEvidence(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	files := map[string]string{
+		".canary/project.yaml": `lifecycle:
+  states:
+    - {name: STUB, pending: true}
+    - {name: IMPL, pending: true}
+    - {name: REVIEWED, satisfies: true}
+    - {name: TESTED, satisfies: true}
+  promotions:
+    - {from: [IMPL, REVIEWED], to: TESTED, when: [tests]}
+`,
+		"GAP.md": "✅ CBIN-307\n✅ CBIN-308\n",
+		"code.go": "package p\n" +
+			`// CANARY: REQ=CBIN-307; FEATURE="Reviewed"; ASPECT=API; STATUS=REVIEWED; UPDATED=2020-01-01` + "\n" +
+			`// CANARY: REQ=CBIN-308; FEATURE="Draft"; ASPECT=API; STATUS=IMPL; UPDATED=2020-01-01` + "\n" +
+			`// CANARY: REQ=CBIN-309; FEATURE="Typo"; ASPECT=API; STATUS=TESTED; TEST=TestTypo; UPDATED=2020-01-01` + "\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out := filepath.Join(t.TempDir(), "status.json")
+
+	var stderr strings.Builder
+	err := Run(Options{Root: root, Out: out, Verify: filepath.Join(root, "GAP.md"), Strict: true, VerifyTests: true}, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitVerifyFail {
+		t.Fatalf("err = %v, want exit %d", err, ExitVerifyFail)
+	}
+	diags := stderr.String()
+	for _, want := range []string{
+		"CANARY_STALE REQ=CBIN-307",
+		"CANARY_VERIFY_FAIL REQ=CBIN-308 reason=claimed_but_not_TESTED_OR_BENCHED",
+		`CANARY_VERIFY_FAIL REQ=CBIN-309 FEATURE="Typo" reason=test_not_found`,
+	} {
+		if !strings.Contains(diags, want) {
+			t.Errorf("stderr missing %q: %s", want, diags)
+		}
+	}
+	for _, unwanted := range []string{"CANARY_VERIFY_FAIL REQ=CBIN-307", "CANARY_STALE REQ=CBIN-308"} {
+		if strings.Contains(diags, unwanted) {
+			t.Errorf("stderr has %q: %s", unwanted, diags)
+		}
+	}
+
+	// Without its test the TESTED token steps down to REVIEWED, not IMPL
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"REVIEWED":2`; !strings.Contains(string(b), want) {
+		t.Errorf("status.json missing %s: %s", want, b)
+	}
+}
+
+func TestRun_LifecycleSources(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".canary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	src := "package p\n// CANARY: REQ=CBIN-310; FEATURE=\"Draft\"; ASPECT=API; STATUS=DRAFT; UPDATED=2025-10-15\n"
+	if err := os.WriteFile(filepath.Join(root, "code.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "status.json")
+
+	// A project.yaml that doesn't parse is reported, not silently replaced
+	// by the default lifecycle
+	if err := os.WriteFile(filepath.Join(root, ".canary", "project.yaml"), []byte("lifecycle: [\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stderr strings.Builder
+	err := Run(Options{Root: root, Out: out}, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitParseError || !strings.Contains(stderr.String(), "parse config file") {
+		t.Fatalf("err = %v, stderr = %q; want a config parse error", err, stderr.String())
+	}
+
+	// An explicit lifecycle is used as given
+	lc := &lifecycle.Lifecycle{States: []lifecycle.State{{Name: "DRAFT", Pending: true}, {Name: "DONE", Satisfies: true}}}
+	if err := Run(Options{Root: root, Out: out, Lifecycle: lc}, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"DRAFT":1`; !strings.Contains(string(b), want) {
+		t.Errorf("status.json missing %s: %s", want, b)
+	}
+}
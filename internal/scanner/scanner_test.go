@@ -3,7 +3,7 @@
 // For more details, see the LICENSE file in the root directory of this
 // source code repository or contact Developer Network at info@devnw.com.
 
-package main
+package scanner
 
 import (
 	"fmt"
@@ -40,7 +40,7 @@ func TestCANARY_CBIN_101_Engine_ScanBasic(t *testing.T) {
 	}
 
 	// Execute: scan directory
-	rep, err := scan(dir, skipDefault, nil, nil)
+	rep, err := Scan(dir, SkipDefault, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("scan failed: %v", err)
 	}
@@ -91,10 +91,10 @@ func setupFixture(tb testing.TB, numFiles int) string {
 // Baseline target: allocs/op ≤ 10
 func BenchmarkCANARY_CBIN_101_Engine_Scan(b *testing.B) {
 	dir := setupFixture(b, 100)
-	skip := skipDefault
+	skip := SkipDefault
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := scan(dir, skip, nil, nil)
+		_, err := Scan(dir, skip, nil, nil, nil)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -109,12 +109,12 @@ func BenchmarkCANARY_CBIN_101_Engine_Scan50k(b *testing.B) {
 	// Create 50k file fixture (one-time setup)
 	b.StopTimer()
 	dir := setupFixture(b, 50000)
-	skip := skipDefault
+	skip := SkipDefault
 	b.StartTimer()
 
 	// Run scan N times
 	for i := 0; i < b.N; i++ {
-		_, err := scan(dir, skip, nil, nil)
+		_, err := Scan(dir, skip, nil, nil, nil)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -0,0 +1,224 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/gitstale"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/token"
+)
+
+// DefaultStaleness is the age after which the UPDATED field of a token in
+// a satisfying status is considered stale.
+const DefaultStaleness = 30 * 24 * time.Hour
+
+var updatedRe = regexp.MustCompile(`(UPDATED=)([0-9]{4}-[0-9]{2}-[0-9]{2})`)
+
+// Stale returns a CANARY_STALE diagnostic for every feature in a
+// satisfying status of lc whose UPDATED date is older than maxAge.
+func Stale(rep Report, maxAge time.Duration, lc *lifecycle.Lifecycle) []string {
+	var diags []string
+	staleFeatures(rep, maxAge, lc, func(r Requirement, f Feature, updated time.Time, err error) {
+		if err != nil {
+			diags = append(diags, fmt.Sprintf("CANARY_PARSE_ERROR file=%s err=%q", strings.Join(f.Files, ","), err))
+			return
+		}
+		age := int(time.Since(updated).Hours() / 24)
+		diags = append(diags, fmt.Sprintf("CANARY_STALE REQ=%s updated=%s age_days=%d threshold=%d", r.ID, f.Updated, age, int(maxAge.Hours()/24)))
+	})
+	return diags
+}
+
+// StaleReqs returns the IDs of the requirements with a feature Stale
+// reports as stale, in report order.
+func StaleReqs(rep Report, maxAge time.Duration, lc *lifecycle.Lifecycle) []string {
+	var ids []string
+	seen := map[string]bool{}
+	staleFeatures(rep, maxAge, lc, func(r Requirement, _ Feature, _ time.Time, err error) {
+		if err == nil && !seen[r.ID] {
+			seen[r.ID] = true
+			ids = append(ids, r.ID)
+		}
+	})
+	return ids
+}
+
+// staleFeatures calls fn for every feature in a satisfying status of lc
+// whose UPDATED date is older than maxAge, or doesn't parse.
+func staleFeatures(rep Report, maxAge time.Duration, lc *lifecycle.Lifecycle, fn func(r Requirement, f Feature, updated time.Time, err error)) {
+	cut := time.Now().UTC().Add(-maxAge)
+	for _, r := range rep.Requirements {
+		for _, f := range r.Features {
+			if !lc.Satisfies(f.Status, f.Aspect) {
+				continue
+			}
+			t, err := time.Parse("2006-01-02", f.Updated)
+			if err != nil || t.Before(cut) {
+				fn(r, f, t, err)
+			}
+		}
+	}
+}
+
+// GitStale returns a CANARY_STALE diagnostic for every completed token under
+// root whose code, or a test it names in ix, changed in git after the token
+// itself last did. ix may be nil to only compare code. Requirements that
+// don't match projectFilter (when non-nil) are not checked, and tokens are
+// completed when their status satisfies lc.
+func GitStale(root string, skip, projectFilter *regexp.Regexp, ignorePatterns *ignore.GitIgnore, ix *evidence.Index, lc *lifecycle.Lifecycle) ([]string, error) {
+	if root == "" {
+		root = "."
+	}
+	if skip == nil {
+		skip = SkipDefault
+	}
+	b, err := gitstale.NewBlamer(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var toks []*token.Token
+	err = walk(root, skip, ignorePatterns, func(path string) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		parsed, _ := token.Parse(path, content, lc)
+		toks = append(toks, parsed...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := gitstale.Check(b, toks, gitstale.Options{Index: ix})
+	if err != nil {
+		return nil, err
+	}
+	var diags []string
+	for _, f := range found {
+		tok := f.Token
+		req := normalizeREQ(tok.ReqID())
+		if projectFilter != nil && !projectFilter.MatchString(req) {
+			continue
+		}
+		if !lc.Satisfies(tok.Status(), tok.Aspect()) {
+			continue
+		}
+		reason := "code_changed"
+		switch {
+		case f.Test != "":
+			reason = "test_changed TEST=" + f.Test
+		case f.Symbol != "":
+			reason = "code_changed SYMBOL=" + f.Symbol
+		}
+		diags = append(diags, fmt.Sprintf("CANARY_STALE REQ=%s FEATURE=%q reason=%s token_changed=%s changed=%s file=%s",
+			req, tok.Feature(), reason, f.TokenTime.Format("2006-01-02"), f.Changed.Format("2006-01-02"), f.File))
+	}
+	return diags, nil
+}
+
+// UpdateStaleTokens rewrites the UPDATED field of the tokens of reqs, as
+// returned by StaleReqs, in source files. Only tokens in a satisfying
+// status of lc are updated.
+// Returns map of file paths that were updated.
+func UpdateStaleTokens(root string, skip *regexp.Regexp, reqs []string, lc *lifecycle.Lifecycle) (map[string]bool, error) {
+	staleReqs := make(map[string]bool)
+	for _, id := range reqs {
+		staleReqs[normalizeREQ(id)] = true
+	}
+
+	if len(staleReqs) == 0 {
+		return nil, nil
+	}
+	if skip == nil {
+		skip = SkipDefault
+	}
+
+	updatedFiles := make(map[string]bool)
+	today := time.Now().UTC().Format("2006-01-02")
+
+	// Walk directory and update files
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			if rel != "." && skip.MatchString(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		if skip.MatchString(rel) {
+			return nil
+		}
+
+		// Read file
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil // Skip unreadable files
+		}
+
+		// Check if file contains CANARY tokens
+		if !bytes.Contains(content, []byte("CANARY:")) {
+			return nil
+		}
+
+		lines := strings.Split(string(content), "\n")
+		modified := false
+
+		toks, _ := token.Parse(path, content, lc)
+		for _, tok := range toks {
+			if !staleReqs[normalizeREQ(tok.ReqID())] {
+				continue
+			}
+
+			// Only tokens in a satisfying status go stale
+			if !lc.Satisfies(tok.Status(), tok.Aspect()) {
+				continue
+			}
+
+			// Update the UPDATED field, which may sit on a continuation line
+			for i := tok.Line - 1; i < tok.EndLine && i < len(lines); i++ {
+				if updatedRe.MatchString(lines[i]) {
+					lines[i] = updatedRe.ReplaceAllString(lines[i], fmt.Sprintf("${1}%s", today))
+					modified = true
+					break
+				}
+			}
+		}
+
+		if modified {
+			// Write back to file
+			newContent := strings.Join(lines, "\n")
+			if err := os.WriteFile(path, []byte(newContent), info.Mode()); err != nil {
+				return fmt.Errorf("write %s: %w", path, err)
+			}
+			updatedFiles[path] = true
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedFiles, nil
+}
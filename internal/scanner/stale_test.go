@@ -0,0 +1,86 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpdateStaleTokens_ContinuationLine(t *testing.T) {
+	dir := t.TempDir()
+	src := `package p
+
+// CANARY: REQ=CBIN-300; FEATURE="Long"; ASPECT=API; STATUS=TESTED; \
+//   TEST=TestLong; UPDATED=2024-01-01
+func Long() {}
+
+// CANARY: REQ=CBIN-301; FEATURE="Other"; ASPECT=API; STATUS=TESTED; TEST=TestOther; UPDATED=2024-01-01
+`
+	path := filepath.Join(dir, "long.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := UpdateStaleTokens(dir, SkipDefault, []string{"CBIN-300"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated[path] {
+		t.Fatalf("file not updated: %v", updated)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(b), "\n")
+	today := time.Now().UTC().Format("2006-01-02")
+	if !strings.HasSuffix(lines[3], "UPDATED="+today) {
+		t.Errorf("continuation line not updated: %q", lines[3])
+	}
+	if !strings.HasSuffix(lines[6], "UPDATED=2024-01-01") {
+		t.Errorf("unrelated token changed: %q", lines[6])
+	}
+}
+
+func TestUpdateStaleTokens_ProjectIDs(t *testing.T) {
+	root := t.TempDir()
+	src := "package p\n" +
+		`// CANARY: REQ=ACME-API-042; FEATURE="Login"; ASPECT=API; STATUS=TESTED; TEST=TestLogin; UPDATED=2024-01-01` + "\n" +
+		`// CANARY: REQ=OPS-1042; FEATURE="Deploy"; ASPECT=CLI; STATUS=TESTED; TEST=TestDeploy; UPDATED=2024-01-01` + "\n" +
+		`// CANARY: REQ=ACME-API-043; FEATURE="Logout"; ASPECT=API; STATUS=IMPL; UPDATED=2024-01-01` + "\n"
+	path := filepath.Join(root, "auth.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr strings.Builder
+	if err := Run(Options{Root: root, Out: filepath.Join(t.TempDir(), "status.json"), UpdateStale: true}, &stderr); err != nil {
+		t.Fatalf("run: %v\n%s", err, stderr.String())
+	}
+	if want := "Updated 2 stale tokens in 1 files"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr missing %q: %s", want, stderr.String())
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(b), "\n")
+	today := time.Now().UTC().Format("2006-01-02")
+	for _, i := range []int{1, 2} {
+		if !strings.HasSuffix(lines[i], "UPDATED="+today) {
+			t.Errorf("stale token not updated: %q", lines[i])
+		}
+	}
+	if !strings.HasSuffix(lines[3], "UPDATED=2024-01-01") {
+		t.Errorf("IMPL token changed: %q", lines[3])
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-103; FEATURE="StatusJSON"; ASPECT=API; STATUS=BENCHED; TEST=TestCANARY_CBIN_103_API_StatusSchema; BENCH=BenchmarkCANARY_CBIN_103_API_Emit; OWNER=canary; UPDATED=2026-10-17
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"go.devnw.com/canary/internal/coverage"
+)
+
+type StatusCounts map[string]int
+
+func (m StatusCounts) MarshalJSON() ([]byte, error) { return marshalSortedMap(m) }
+
+type AspectCounts map[string]int
+
+func (m AspectCounts) MarshalJSON() ([]byte, error) { return marshalSortedMap(m) }
+
+// Report is the status.json document produced by a scan
+type Report struct {
+	GeneratedAt  string        `json:"generated_at"`
+	Requirements []Requirement `json:"requirements"`
+	Summary      Summary       `json:"summary"`
+}
+
+// Requirement groups the features found for a single requirement ID
+type Requirement struct {
+	ID       string    `json:"id"`
+	Features []Feature `json:"features"`
+
+	// Set by ApplyCoverage, summed over the features with instrumented code
+	Coverage *coverage.Counts `json:"coverage,omitempty"`
+}
+
+// Feature is one aggregated REQ/FEATURE/ASPECT entry with its evidence
+type Feature struct {
+	Feature string   `json:"feature"`
+	Aspect  string   `json:"aspect"`
+	Status  string   `json:"status"`
+	Files   []string `json:"files"`
+	Tests   []string `json:"tests"`
+	Benches []string `json:"benches"`
+	Owner   string   `json:"owner,omitempty"`
+	Updated string   `json:"updated"`
+
+	// Set by ApplyEvidence for TEST/BENCH names with no matching function
+	MissingTests   []string `json:"missing_tests,omitempty"`
+	MissingBenches []string `json:"missing_benches,omitempty"`
+
+	// Set by ApplyResults for named tests and benchmarks that failed or
+	// didn't run
+	FailingTests  []string `json:"failing_tests,omitempty"`
+	NotRunTests   []string `json:"not_run_tests,omitempty"`
+	NotRunBenches []string `json:"not_run_benches,omitempty"`
+
+	// Set by ApplyCoverage when the feature's code is instrumented
+	Coverage *coverage.Counts `json:"coverage,omitempty"`
+}
+
+// Summary holds the aggregate counts for a Report
+type Summary struct {
+	ByStatus           StatusCounts `json:"by_status"`
+	ByAspect           AspectCounts `json:"by_aspect"`
+	TotalTokens        int          `json:"total_tokens"`
+	UniqueRequirements int          `json:"unique_requirements"`
+}
+
+// WriteJSON writes the report as minified JSON to path
+func WriteJSON(path string, rep Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(rep)
+}
+
+// WriteCSV writes the report as CSV to path, one row per file/test/bench
+func WriteCSV(path string, rep Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	fmt.Fprintln(f, "req,feature,aspect,status,file,test,bench,owner,updated")
+	for _, r := range rep.Requirements {
+		for _, ft := range r.Features {
+			max := max3(len(ft.Files), len(ft.Tests), len(ft.Benches))
+			if max == 0 {
+				fmt.Fprintf(f, "%s,%s,%s,%s,,,,%s,%s\n", r.ID, ft.Feature, ft.Aspect, ft.Status, ft.Owner, ft.Updated)
+				continue
+			}
+			for i := 0; i < max; i++ {
+				file, test, bench := "", "", ""
+				if i < len(ft.Files) {
+					file = ft.Files[i]
+				}
+				if i < len(ft.Tests) {
+					test = ft.Tests[i]
+				}
+				if i < len(ft.Benches) {
+					bench = ft.Benches[i]
+				}
+
+				fmt.Fprintf(f, "%s,%s,%s,%s,%s,%s,%s,%s,%s\n", r.ID, ft.Feature, ft.Aspect, ft.Status, file, test, bench, ft.Owner, ft.Updated)
+			}
+		}
+	}
+	return nil
+}
+
+func marshalSortedMap(m map[string]int) ([]byte, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(fmt.Sprintf("%q:%d", k, m[k]))
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
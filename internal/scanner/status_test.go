@@ -3,7 +3,7 @@
 // For more details, see the LICENSE file in the root directory of this
 // source code repository or contact Developer Network at info@devnw.com.
 
-package main
+package scanner
 
 import (
 	"crypto/sha256"
@@ -152,7 +152,7 @@ func Feature%d() {}
 	var hashes []string
 	var jsons []string
 	for run := 0; run < 5; run++ {
-		rep, err := scan(dir, skipDefault, nil, nil)
+		rep, err := Scan(dir, SkipDefault, nil, nil, nil)
 		if err != nil {
 			t.Fatalf("scan %d failed: %v", run, err)
 		}
@@ -263,10 +263,10 @@ func BenchmarkCANARY_CBIN_103_API_Emit(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		if err := writeJSON(jsonPath, *rep); err != nil {
+		if err := WriteJSON(jsonPath, *rep); err != nil {
 			b.Fatal(err)
 		}
-		if err := writeCSV(csvPath, *rep); err != nil {
+		if err := WriteCSV(csvPath, *rep); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-102; FEATURE="VerifyGate"; ASPECT=CLI; STATUS=BENCHED; TEST=TestCANARY_CBIN_102_CLI_Verify; BENCH=BenchmarkCANARY_CBIN_102_CLI_Verify; OWNER=canary; UPDATED=2026-10-17
+package scanner
+
+import (
+	"fmt"
+	"os"
+
+	"go.devnw.com/canary/internal/evidence"
+	"go.devnw.com/canary/internal/lifecycle"
+)
+
+// VerifyClaims checks every requirement claimed implemented in the GAP file
+// at gapPath against the report and returns a CANARY_VERIFY_FAIL diagnostic
+// for each claim without a feature in a satisfying status of lc. A GAP file
+// that can't be read or has no recognizable claims is reported as a
+// CANARY_PARSE_ERROR.
+func VerifyClaims(rep Report, gapPath string, opts ClaimOptions, lc *lifecycle.Lifecycle) []string {
+	b, err := os.ReadFile(gapPath)
+	if err != nil {
+		return []string{fmt.Sprintf("CANARY_PARSE_ERROR file=%s err=%q", gapPath, err)}
+	}
+	claims, err := ParseClaims(b, opts)
+	if err != nil {
+		return []string{fmt.Sprintf("CANARY_PARSE_ERROR file=%s err=%q", gapPath, err)}
+	}
+	evidence := map[string]bool{}
+	for _, r := range rep.Requirements {
+		ok := false
+		for _, f := range r.Features {
+			if lc.Satisfies(f.Status, f.Aspect) {
+				ok = true
+				break
+			}
+		}
+		evidence[r.ID] = ok
+	}
+	var diags []string
+	reported := map[string]bool{}
+	for _, c := range claims {
+		if !c.Implemented || evidence[c.REQ] || reported[c.REQ] {
+			continue
+		}
+		reported[c.REQ] = true
+		diags = append(diags, fmt.Sprintf("CANARY_VERIFY_FAIL REQ=%s reason=claimed_but_not_TESTED_OR_BENCHED", c.REQ))
+	}
+	return diags
+}
+
+// EvidenceIndex resolves the test symbols of every test file under root.
+// The index is returned even when some test files fail to parse.
+func EvidenceIndex(root string) (*evidence.Index, error) {
+	paths, err := evidence.Files(root)
+	if err != nil {
+		return nil, err
+	}
+	return evidence.Build(paths)
+}
+
+// ApplyEvidence checks every TEST and BENCH name in rep against ix. Features
+// whose evidence doesn't exist are demoted by lc, their missing names
+// recorded and one CANARY_VERIFY_FAIL diagnostic returned per missing name.
+// The summary counts are recomputed.
+func ApplyEvidence(rep *Report, ix *evidence.Index, lc *lifecycle.Lifecycle) []string {
+	var diags []string
+	for i := range rep.Requirements {
+		r := &rep.Requirements[i]
+		for j := range r.Features {
+			f := &r.Features[j]
+			f.MissingTests = ix.Missing(evidence.Test, f.Tests)
+			f.MissingBenches = ix.Missing(evidence.Bench, f.Benches)
+			for _, n := range f.MissingTests {
+				diags = append(diags, fmt.Sprintf("CANARY_VERIFY_FAIL REQ=%s FEATURE=%q reason=test_not_found TEST=%s", r.ID, f.Feature, n))
+			}
+			for _, n := range f.MissingBenches {
+				diags = append(diags, fmt.Sprintf("CANARY_VERIFY_FAIL REQ=%s FEATURE=%q reason=bench_not_found BENCH=%s", r.ID, f.Feature, n))
+			}
+			f.Status = lc.Demote(f.Status, f.Aspect,
+				len(f.Tests) > len(f.MissingTests), len(f.Benches) > len(f.MissingBenches))
+		}
+	}
+	recount(rep, lc)
+	return diags
+}
+
+// ApplyResults sets the effective status of every feature from a test run:
+// a feature in a satisfying status with a failing test becomes REGRESSED, and one
+// whose tests didn't run keeps only the status its passing tests support.
+// Statuses are judged by lc. It returns one CANARY_VERIFY_FAIL diagnostic
+// per failing or missing test result and recomputes the summary counts.
+func ApplyResults(rep *Report, res *evidence.Results, lc *lifecycle.Lifecycle) []string {
+	var diags []string
+	for i := range rep.Requirements {
+		r := &rep.Requirements[i]
+		for j := range r.Features {
+			f := &r.Features[j]
+			ev := res.Evaluate(f.Status, f.Aspect, f.Tests, f.Benches, lc)
+			f.Status, f.FailingTests = ev.Status, ev.Failing
+			f.NotRunTests, f.NotRunBenches = ev.NotRunTests, ev.NotRunBenches
+			for _, n := range ev.Failing {
+				diags = append(diags, fmt.Sprintf("CANARY_VERIFY_FAIL REQ=%s FEATURE=%q reason=test_failed TEST=%s", r.ID, f.Feature, n))
+			}
+			for _, n := range ev.NotRunTests {
+				diags = append(diags, fmt.Sprintf("CANARY_VERIFY_FAIL REQ=%s FEATURE=%q reason=test_not_run TEST=%s", r.ID, f.Feature, n))
+			}
+			for _, n := range ev.NotRunBenches {
+				diags = append(diags, fmt.Sprintf("CANARY_VERIFY_FAIL REQ=%s FEATURE=%q reason=bench_not_run BENCH=%s", r.ID, f.Feature, n))
+			}
+		}
+	}
+	recount(rep, lc)
+	return diags
+}
+
+// recount recomputes the status counts after features were re-evaluated.
+func recount(rep *Report, lc *lifecycle.Lifecycle) {
+	byStatus := newStatusCounts(lc)
+	for _, r := range rep.Requirements {
+		for _, f := range r.Features {
+			byStatus[f.Status]++
+		}
+	}
+	rep.Summary.ByStatus = byStatus
+}
@@ -3,7 +3,7 @@
 // For more details, see the LICENSE file in the root directory of this
 // source code repository or contact Developer Network at info@devnw.com.
 
-package main
+package scanner
 
 import (
 	"fmt"
@@ -44,13 +44,13 @@ func TestCANARY_CBIN_102_CLI_Verify(t *testing.T) {
 	}
 
 	// Execute: scan repo
-	rep, err := scan(repoDir, skipDefault, nil, nil)
+	rep, err := Scan(repoDir, SkipDefault, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("scan failed: %v", err)
 	}
 
 	// Execute: verify claims
-	diags := verifyClaims(rep, gapFile)
+	diags := VerifyClaims(rep, gapFile, ClaimOptions{}, nil)
 
 	// Verify: overclaim detected
 	if len(diags) == 0 {
@@ -130,6 +130,6 @@ func BenchmarkCANARY_CBIN_102_CLI_Verify(b *testing.B) {
 	gapFile, rep := setupGAPFixture(b, 50)
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = verifyClaims(*rep, gapFile)
+		_ = VerifyClaims(*rep, gapFile, ClaimOptions{}, nil)
 	}
 }
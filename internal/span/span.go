@@ -0,0 +1,263 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package span finds the code a CANARY token annotates: the declaration that
+// immediately follows the token, or the one enclosing it. Go files are read
+// with go/ast; other languages are matched by declaration keywords and their
+// extent found by brace matching, or by indentation for Python.
+package span
+
+// CANARY: REQ=CBIN-165; FEATURE="CodeSpans"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_165_Engine_CodeSpans
+
+import (
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.devnw.com/canary/internal/token"
+)
+
+// Span is a declaration and the lines it occupies, doc comment excluded.
+type Span struct {
+	Kind   string // func, method, type, var, const, or the keyword matched (def, class, fn...)
+	Symbol string // e.g. Parse, Blamer.Lines
+	Start  int
+	End    int
+}
+
+// File holds the declarations of one source file.
+type File struct {
+	lines []string
+	decls []Span
+}
+
+// Parse finds the declarations in src. Go files that don't parse, and every
+// other language, fall back to the keyword heuristic.
+func Parse(path string, src []byte) *File {
+	f := &File{lines: strings.Split(string(src), "\n")}
+	if strings.HasSuffix(path, ".go") {
+		if decls, ok := goDecls(path, src); ok {
+			f.decls = decls
+			return f
+		}
+	}
+	f.decls = heuristicDecls(f.lines, strings.ToLower(filepath.Ext(path)) == ".py")
+	return f
+}
+
+// Tokens returns the span of each of toks, all from the file at path, in the
+// same order. Tokens that annotate no declaration get a zero Span.
+func Tokens(path string, src []byte, toks []*token.Token) []Span {
+	if len(toks) == 0 {
+		return nil
+	}
+	f := Parse(path, src)
+	out := make([]Span, len(toks))
+	for i, t := range toks {
+		end := t.EndLine
+		if end < t.Line {
+			end = t.Line
+		}
+		out[i], _ = f.Find(t.Line, end)
+	}
+	return out
+}
+
+// Find returns the declaration annotated by a token on lines start through
+// end. The declaration following the token wins when only blank lines,
+// comments and attributes separate them, unless it lies outside the
+// declaration enclosing the token.
+func (f *File) Find(start, end int) (Span, bool) {
+	var enclosing, following *Span
+	for i := range f.decls {
+		d := &f.decls[i]
+		if d.Start <= start && end <= d.End {
+			if enclosing == nil || d.End-d.Start < enclosing.End-enclosing.Start {
+				enclosing = d
+			}
+		}
+		if d.Start > end && (following == nil || d.Start < following.Start) {
+			following = d
+		}
+	}
+	if following != nil && !f.trivia(end+1, following.Start-1) {
+		following = nil
+	}
+	if following != nil && enclosing != nil && following.End > enclosing.End {
+		following = nil
+	}
+
+	switch {
+	case following != nil:
+		return *following, true
+	case enclosing != nil:
+		return *enclosing, true
+	}
+	return Span{}, false
+}
+
+// trivia reports whether lines from through to hold nothing but blank
+// lines, comments and attributes.
+func (f *File) trivia(from, to int) bool {
+	for i := from; i <= to && i <= len(f.lines); i++ {
+		l := strings.TrimSpace(f.lines[i-1])
+		if l == "" {
+			continue
+		}
+		trivial := false
+		for _, p := range []string{"//", "/*", "*", "#", "--", ";", "<!--", "@"} {
+			if strings.HasPrefix(l, p) {
+				trivial = true
+				break
+			}
+		}
+		if !trivial {
+			return false
+		}
+	}
+	return true
+}
+
+// goDecls lists the top-level declarations of a Go file, and the specs of
+// grouped type, var and const declarations.
+func goDecls(path string, src []byte) ([]Span, bool) {
+	fset := gotoken.NewFileSet()
+	af, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, false
+	}
+	line := func(p gotoken.Pos) int { return fset.Position(p).Line }
+
+	var out []Span
+	for _, d := range af.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			s := Span{Kind: "func", Symbol: d.Name.Name, Start: line(d.Pos()), End: line(d.End())}
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				s.Kind, s.Symbol = "method", receiver(d.Recv.List[0].Type)+"."+d.Name.Name
+			}
+			out = append(out, s)
+		case *ast.GenDecl:
+			if d.Tok == gotoken.IMPORT {
+				continue
+			}
+			kind := d.Tok.String()
+			var names []string
+			for _, spec := range d.Specs {
+				var specNames []string
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					specNames = []string{spec.Name.Name}
+				case *ast.ValueSpec:
+					for _, n := range spec.Names {
+						specNames = append(specNames, n.Name)
+					}
+				}
+				names = append(names, specNames...)
+				if d.Lparen.IsValid() {
+					out = append(out, Span{Kind: kind, Symbol: strings.Join(specNames, ", "), Start: line(spec.Pos()), End: line(spec.End())})
+				}
+			}
+			symbol := strings.Join(names, ", ")
+			if d.Lparen.IsValid() && len(names) > 1 {
+				symbol = names[0] + ", …"
+			}
+			out = append(out, Span{Kind: kind, Symbol: symbol, Start: line(d.Pos()), End: line(d.End())})
+		}
+	}
+	return out, true
+}
+
+// receiver returns the type name of a method receiver.
+func receiver(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.StarExpr:
+		return receiver(e.X)
+	case *ast.IndexExpr:
+		return receiver(e.X)
+	case *ast.IndexListExpr:
+		return receiver(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+var (
+	declRe  = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:static\s+)?(?:abstract\s+)?(def|class|function\*?|fn|func|struct|enum|trait|interface|impl|type|module|mod)\s+([A-Za-z_$][\w$]*)`)
+	arrowRe = regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=\s*(?:async\s*)?(?:\([^)]*\)|[A-Za-z_$][\w$]*)\s*=>`)
+)
+
+// heuristicDecls finds declarations by keyword. A declaration ends where
+// its braces balance, or for indented languages at the next line indented
+// no deeper than the declaration.
+func heuristicDecls(lines []string, indented bool) []Span {
+	var out []Span
+	for i, l := range lines {
+		var kind, name string
+		if m := declRe.FindStringSubmatch(l); m != nil {
+			kind, name = strings.TrimSuffix(m[1], "*"), m[2]
+		} else if m := arrowRe.FindStringSubmatch(l); m != nil {
+			kind, name = "function", m[1]
+		} else {
+			continue
+		}
+		end := braceEnd(lines, i)
+		if indented {
+			end = indentEnd(lines, i)
+		}
+		out = append(out, Span{Kind: kind, Symbol: name, Start: i + 1, End: end + 1})
+	}
+	return out
+}
+
+// braceEnd returns the index of the line closing the block opened at or
+// after lines[i]. A declaration without a block ends on its own line.
+func braceEnd(lines []string, i int) int {
+	depth, opened := 0, false
+	for j := i; j < len(lines); j++ {
+		for _, c := range lines[j] {
+			switch c {
+			case '{':
+				depth++
+				opened = true
+			case '}':
+				depth--
+			}
+		}
+		if opened && depth <= 0 {
+			return j
+		}
+		if !opened && (strings.HasSuffix(strings.TrimSpace(lines[j]), ";") || j-i >= 5) {
+			return i
+		}
+	}
+	return i
+}
+
+// indentEnd returns the index of the last line indented deeper than
+// lines[i], skipping blank lines.
+func indentEnd(lines []string, i int) int {
+	base := indent(lines[i])
+	end := i
+	for j := i + 1; j < len(lines); j++ {
+		if strings.TrimSpace(lines[j]) == "" {
+			continue
+		}
+		if indent(lines[j]) <= base {
+			break
+		}
+		end = j
+	}
+	return end
+}
+
+func indent(l string) int {
+	return len(l) - len(strings.TrimLeft(l, " \t"))
+}
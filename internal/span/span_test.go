@@ -0,0 +1,113 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package span
+
+import (
+	"fmt"
+	"testing"
+
+	"go.devnw.com/canary/internal/token"
+)
+
+const goSrc = `// CANARY: REQ=CBIN-300; FEATURE="File"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+package a
+
+// CANARY: REQ=CBIN-301; FEATURE="Parse"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+
+// Parse parses.
+func Parse() int {
+	// CANARY: REQ=CBIN-302; FEATURE="Inner"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+	return 1
+}
+
+// CANARY: REQ=CBIN-303; FEATURE="Lines"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+// CANARY+: TEST=TestLines
+func (b *Blamer[T]) Lines() {}
+
+const (
+	// CANARY: REQ=CBIN-304; FEATURE="Exit"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+	ExitOK   = 0
+	ExitFail = 2
+)
+
+// CANARY: REQ=CBIN-305; FEATURE="Opts"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+type Options struct {
+	Root string
+}
+`
+
+const pySrc = `# CANARY: REQ=CBIN-310; FEATURE="Loader"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+class Loader:
+    # CANARY: REQ=CBIN-311; FEATURE="Load"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+    @cached
+    def load(self):
+        return 1
+
+    def other(self):
+        # CANARY: REQ=CBIN-312; FEATURE="Other"; ASPECT=Engine; STATUS=IMPL; UPDATED=2026-10-17
+        return 2
+
+x = 1
+`
+
+const tsSrc = `// CANARY: REQ=CBIN-320; FEATURE="Fetch"; ASPECT=API; STATUS=IMPL; UPDATED=2026-10-17
+export async function fetchUser(id: string) {
+  if (id) {
+    return 1
+  }
+}
+
+// CANARY: REQ=CBIN-321; FEATURE="Handler"; ASPECT=API; STATUS=IMPL; UPDATED=2026-10-17
+export const handler = async (req) => {
+  return req
+}
+`
+
+// TestCANARY_CBIN_165_Engine_CodeSpans verifies tokens are attached to the
+// following or enclosing declaration in Go, Python and TypeScript.
+func TestCANARY_CBIN_165_Engine_CodeSpans(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		want []string
+	}{
+		"a.go": {goSrc, []string{
+			"",
+			"func Parse 7-10",
+			"func Parse 7-10",
+			"method Blamer.Lines 14-14",
+			"const ExitOK 18-18",
+			"type Options 23-25",
+		}},
+		"a.py": {pySrc, []string{
+			"class Loader 2-10",
+			"def load 5-6",
+			"def other 8-10",
+		}},
+		"a.ts": {tsSrc, []string{
+			"function fetchUser 2-6",
+			"function handler 9-11",
+		}},
+	}
+	for path, tt := range tests {
+		toks, diags := token.Parse(path, []byte(tt.src), nil)
+		if len(diags) != 0 {
+			t.Fatalf("%s: %v", path, diags)
+		}
+		spans := Tokens(path, []byte(tt.src), toks)
+		if len(spans) != len(tt.want) {
+			t.Fatalf("%s: got %d spans, want %d", path, len(spans), len(tt.want))
+		}
+		for i, s := range spans {
+			got := ""
+			if s.Symbol != "" {
+				got = fmt.Sprintf("%s %s %d-%d", s.Kind, s.Symbol, s.Start, s.End)
+			}
+			if got != tt.want[i] {
+				t.Errorf("%s: %s: got %q, want %q", path, toks[i].Feature(), got, tt.want[i])
+			}
+		}
+	}
+}
@@ -144,7 +144,7 @@ func BenchmarkStatusChecking_FullDependency(b *testing.B) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source: "CBIN-147",
 		Target: "CBIN-146",
@@ -181,7 +181,7 @@ func BenchmarkStatusChecking_Batch(b *testing.B) {
 	}
 
 	tokenProvider := &MockTokenProvider{tokens: tokens}
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-170; FEATURE="SpecHeader"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_170_Engine_ParseHeader; OWNER=canary; UPDATED=2026-10-17
+package specs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.devnw.com/canary/internal/reqid"
+)
+
+// Header is the metadata at the top of a spec.md
+type Header struct {
+	Title   string // feature name from the first heading
+	Aspect  string // normalized; empty while the template placeholder remains
+	Created string // YYYY-MM-DD; empty while the template placeholder remains
+}
+
+// ReadHeader parses the header of the spec.md at path
+func ReadHeader(path string) (*Header, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read spec: %w", err)
+	}
+	h := ParseHeader(string(content))
+	return &h, nil
+}
+
+// ParseHeader extracts the title, aspect and creation date from spec
+// content. Parsing stops at the first section (##) heading.
+func ParseHeader(content string) Header {
+	var h Header
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "## "):
+			return h
+		case strings.HasPrefix(line, "# ") && h.Title == "":
+			h.Title = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(line, "# "), "Feature Specification:"))
+		case strings.HasPrefix(line, "**Aspect:**"):
+			if v := headerValue(line, "**Aspect:**"); reqid.ValidateAspect(v) == nil {
+				h.Aspect = reqid.NormalizeAspect(v)
+			}
+		case strings.HasPrefix(line, "**Created:**"):
+			if v := headerValue(line, "**Created:**"); v != "YYYY-MM-DD" {
+				h.Created = v
+			}
+		}
+	}
+	return h
+}
+
+func headerValue(line, label string) string {
+	return strings.TrimSpace(strings.TrimPrefix(line, label))
+}
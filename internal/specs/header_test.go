@@ -0,0 +1,55 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package specs
+
+import "testing"
+
+func TestCANARY_CBIN_170_Engine_ParseHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Header
+	}{
+		{
+			name: "filled in",
+			content: `<!-- CANARY: REQ=CBIN-Docs-115; FEATURE="SpecTemplate"; ASPECT=Docs; STATUS=IMPL -->
+# Feature Specification: User Auth
+
+**Requirement ID:** CBIN-API-105
+**Aspect:** api
+**Status:** STUB
+**Created:** 2026-10-01
+
+## Overview
+
+# Not the title
+**Aspect:** CLI
+`,
+			want: Header{Title: "User Auth", Aspect: "API", Created: "2026-10-01"},
+		},
+		{
+			name: "template placeholders",
+			content: `# Feature Specification: Parser
+**Aspect:** [API|CLI|Engine]
+**Created:** YYYY-MM-DD
+`,
+			want: Header{Title: "Parser"},
+		},
+		{
+			name:    "plain heading",
+			content: "# Parser\n\n## Dependencies\n",
+			want:    Header{Title: "Parser"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseHeader(tt.content); got != tt.want {
+				t.Errorf("ParseHeader() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -189,7 +189,7 @@ func TestIntegration_StatusChecking(t *testing.T) {
 	}
 
 	// Check dependencies
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	deps := graph.GetDependencies("CBIN-147")
 	statuses := checker.CheckAllDependencies(deps)
 
@@ -7,17 +7,20 @@ import (
 	"os"
 	"regexp"
 	"strings"
+
+	"go.devnw.com/canary/internal/reqid"
 )
 
 // CANARY: REQ=CBIN-147; FEATURE="DependencyParser"; ASPECT=Engine; STATUS=TESTED; TEST=TestParseDependencies_FullDependency,TestParseDependencies_PartialFeatures,TestParseDependencies_PartialAspect,TestParseDependencies_MixedTypes; UPDATED=2025-10-18
 
 var (
-	// Regex patterns for parsing dependency lines
+	// Regex patterns for parsing dependency lines; any requirement ID
+	// (reqid.Pattern) may stand in for CBIN-123
 	// Format: "- CBIN-123 (Description)" for full dependencies
 	// Format: "- CBIN-123:Feature1,Feature2 (Description)" for partial feature dependencies
 	// Format: "- CBIN-123:AspectName (Description)" for partial aspect dependencies
-	fullDependencyPattern    = regexp.MustCompile(`^-\s+(CBIN-\d+)\s*(?:\(([^)]+)\))?`)
-	partialDependencyPattern = regexp.MustCompile(`^-\s+(CBIN-\d+):([^(\s]+)\s*(?:\(([^)]+)\))?`)
+	fullDependencyPattern    = regexp.MustCompile(`^-\s+(` + reqid.Pattern + `)\s*(?:\(([^)]+)\))?`)
+	partialDependencyPattern = regexp.MustCompile(`^-\s+(` + reqid.Pattern + `):([^(\s]+)\s*(?:\(([^)]+)\))?`)
 )
 
 // ParseDependenciesFromFile reads a spec.md file and extracts all dependencies.
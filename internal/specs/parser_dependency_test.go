@@ -234,3 +234,24 @@ func TestParseDependencies_PreservesDescription(t *testing.T) {
 	assert.Contains(t, deps[0].Description, "This is a detailed description")
 	assert.Contains(t, deps[0].Description, "special chars!")
 }
+
+// CANARY: REQ=CBIN-147; FEATURE="DependencyParser"; ASPECT=Engine; STATUS=TESTED; TEST=TestParseDependencies_OtherKeys; UPDATED=2026-10-17
+func TestParseDependencies_OtherKeys(t *testing.T) {
+	specContent := `## Dependencies
+
+- ACME-001 (Accounts)
+- ACME-API-042:Export,Import
+- PROJ-1042:CLI
+`
+
+	deps, err := ParseDependencies("ACME-002", strings.NewReader(specContent))
+	require.NoError(t, err)
+	require.Len(t, deps, 3)
+
+	assert.Equal(t, "ACME-001", deps[0].Target)
+	assert.Equal(t, DependencyTypeFull, deps[0].Type)
+	assert.Equal(t, "ACME-API-042", deps[1].Target)
+	assert.Equal(t, []string{"Export", "Import"}, deps[1].RequiredFeatures)
+	assert.Equal(t, "PROJ-1042", deps[2].Target)
+	assert.Equal(t, "CLI", deps[2].RequiredAspect)
+}
@@ -3,6 +3,8 @@ package specs
 import (
 	"fmt"
 	"strings"
+
+	"go.devnw.com/canary/internal/lifecycle"
 )
 
 // CANARY: REQ=CBIN-147; FEATURE="StatusChecker"; ASPECT=Engine; STATUS=TESTED; TEST=TestCheckDependencyStatus_FullSatisfied,TestCheckDependencyStatus_FullBlocked,TestCheckDependencyStatus_PartialFeaturesSatisfied,TestCheckDependencyStatus_PartialFeaturesBlocked; UPDATED=2025-10-18
@@ -17,22 +19,27 @@ type TokenProvider interface {
 // StatusChecker checks whether dependencies are satisfied based on CANARY token status.
 type StatusChecker struct {
 	tokenProvider TokenProvider
+	lifecycle     *lifecycle.Lifecycle
 }
 
 // NewStatusChecker creates a new status checker with the given token provider.
-func NewStatusChecker(provider TokenProvider) *StatusChecker {
+// The lifecycle lc decides which statuses satisfy a dependency; nil is the
+// default lifecycle.
+func NewStatusChecker(provider TokenProvider, lc *lifecycle.Lifecycle) *StatusChecker {
 	return &StatusChecker{
 		tokenProvider: provider,
+		lifecycle:     lc,
 	}
 }
 
 // CheckDependency checks whether a single dependency is satisfied.
 // Satisfaction rules:
-// - Full: All features of target requirement must be TESTED or BENCHED
-// - PartialFeatures: All RequiredFeatures must be TESTED or BENCHED
-// - PartialAspect: All features of RequiredAspect must be TESTED or BENCHED
+// - Full: All features of target requirement must be in a satisfying state
+// - PartialFeatures: All RequiredFeatures must be in a satisfying state
+// - PartialAspect: All features of RequiredAspect must be in a satisfying state
 //
-// IMPL status is NOT sufficient - dependencies require tests.
+// The satisfying states come from the project lifecycle: TESTED and BENCHED
+// by default. IMPL status is NOT sufficient - dependencies require tests.
 func (sc *StatusChecker) CheckDependency(dep Dependency) DependencyStatus {
 	// Get all tokens for target requirement
 	tokens := sc.tokenProvider.GetTokensByReqID(dep.Target)
@@ -65,14 +72,14 @@ func (sc *StatusChecker) CheckDependency(dep Dependency) DependencyStatus {
 	}
 }
 
-// checkFullDependency verifies all features are TESTED or BENCHED.
+// checkFullDependency verifies all features are in a satisfying state.
 func (sc *StatusChecker) checkFullDependency(dep Dependency, tokens []TokenInfo) DependencyStatus {
 	var missingFeatures []string
 	allStatuses := make(map[string]string) // feature -> status
 
 	for _, token := range tokens {
 		allStatuses[token.Feature] = token.Status
-		if !isStatusSatisfied(token.Status) {
+		if !sc.isStatusSatisfied(token.Status, token.Aspect) {
 			missingFeatures = append(missingFeatures, token.Feature)
 		}
 	}
@@ -97,12 +104,14 @@ func (sc *StatusChecker) checkFullDependency(dep Dependency, tokens []TokenInfo)
 	}
 }
 
-// checkPartialFeaturesDependency verifies only the required features are TESTED or BENCHED.
+// checkPartialFeaturesDependency verifies only the required features are in a satisfying state.
 func (sc *StatusChecker) checkPartialFeaturesDependency(dep Dependency, tokens []TokenInfo) DependencyStatus {
 	// Build map of feature -> status
 	featureStatus := make(map[string]string)
+	aspects := make(map[string]string)
 	for _, token := range tokens {
 		featureStatus[token.Feature] = token.Status
+		aspects[token.Feature] = token.Aspect
 	}
 
 	var missingFeatures []string
@@ -112,7 +121,7 @@ func (sc *StatusChecker) checkPartialFeaturesDependency(dep Dependency, tokens [
 			missingFeatures = append(missingFeatures, requiredFeature)
 			continue
 		}
-		if !isStatusSatisfied(status) {
+		if !sc.isStatusSatisfied(status, aspects[requiredFeature]) {
 			missingFeatures = append(missingFeatures, requiredFeature)
 		}
 	}
@@ -137,7 +146,7 @@ func (sc *StatusChecker) checkPartialFeaturesDependency(dep Dependency, tokens [
 	}
 }
 
-// checkPartialAspectDependency verifies all features of the required aspect are TESTED or BENCHED.
+// checkPartialAspectDependency verifies all features of the required aspect are in a satisfying state.
 func (sc *StatusChecker) checkPartialAspectDependency(dep Dependency, tokens []TokenInfo) DependencyStatus {
 	var aspectTokens []TokenInfo
 	for _, token := range tokens {
@@ -158,7 +167,7 @@ func (sc *StatusChecker) checkPartialAspectDependency(dep Dependency, tokens []T
 
 	var missingFeatures []string
 	for _, token := range aspectTokens {
-		if !isStatusSatisfied(token.Status) {
+		if !sc.isStatusSatisfied(token.Status, token.Aspect) {
 			missingFeatures = append(missingFeatures, token.Feature)
 		}
 	}
@@ -226,7 +235,8 @@ func (sc *StatusChecker) FormatBlockingReport(deps []Dependency) string {
 }
 
 // isStatusSatisfied returns true if the status satisfies dependency requirements.
-// Only TESTED and BENCHED satisfy dependencies. IMPL is insufficient.
-func isStatusSatisfied(status string) bool {
-	return status == "TESTED" || status == "BENCHED"
+// The project lifecycle decides which statuses do; by default only TESTED and
+// BENCHED satisfy dependencies and IMPL is insufficient.
+func (sc *StatusChecker) isStatusSatisfied(status, aspect string) bool {
+	return sc.lifecycle.Satisfies(status, aspect)
 }
@@ -19,7 +19,7 @@ func TestCheckDependencyStatus_FullSatisfied(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source: "CBIN-147",
 		Target: "CBIN-146",
@@ -45,7 +45,7 @@ func TestCheckDependencyStatus_FullBlocked(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source: "CBIN-147",
 		Target: "CBIN-146",
@@ -71,7 +71,7 @@ func TestCheckDependencyStatus_PartialFeaturesSatisfied(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source:           "CBIN-147",
 		Target:           "CBIN-146",
@@ -96,7 +96,7 @@ func TestCheckDependencyStatus_PartialFeaturesBlocked(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source:           "CBIN-147",
 		Target:           "CBIN-146",
@@ -124,7 +124,7 @@ func TestCheckDependencyStatus_PartialAspectSatisfied(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source:         "CBIN-147",
 		Target:         "CBIN-129",
@@ -149,7 +149,7 @@ func TestCheckDependencyStatus_PartialAspectBlocked(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source:         "CBIN-147",
 		Target:         "CBIN-129",
@@ -169,7 +169,7 @@ func TestCheckDependencyStatus_MissingRequirement(t *testing.T) {
 		tokens: map[string][]MockToken{}, // No tokens for CBIN-999
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source: "CBIN-147",
 		Target: "CBIN-999",
@@ -193,7 +193,7 @@ func TestCheckDependencyStatus_BENCHEDAccepted(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	dep := Dependency{
 		Source: "CBIN-147",
 		Target: "CBIN-146",
@@ -219,7 +219,7 @@ func TestCheckAllDependencies(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	deps := []Dependency{
 		{Source: "CBIN-147", Target: "CBIN-146", Type: DependencyTypeFull},
 		{Source: "CBIN-147", Target: "CBIN-145", Type: DependencyTypeFull},
@@ -245,7 +245,7 @@ func TestGetBlockingDependencies(t *testing.T) {
 		},
 	}
 
-	checker := NewStatusChecker(tokenProvider)
+	checker := NewStatusChecker(tokenProvider, nil)
 	deps := []Dependency{
 		{Source: "CBIN-147", Target: "CBIN-146", Type: DependencyTypeFull},
 		{Source: "CBIN-147", Target: "CBIN-145", Type: DependencyTypeFull},
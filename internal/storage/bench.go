@@ -0,0 +1,125 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-167; FEATURE="BenchStore"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_167_Storage_RecordBench; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// BenchResult is one benchmark a token's BENCH= reference names, measured
+// at a commit
+type BenchResult struct {
+	ReqID       string
+	Feature     string
+	Bench       string // BENCH= reference
+	Name        string // benchmark as reported, e.g. BenchmarkParse/small
+	Package     string
+	CommitHash  string
+	ProjectID   string
+	Runs        int
+	NsPerOp     float64
+	Mem         bool // BytesPerOp and AllocsPerOp were reported
+	BytesPerOp  float64
+	AllocsPerOp float64
+	Source      string
+	RecordedAt  string
+}
+
+// RecordBenchResults stores results, replacing earlier results of the same
+// benchmark for the same token at the same commit.
+func (db *DB) RecordBenchResults(results []BenchResult) error {
+	tx, err := db.conn.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, r := range results {
+		if r.RecordedAt == "" {
+			r.RecordedAt = now
+		}
+		_, err := tx.Exec(`
+			INSERT INTO bench_results (req_id, feature, bench, name, package, commit_hash, project_id,
+				runs, ns_per_op, benchmem, bytes_per_op, allocs_per_op, source, recorded_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(req_id, feature, name, commit_hash, project_id) DO UPDATE SET
+				bench = excluded.bench,
+				package = excluded.package,
+				runs = excluded.runs,
+				ns_per_op = excluded.ns_per_op,
+				benchmem = excluded.benchmem,
+				bytes_per_op = excluded.bytes_per_op,
+				allocs_per_op = excluded.allocs_per_op,
+				source = excluded.source,
+				recorded_at = excluded.recorded_at
+		`, r.ReqID, r.Feature, r.Bench, r.Name, r.Package, r.CommitHash, r.ProjectID,
+			r.Runs, r.NsPerOp, r.Mem, r.BytesPerOp, r.AllocsPerOp, r.Source, r.RecordedAt)
+		if err != nil {
+			return fmt.Errorf("record benchmark %s: %w", r.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// BenchResults returns the results recorded for a project at commit,
+// sorted by requirement, feature and benchmark
+func (db *DB) BenchResults(projectID, commit string) ([]BenchResult, error) {
+	rows, err := db.conn.Query(`
+		SELECT req_id, feature, bench, name, COALESCE(package, ''), commit_hash, COALESCE(project_id, ''),
+			runs, ns_per_op, benchmem, bytes_per_op, allocs_per_op, COALESCE(source, ''), recorded_at
+		FROM bench_results
+		WHERE COALESCE(project_id, '') = ? AND commit_hash = ?
+		ORDER BY req_id, feature, name
+	`, projectID, commit)
+	if err != nil {
+		return nil, fmt.Errorf("query benchmark results: %w", err)
+	}
+	defer rows.Close()
+
+	var out []BenchResult
+	for rows.Next() {
+		var r BenchResult
+		err := rows.Scan(&r.ReqID, &r.Feature, &r.Bench, &r.Name, &r.Package, &r.CommitHash, &r.ProjectID,
+			&r.Runs, &r.NsPerOp, &r.Mem, &r.BytesPerOp, &r.AllocsPerOp, &r.Source, &r.RecordedAt)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+
+	return out, rows.Err()
+}
+
+// BenchCommits returns the commits with recorded results whose hash starts
+// with prefix, most recently recorded first. An empty prefix matches every
+// commit.
+func (db *DB) BenchCommits(projectID, prefix string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT commit_hash FROM bench_results
+		WHERE COALESCE(project_id, '') = ? AND substr(commit_hash, 1, length(?)) = ?
+		GROUP BY commit_hash
+		ORDER BY MAX(recorded_at) DESC
+	`, projectID, prefix, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("query benchmark commits: %w", err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+
+	return out, rows.Err()
+}
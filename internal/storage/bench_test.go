@@ -0,0 +1,49 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCANARY_CBIN_167_Storage_RecordBench(t *testing.T) {
+	db := openMigrated(t)
+
+	if err := db.RecordBenchResults([]BenchResult{
+		{ReqID: "CBIN-100", Feature: "Parse", Bench: "BenchmarkParse", Name: "BenchmarkParse", CommitHash: "aaaa1111", Runs: 1, NsPerOp: 900, RecordedAt: "2026-10-01T00:00:00Z"},
+		{ReqID: "CBIN-100", Feature: "Parse", Bench: "BenchmarkParse", Name: "BenchmarkParse", CommitHash: "bbbb2222", Runs: 1, NsPerOp: 1000, RecordedAt: "2026-10-02T00:00:00Z"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Ingesting the same commit again replaces its results
+	if err := db.RecordBenchResults([]BenchResult{
+		{ReqID: "CBIN-100", Feature: "Parse", Bench: "BenchmarkParse", Name: "BenchmarkParse", CommitHash: "bbbb2222", Runs: 3, NsPerOp: 1100, Mem: true, BytesPerOp: 64, AllocsPerOp: 2, Source: "bench.txt", RecordedAt: "2026-10-03T00:00:00Z"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := db.BenchResults("", "bbbb2222")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BenchResult{{ReqID: "CBIN-100", Feature: "Parse", Bench: "BenchmarkParse", Name: "BenchmarkParse", CommitHash: "bbbb2222",
+		Runs: 3, NsPerOp: 1100, Mem: true, BytesPerOp: 64, AllocsPerOp: 2, Source: "bench.txt", RecordedAt: "2026-10-03T00:00:00Z"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BenchResults:\n got %+v\nwant %+v", got, want)
+	}
+
+	commits, err := db.BenchCommits("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(commits, []string{"bbbb2222", "aaaa1111"}) {
+		t.Errorf("BenchCommits = %v", commits)
+	}
+	if commits, _ := db.BenchCommits("", "aaaa"); !reflect.DeepEqual(commits, []string{"aaaa1111"}) {
+		t.Errorf("BenchCommits(aaaa) = %v", commits)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-166; FEATURE="CoverageStore"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_166_Storage_RecordCoverage; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TokenCoverage is the line coverage of the code one token annotates
+type TokenCoverage struct {
+	ReqID      string
+	Feature    string
+	FilePath   string
+	LineNumber int
+	ProjectID  string
+	Covered    int
+	Total      int // instrumented lines
+	Source     string
+	RecordedAt string
+}
+
+// ReplaceCoverage replaces every coverage row of a project with rows. A
+// profile describes a whole test run, so coverage isn't merged across runs.
+func (db *DB) ReplaceCoverage(projectID string, rows []TokenCoverage) error {
+	return db.replaceTokenRows("token_coverage", "coverage", projectID, len(rows), func(tx *sqlx.Tx, i int, now string) error {
+		r := rows[i]
+		if r.RecordedAt == "" {
+			r.RecordedAt = now
+		}
+		_, err := tx.Exec(`
+			INSERT INTO token_coverage (req_id, feature, file_path, line_number, project_id,
+				covered_lines, total_lines, source, recorded_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(req_id, feature, file_path, line_number, project_id) DO UPDATE SET
+				covered_lines = excluded.covered_lines,
+				total_lines = excluded.total_lines,
+				source = excluded.source,
+				recorded_at = excluded.recorded_at
+		`, r.ReqID, r.Feature, r.FilePath, r.LineNumber, projectID, r.Covered, r.Total, r.Source, r.RecordedAt)
+		if err != nil {
+			return fmt.Errorf("record coverage %s/%s: %w", r.ReqID, r.Feature, err)
+		}
+		return nil
+	})
+}
+
+// ApplyCoverage sets CoveredLines and CoverableLines on every token with
+// recorded coverage. Tokens are matched on requirement, feature, file and
+// line, so tokens that moved since the profile was ingested get none.
+func (db *DB) ApplyCoverage(projectID string, tokens []*Token) error {
+	byToken, err := db.tokenValues(`
+		SELECT req_id, feature, file_path, line_number, covered_lines, total_lines
+		FROM token_coverage
+		WHERE COALESCE(project_id, '') = ?
+	`, "coverage", projectID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tokens {
+		if c, ok := byToken[keyOf(t)]; ok {
+			t.CoveredLines, t.CoverableLines = c[0], c[1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"testing"
+)
+
+func TestCANARY_CBIN_166_Storage_RecordCoverage(t *testing.T) {
+	db := openMigrated(t)
+
+	if err := db.ReplaceCoverage("", []TokenCoverage{
+		{ReqID: "CBIN-100", Feature: "A", FilePath: "a.go", LineNumber: 3, Covered: 1, Total: 4},
+		{ReqID: "CBIN-100", Feature: "B", FilePath: "a.go", LineNumber: 9, Covered: 2, Total: 2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// A new profile replaces the old one entirely
+	if err := db.ReplaceCoverage("", []TokenCoverage{
+		{ReqID: "CBIN-100", Feature: "A", FilePath: "a.go", LineNumber: 3, Covered: 3, Total: 4, Source: "cover.out"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	toks := []*Token{
+		{ReqID: "CBIN-100", Feature: "A", FilePath: "a.go", LineNumber: 3},
+		{ReqID: "CBIN-100", Feature: "B", FilePath: "a.go", LineNumber: 9},
+		{ReqID: "CBIN-100", Feature: "A", FilePath: "a.go", LineNumber: 4},
+	}
+	if err := db.ApplyCoverage("", toks); err != nil {
+		t.Fatal(err)
+	}
+	if toks[0].CoveredLines != 3 || toks[0].CoverableLines != 4 {
+		t.Errorf("A = %d/%d, want 3/4", toks[0].CoveredLines, toks[0].CoverableLines)
+	}
+	for _, tok := range toks[1:] {
+		if tok.CoverableLines != 0 {
+			t.Errorf("%s:%d has coverage %d/%d", tok.Feature, tok.LineNumber, tok.CoveredLines, tok.CoverableLines)
+		}
+	}
+}
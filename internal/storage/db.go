@@ -31,7 +31,7 @@ const (
 	DBSourceName    = "iofs"
 	DBURLProtocol   = "sqlite://"
 	MigrateAll      = "all"
-	LatestVersion   = 5 // Update this when adding new migrations
+	LatestVersion   = 15 // Update this when adding new migrations
 )
 
 var ErrDatabaseNotPopulated = errors.New("database not migrated")
@@ -0,0 +1,268 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-169; FEATURE="RequirementGraph"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_169_Storage_RequirementGraph; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.devnw.com/canary/internal/reqid"
+)
+
+// Relationship types of a requirement edge
+const (
+	EdgeDependsOn = "depends_on" // source needs target
+	EdgeBlocks    = "blocks"     // target needs source
+	EdgeRelatedTo = "related_to" // informational only
+)
+
+// Origins of a requirement edge
+const (
+	OriginToken = "token" // a DEPENDS_ON, BLOCKS or RELATED_TO token field
+	OriginSpec  = "spec"  // the Dependencies section of a spec.md
+)
+
+// RequirementEdge is one declared relationship between two requirements
+type RequirementEdge struct {
+	Source           string
+	Target           string
+	Type             string
+	RequiredFeatures []string // partial dependency on these features only
+	RequiredAspect   string   // partial dependency on one aspect only
+	Description      string
+	Origin           string
+	OriginPath       string
+	OriginLine       int
+	ProjectID        string
+
+	// Depth is the number of dependency hops from the requirement a graph
+	// query started at; 1 for direct edges. Unset for stored edges.
+	Depth int
+}
+
+// createEdgesTable mirrors migration 000012 for databases created by
+// ensureTokensTable.
+const createEdgesTable = `
+	CREATE TABLE IF NOT EXISTS requirement_edges (
+		source TEXT NOT NULL,
+		target TEXT NOT NULL,
+		type TEXT NOT NULL,
+		required_features TEXT NOT NULL DEFAULT '',
+		required_aspect TEXT NOT NULL DEFAULT '',
+		description TEXT NOT NULL DEFAULT '',
+		origin TEXT NOT NULL,
+		origin_path TEXT NOT NULL,
+		origin_line INTEGER NOT NULL DEFAULT 0,
+		project_id TEXT DEFAULT '',
+
+		PRIMARY KEY (source, target, type, required_features, required_aspect, origin, origin_path, origin_line, project_id)
+	)
+`
+
+// TokenEdges returns the relationships declared by a token's DEPENDS_ON,
+// BLOCKS and RELATED_TO fields. Each is a comma-separated list of
+// requirement IDs; a DEPENDS_ON entry may narrow the dependency to one
+// feature or aspect as REQ:Name, like a spec.md partial dependency.
+func TokenEdges(t *Token) []RequirementEdge {
+	var edges []RequirementEdge
+	add := func(typ, list string) {
+		for _, ref := range strings.Split(list, ",") {
+			ref = strings.TrimSpace(ref)
+			if ref == "" {
+				continue
+			}
+			e := RequirementEdge{
+				Source: t.ReqID, Type: typ,
+				Origin: OriginToken, OriginPath: t.FilePath, OriginLine: t.LineNumber,
+				ProjectID: t.ProjectID,
+			}
+			target, name, partial := strings.Cut(ref, ":")
+			e.Target = strings.TrimSpace(target)
+			if name = strings.TrimSpace(name); partial && name != "" && typ == EdgeDependsOn {
+				if reqid.ValidateAspect(name) == nil {
+					e.RequiredAspect = reqid.NormalizeAspect(name)
+				} else {
+					e.RequiredFeatures = []string{name}
+				}
+			}
+			edges = append(edges, e)
+		}
+	}
+	add(EdgeDependsOn, t.DependsOn)
+	add(EdgeBlocks, t.Blocks)
+	add(EdgeRelatedTo, t.RelatedTo)
+
+	return edges
+}
+
+const insertEdgeQuery = `
+	INSERT OR IGNORE INTO requirement_edges (
+		source, target, type, required_features, required_aspect, description,
+		origin, origin_path, origin_line, project_id
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+func insertEdges(ex sqlx.Execer, edges []RequirementEdge) error {
+	for _, e := range edges {
+		_, err := ex.Exec(insertEdgeQuery,
+			e.Source, e.Target, e.Type, strings.Join(e.RequiredFeatures, ","), e.RequiredAspect, e.Description,
+			e.Origin, e.OriginPath, e.OriginLine, e.ProjectID)
+		if err != nil {
+			return fmt.Errorf("store edge %s -> %s: %w", e.Source, e.Target, err)
+		}
+	}
+	return nil
+}
+
+// replaceTokenEdges replaces the edges declared by the token at t's location
+func replaceTokenEdges(ex sqlx.Execer, t *Token) error {
+	_, err := ex.Exec(`
+		DELETE FROM requirement_edges
+		WHERE origin = ? AND origin_path = ? AND origin_line = ? AND COALESCE(project_id, '') = ?
+	`, OriginToken, t.FilePath, t.LineNumber, t.ProjectID)
+	if err != nil {
+		return fmt.Errorf("delete edges for %s:%d: %w", t.FilePath, t.LineNumber, err)
+	}
+	return insertEdges(ex, TokenEdges(t))
+}
+
+// deleteFileEdges removes the edges declared in path, by tokens when origin
+// is OriginToken, by a spec for OriginSpec, or by either when origin is empty
+func deleteFileEdges(ex sqlx.Execer, path, projectID, origin string) error {
+	_, err := ex.Exec(`
+		DELETE FROM requirement_edges
+		WHERE origin_path = ? AND COALESCE(project_id, '') = ? AND (? = '' OR origin = ?)
+	`, path, projectID, origin, origin)
+	if err != nil {
+		return fmt.Errorf("delete edges for %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReplaceSpecEdges replaces the edges declared by the spec at path with
+// edges
+func (t *IndexTx) ReplaceSpecEdges(path, projectID string, edges []RequirementEdge) error {
+	if err := deleteFileEdges(t.tx, path, projectID, OriginSpec); err != nil {
+		return err
+	}
+	for i := range edges {
+		edges[i].Origin, edges[i].OriginPath, edges[i].ProjectID = OriginSpec, path, projectID
+	}
+	return insertEdges(t.tx, edges)
+}
+
+// dependencyEdges is a CTE of every dependency as source needs target: a
+// DEPENDS_ON as declared and a BLOCKS reversed. Edges declared more than
+// once, e.g. by a token and a spec, appear once.
+const dependencyEdges = `
+	dependency(source, target, required_features, required_aspect, description) AS (
+		SELECT source, target, required_features, required_aspect, MAX(description)
+		FROM (
+			SELECT source, target, required_features, required_aspect, description
+			FROM requirement_edges
+			WHERE type = 'depends_on' AND COALESCE(project_id, '') = :project
+			UNION ALL
+			SELECT target, source, '', '', description
+			FROM requirement_edges
+			WHERE type = 'blocks' AND COALESCE(project_id, '') = :project
+		)
+		GROUP BY source, target, required_features, required_aspect
+	)
+`
+
+// Dependencies returns every requirement reqID needs, directly or through
+// other requirements, as dependency edges with Depth set. Each edge is
+// reported once, at the shortest depth it is reached; cycles end the walk.
+func (db *DB) Dependencies(projectID, reqID string) ([]RequirementEdge, error) {
+	return db.walkDependencies(projectID, reqID, `
+		SELECT d.source, d.target, d.required_features, d.required_aspect, d.description,
+			1, '/' || d.source || '/' || d.target || '/'
+		FROM dependency d WHERE d.source = :req
+		UNION ALL
+		SELECT d.source, d.target, d.required_features, d.required_aspect, d.description,
+			w.depth + 1, w.path || d.target || '/'
+		FROM dependency d JOIN walk w ON d.source = w.target
+		WHERE instr(w.path, '/' || d.source || '/' || d.target || '/') = 0
+	`)
+}
+
+// Dependents returns every requirement that needs reqID, directly or
+// through other requirements, as dependency edges with Depth set.
+func (db *DB) Dependents(projectID, reqID string) ([]RequirementEdge, error) {
+	return db.walkDependencies(projectID, reqID, `
+		SELECT d.source, d.target, d.required_features, d.required_aspect, d.description,
+			1, '/' || d.target || '/' || d.source || '/'
+		FROM dependency d WHERE d.target = :req
+		UNION ALL
+		SELECT d.source, d.target, d.required_features, d.required_aspect, d.description,
+			w.depth + 1, w.path || d.source || '/'
+		FROM dependency d JOIN walk w ON d.target = w.source
+		WHERE instr(w.path, '/' || d.target || '/' || d.source || '/') = 0
+	`)
+}
+
+// walkDependencies runs the recursive walk step over the dependency CTE
+func (db *DB) walkDependencies(projectID, reqID, step string) ([]RequirementEdge, error) {
+	rows, err := db.conn.NamedQuery(`
+		WITH RECURSIVE `+dependencyEdges+`,
+		walk(source, target, required_features, required_aspect, description, depth, path) AS (
+			`+step+`
+		)
+		SELECT source, target, required_features, required_aspect, description, MIN(depth)
+		FROM walk
+		GROUP BY source, target, required_features, required_aspect
+		ORDER BY MIN(depth), source, target
+	`, map[string]any{"project": projectID, "req": reqID})
+	if err != nil {
+		return nil, fmt.Errorf("query dependencies of %s: %w", reqID, err)
+	}
+	defer rows.Close()
+
+	return scanDependencies(rows, projectID)
+}
+
+// DependencyEdges returns every dependency of a project as source needs
+// target, sorted by source and target
+func (db *DB) DependencyEdges(projectID string) ([]RequirementEdge, error) {
+	rows, err := db.conn.NamedQuery(`
+		WITH `+dependencyEdges+`
+		SELECT source, target, required_features, required_aspect, description, 0
+		FROM dependency
+		ORDER BY source, target
+	`, map[string]any{"project": projectID})
+	if err != nil {
+		return nil, fmt.Errorf("query dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDependencies(rows, projectID)
+}
+
+// scanDependencies reads dependency rows of source, target, required
+// features and aspect, description and depth
+func scanDependencies(rows *sqlx.Rows, projectID string) ([]RequirementEdge, error) {
+	var out []RequirementEdge
+	for rows.Next() {
+		e := RequirementEdge{Type: EdgeDependsOn, ProjectID: projectID}
+		var features string
+		if err := rows.Scan(&e.Source, &e.Target, &features, &e.RequiredAspect, &e.Description, &e.Depth); err != nil {
+			return nil, err
+		}
+		e.RequiredFeatures = splitFeatures(features)
+		out = append(out, e)
+	}
+
+	return out, rows.Err()
+}
+
+func splitFeatures(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
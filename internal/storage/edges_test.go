@@ -0,0 +1,112 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCANARY_CBIN_169_Storage_RequirementGraph(t *testing.T) {
+	db := openMigrated(t)
+
+	// CBIN-100 needs CBIN-101 and CBIN-102's Storage aspect; CBIN-102 needs
+	// CBIN-103, which CBIN-104 blocks. CBIN-101 and CBIN-100 form a cycle.
+	for _, tok := range []*Token{
+		{ReqID: "CBIN-100", Feature: "A", Aspect: "API", Status: "STUB", FilePath: "a.go", LineNumber: 1,
+			DependsOn: "CBIN-101, CBIN-102:Storage", RelatedTo: "CBIN-109"},
+		{ReqID: "CBIN-101", Feature: "B", Aspect: "API", Status: "STUB", FilePath: "b.go", LineNumber: 1,
+			DependsOn: "CBIN-100"},
+		{ReqID: "CBIN-104", Feature: "D", Aspect: "API", Status: "STUB", FilePath: "d.go", LineNumber: 1,
+			Blocks: "CBIN-103"},
+	} {
+		if err := db.UpsertToken(tok); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tx, err := db.BeginIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tx.ReplaceSpecEdges(".canary/specs/CBIN-102-x/spec.md", "", []RequirementEdge{
+		{Source: "CBIN-102", Target: "CBIN-103", Type: EdgeDependsOn, Description: "parser"},
+		{Source: "CBIN-102", Target: "CBIN-105", Type: EdgeDependsOn, RequiredFeatures: []string{"X", "Y"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	type hop struct {
+		Source, Target string
+		Depth          int
+	}
+	hops := func(edges []RequirementEdge) []hop {
+		var out []hop
+		for _, e := range edges {
+			out = append(out, hop{e.Source, e.Target, e.Depth})
+		}
+		return out
+	}
+
+	deps, err := db.Dependencies("", "CBIN-100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []hop{
+		{"CBIN-100", "CBIN-101", 1},
+		{"CBIN-100", "CBIN-102", 1},
+		{"CBIN-101", "CBIN-100", 2},
+		{"CBIN-102", "CBIN-103", 2},
+		{"CBIN-102", "CBIN-105", 2},
+		{"CBIN-103", "CBIN-104", 3},
+	}
+	if got := hops(deps); !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependencies = %v, want %v", got, want)
+	}
+	if deps[1].RequiredAspect != "Storage" {
+		t.Errorf("CBIN-102 dependency aspect = %q, want Storage", deps[1].RequiredAspect)
+	}
+	if !reflect.DeepEqual(deps[4].RequiredFeatures, []string{"X", "Y"}) {
+		t.Errorf("CBIN-105 dependency features = %v", deps[4].RequiredFeatures)
+	}
+
+	dependents, err := db.Dependents("", "CBIN-103")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []hop{
+		{"CBIN-102", "CBIN-103", 1},
+		{"CBIN-100", "CBIN-102", 2},
+		{"CBIN-101", "CBIN-100", 3},
+		{"CBIN-100", "CBIN-101", 4},
+	}
+	if got := hops(dependents); !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependents = %v, want %v", got, want)
+	}
+
+	// Editing a token replaces its edges; RELATED_TO never counts as a
+	// dependency
+	if err := db.UpsertToken(&Token{ReqID: "CBIN-100", Feature: "A", Aspect: "API", Status: "STUB",
+		FilePath: "a.go", LineNumber: 1, RelatedTo: "CBIN-101"}); err != nil {
+		t.Fatal(err)
+	}
+	all, err := db.DependencyEdges("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []hop{
+		{"CBIN-101", "CBIN-100", 0},
+		{"CBIN-102", "CBIN-103", 0},
+		{"CBIN-102", "CBIN-105", 0},
+		{"CBIN-103", "CBIN-104", 0},
+	}
+	if got := hops(all); !reflect.DeepEqual(got, want) {
+		t.Errorf("DependencyEdges = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-172; FEATURE="TokenEvents"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_172_Storage_TokenEvents; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Kinds of token events
+const (
+	EventAppear    = "appear"    // a token was added
+	EventDisappear = "disappear" // a token was removed
+	EventMove      = "move"      // a token changed file or line
+	EventStatus    = "status"    // STATUS changed
+	EventOwner     = "owner"     // OWNER changed
+	EventPriority  = "priority"  // PRIORITY changed
+)
+
+// TokenEvent is one change to a token seen by an index pass
+type TokenEvent struct {
+	ID         int64
+	ReqID      string
+	Feature    string
+	Aspect     string
+	Kind       string
+	OldValue   string // previous status, owner, priority or file:line
+	NewValue   string // new status, owner, priority or file:line
+	FilePath   string // where the token is, or was last seen
+	LineNumber int
+	CommitHash string
+	Branch     string
+	RecordedAt string
+	ProjectID  string
+}
+
+// createTokenEventsTable mirrors migration 000015 for databases created by
+// ensureTokensTable.
+const createTokenEventsTable = `
+	CREATE TABLE IF NOT EXISTS token_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		req_id TEXT NOT NULL,
+		feature TEXT NOT NULL,
+		aspect TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		old_value TEXT NOT NULL DEFAULT '',
+		new_value TEXT NOT NULL DEFAULT '',
+		file_path TEXT NOT NULL,
+		line_number INTEGER NOT NULL,
+		commit_hash TEXT NOT NULL DEFAULT '',
+		branch TEXT NOT NULL DEFAULT '',
+		recorded_at TEXT NOT NULL,
+		project_id TEXT NOT NULL DEFAULT ''
+	)
+`
+
+// RecordEvents appends an event to token_events for every token the pass
+// added, removed, moved, or changed the status, owner or priority of, and
+// returns them. Call it once, after every file of the pass is applied.
+func (t *IndexTx) RecordEvents(commitHash, branch, recordedAt string) ([]TokenEvent, error) {
+	var events []TokenEvent
+	for _, p := range t.matched {
+		events = append(events, pairEvents(p)...)
+	}
+	moved, added, removed := t.crossFileMoves()
+	for _, p := range moved {
+		events = append(events, pairEvents(p)...)
+	}
+	for _, tok := range added {
+		events = append(events, newEvent(tok, EventAppear, "", tok.Status))
+	}
+	for _, tok := range removed {
+		events = append(events, newEvent(tok, EventDisappear, tok.Status, ""))
+	}
+
+	for i := range events {
+		e := &events[i]
+		e.CommitHash, e.Branch, e.RecordedAt = commitHash, branch, recordedAt
+		res, err := t.tx.Exec(`
+			INSERT INTO token_events (req_id, feature, aspect, kind, old_value, new_value,
+				file_path, line_number, commit_hash, branch, recorded_at, project_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, e.ReqID, e.Feature, e.Aspect, e.Kind, e.OldValue, e.NewValue,
+			e.FilePath, e.LineNumber, e.CommitHash, e.Branch, e.RecordedAt, e.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("record %s event for %s/%s: %w", e.Kind, e.ReqID, e.Feature, err)
+		}
+		if e.ID, err = res.LastInsertId(); err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// pairEvents returns the events between two versions of a token
+func pairEvents(p tokenPair) []TokenEvent {
+	b, a := p.before, p.after
+
+	var events []TokenEvent
+	if b.FilePath != a.FilePath || b.LineNumber != a.LineNumber {
+		events = append(events, newEvent(a, EventMove,
+			fmt.Sprintf("%s:%d", b.FilePath, b.LineNumber), fmt.Sprintf("%s:%d", a.FilePath, a.LineNumber)))
+	}
+	if b.Status != a.Status {
+		events = append(events, newEvent(a, EventStatus, b.Status, a.Status))
+	}
+	if b.Owner != a.Owner {
+		events = append(events, newEvent(a, EventOwner, b.Owner, a.Owner))
+	}
+	if b.Priority != a.Priority {
+		events = append(events, newEvent(a, EventPriority, strconv.Itoa(b.Priority), strconv.Itoa(a.Priority)))
+	}
+
+	return events
+}
+
+func newEvent(tok *Token, kind, oldValue, newValue string) TokenEvent {
+	return TokenEvent{
+		ReqID:      tok.ReqID,
+		Feature:    tok.Feature,
+		Aspect:     tok.Aspect,
+		Kind:       kind,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		FilePath:   tok.FilePath,
+		LineNumber: tok.LineNumber,
+		ProjectID:  tok.ProjectID,
+	}
+}
+
+// GetTokenEvents returns the recorded events of reqID's tokens, oldest
+// first
+func (db *DB) GetTokenEvents(projectID, reqID string) ([]TokenEvent, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, req_id, feature, aspect, kind, old_value, new_value, file_path, line_number,
+			commit_hash, branch, recorded_at, project_id
+		FROM token_events
+		WHERE req_id = ? AND project_id = ?
+		ORDER BY id
+	`, reqID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query token events for %s: %w", reqID, err)
+	}
+	defer rows.Close()
+
+	var events []TokenEvent
+	for rows.Next() {
+		var e TokenEvent
+		if err := rows.Scan(&e.ID, &e.ReqID, &e.Feature, &e.Aspect, &e.Kind, &e.OldValue, &e.NewValue,
+			&e.FilePath, &e.LineNumber, &e.CommitHash, &e.Branch, &e.RecordedAt, &e.ProjectID); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCANARY_CBIN_172_Storage_TokenEvents(t *testing.T) {
+	db := openMigrated(t)
+
+	pass := func(commit string, apply func(tx *IndexTx)) []TokenEvent {
+		t.Helper()
+		tx, err := db.BeginIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		apply(tx)
+		events, err := tx.RecordEvents(commit, "main", "2026-10-17T00:00:00Z")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		return events
+	}
+	replace := func(tx *IndexTx, path string, toks ...*Token) {
+		t.Helper()
+		if _, err := tx.ReplaceFile(&IndexedFile{Path: path, ContentHash: path, IndexedAt: "now"}, toks); err != nil {
+			t.Fatal(err)
+		}
+	}
+	token := func(feature, status, owner string, priority, line int) *Token {
+		tok := fileToken("CBIN-200", feature, line, fmt.Sprint(feature, status, owner, priority))
+		tok.Status, tok.Owner, tok.Priority = status, owner, priority
+		return tok
+	}
+	summary := func(events []TokenEvent) []string {
+		var out []string
+		for _, e := range events {
+			out = append(out, fmt.Sprintf("%s %s %s->%s", e.Feature, e.Kind, e.OldValue, e.NewValue))
+		}
+		return out
+	}
+
+	events := pass("c1", func(tx *IndexTx) {
+		replace(tx, "a.go", token("Parse", "IMPL", "ana", 5, 1), token("Lex", "IMPL", "", 5, 9))
+	})
+	assertEvents(t, "first pass", summary(events), "Parse appear ->IMPL", "Lex appear ->IMPL")
+
+	// Unchanged tokens record nothing
+	if events := pass("c2", func(tx *IndexTx) {
+		replace(tx, "a.go", token("Parse", "IMPL", "ana", 5, 1), token("Lex", "IMPL", "", 5, 9))
+	}); len(events) != 0 {
+		t.Errorf("unchanged pass recorded %v", summary(events))
+	}
+
+	events = pass("c3", func(tx *IndexTx) {
+		replace(tx, "a.go", token("Parse", "TESTED", "bo", 2, 3))
+		replace(tx, "lex.go", token("Lex", "IMPL", "", 5, 1))
+	})
+	assertEvents(t, "edit pass", summary(events),
+		"Parse move a.go:1->a.go:3", "Parse status IMPL->TESTED", "Parse owner ana->bo", "Parse priority 5->2",
+		"Lex move a.go:9->lex.go:1")
+
+	events = pass("c4", func(tx *IndexTx) {
+		if _, err := tx.RemoveFile("lex.go", ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assertEvents(t, "removal pass", summary(events), "Lex disappear IMPL->")
+
+	history, err := db.GetTokenEvents("", "CBIN-200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 8 {
+		t.Fatalf("history = %v, want 8 events", summary(history))
+	}
+	if e := history[2]; e.CommitHash != "c3" || e.Branch != "main" || e.FilePath != "a.go" || e.LineNumber != 3 {
+		t.Errorf("history[2] = %+v", e)
+	}
+	if e := history[7]; e.Kind != EventDisappear || e.FilePath != "lex.go" || e.LineNumber != 1 {
+		t.Errorf("history[7] = %+v", e)
+	}
+
+	if _, err := db.conn.Exec(`DELETE FROM token_events`); err == nil {
+		t.Error("deleting token events succeeded, want the table to be append-only")
+	}
+}
+
+func assertEvents(t *testing.T, name string, got []string, want ...string) {
+	t.Helper()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("%s events = %q, want %q", name, got, want)
+	}
+}
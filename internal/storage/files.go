@@ -0,0 +1,413 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-152; FEATURE="IncrementalIndex"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_152_Storage_ReplaceFileTokens; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// indexedRows restricts a tokens query to rows written by the indexer. Rows
+// created directly (e.g. by `canary bug create`) have no raw token and are
+// never replaced or pruned by an index pass.
+const indexedRows = `raw_token <> ''`
+
+// IndexedFile records the state of a file the last time it was indexed
+type IndexedFile struct {
+	Path        string
+	ProjectID   string
+	ContentHash string
+	ModTime     int64 // Unix nanoseconds
+	Size        int64
+	TokenCount  int
+	IndexedAt   string
+}
+
+// TokenMove describes a token that kept its requirement and feature but
+// changed location
+type TokenMove struct {
+	ReqID    string
+	Feature  string
+	FromFile string
+	FromLine int
+	ToFile   string
+	ToLine   int
+}
+
+// TokenChanges counts token differences produced by an index pass
+type TokenChanges struct {
+	Added   int
+	Changed int
+	Moved   int
+	Removed int
+
+	Moves []TokenMove
+}
+
+// GetIndexedFiles returns every indexed file for a project keyed by path
+func (db *DB) GetIndexedFiles(projectID string) (map[string]*IndexedFile, error) {
+	rows, err := db.conn.Query(`
+		SELECT path, COALESCE(project_id, ''), content_hash, mtime, size, token_count, indexed_at
+		FROM indexed_files
+		WHERE COALESCE(project_id, '') = ?
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query indexed files: %w", err)
+	}
+	defer rows.Close()
+
+	files := map[string]*IndexedFile{}
+	for rows.Next() {
+		f := &IndexedFile{}
+		if err := rows.Scan(&f.Path, &f.ProjectID, &f.ContentHash, &f.ModTime, &f.Size, &f.TokenCount, &f.IndexedAt); err != nil {
+			return nil, err
+		}
+		files[f.Path] = f
+	}
+
+	return files, rows.Err()
+}
+
+// CountByStatus returns the number of tokens in each status for a project
+func (db *DB) CountByStatus(projectID string) (map[string]int, error) {
+	rows, err := db.conn.Query(`
+		SELECT status, COUNT(*) FROM tokens
+		WHERE COALESCE(project_id, '') = ?
+		GROUP BY status
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("count tokens by status: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, err
+		}
+		counts[status] = n
+	}
+
+	return counts, rows.Err()
+}
+
+// IndexTx applies the changes of one index pass atomically
+type IndexTx struct {
+	tx *sqlx.Tx
+
+	changes TokenChanges
+	// Unmatched tokens across files, paired into moves by Changes
+	added, removed []*Token
+	// Tokens matched within a file that moved or changed, for Events
+	matched []tokenPair
+}
+
+// tokenPair is one token before and after an index pass
+type tokenPair struct {
+	before, after *Token
+}
+
+// BeginIndex starts a transaction for an index pass
+func (db *DB) BeginIndex() (*IndexTx, error) {
+	if err := db.ensureTokensTable(); err != nil {
+		return nil, fmt.Errorf("ensure tokens table: %w", err)
+	}
+
+	tx, err := db.conn.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("begin index transaction: %w", err)
+	}
+
+	return &IndexTx{tx: tx}, nil
+}
+
+// Commit commits the index pass
+func (t *IndexTx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback abandons the index pass
+func (t *IndexTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// Changes returns the token changes of the whole pass. A token removed from
+// one file and added to another with the same requirement and feature is
+// reported as a move.
+func (t *IndexTx) Changes() TokenChanges {
+	c := t.changes
+	c.Moves = append([]TokenMove(nil), c.Moves...)
+
+	moved, added, removed := t.crossFileMoves()
+	for _, m := range moved {
+		c.Moves = append(c.Moves, TokenMove{
+			ReqID: m.after.ReqID, Feature: m.after.Feature,
+			FromFile: m.before.FilePath, FromLine: m.before.LineNumber,
+			ToFile: m.after.FilePath, ToLine: m.after.LineNumber,
+		})
+	}
+	c.Moved += len(moved)
+	c.Added = len(added)
+	c.Removed = len(removed)
+
+	return c
+}
+
+// crossFileMoves pairs tokens removed from one file and added to another
+// with the same requirement and feature. The tokens left over were really
+// added or removed.
+func (t *IndexTx) crossFileMoves() (moved []tokenPair, added, removed []*Token) {
+	removed = append([]*Token(nil), t.removed...)
+	for _, a := range t.added {
+		found := false
+		for i, r := range removed {
+			if r.ReqID != a.ReqID || r.Feature != a.Feature {
+				continue
+			}
+			moved = append(moved, tokenPair{before: r, after: a})
+			removed = append(removed[:i], removed[i+1:]...)
+			found = true
+			break
+		}
+		if !found {
+			added = append(added, a)
+		}
+	}
+
+	return moved, added, removed
+}
+
+// ReplaceFile replaces every indexed token stored for file with tokens and
+// records the file's new hash. It reports how the file's tokens changed.
+func (t *IndexTx) ReplaceFile(file *IndexedFile, tokens []*Token) (TokenChanges, error) {
+	old, err := t.fileTokens(file.Path, file.ProjectID)
+	if err != nil {
+		return TokenChanges{}, err
+	}
+
+	if err := t.deleteFileTokens(file.Path, file.ProjectID); err != nil {
+		return TokenChanges{}, err
+	}
+
+	for _, tok := range tokens {
+		tok.FilePath = file.Path
+		tok.ProjectID = file.ProjectID
+		if _, err := t.tx.Exec(upsertTokenQuery, tokenArgs(tok)...); err != nil {
+			return TokenChanges{}, fmt.Errorf("store token %s/%s: %w", tok.ReqID, tok.Feature, err)
+		}
+		if err := insertEdges(t.tx, TokenEdges(tok)); err != nil {
+			return TokenChanges{}, err
+		}
+	}
+
+	file.TokenCount = len(tokens)
+	if err := t.TouchFile(file); err != nil {
+		return TokenChanges{}, err
+	}
+
+	c, matched, added, removed := diffTokens(old, tokens)
+	t.record(c, matched, added, removed)
+
+	return c, nil
+}
+
+// TouchFile records file's hash, size and mtime without touching its tokens
+func (t *IndexTx) TouchFile(file *IndexedFile) error {
+	_, err := t.tx.Exec(`
+		INSERT INTO indexed_files (path, project_id, content_hash, mtime, size, token_count, indexed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(path, project_id) DO UPDATE SET
+			content_hash = excluded.content_hash,
+			mtime = excluded.mtime,
+			size = excluded.size,
+			token_count = excluded.token_count,
+			indexed_at = excluded.indexed_at
+	`, file.Path, file.ProjectID, file.ContentHash, file.ModTime, file.Size, file.TokenCount, file.IndexedAt)
+	if err != nil {
+		return fmt.Errorf("record indexed file %s: %w", file.Path, err)
+	}
+
+	return nil
+}
+
+// RemoveFile deletes a file's indexed tokens and index record, returning the
+// number of tokens removed
+func (t *IndexTx) RemoveFile(path, projectID string) (int, error) {
+	old, err := t.fileTokens(path, projectID)
+	if err != nil {
+		return 0, err
+	}
+	if err := t.deleteFileTokens(path, projectID); err != nil {
+		return 0, err
+	}
+	if err := deleteFileEdges(t.tx, path, projectID, ""); err != nil {
+		return 0, err
+	}
+	if _, err := t.tx.Exec(`DELETE FROM indexed_files WHERE path = ? AND COALESCE(project_id, '') = ?`, path, projectID); err != nil {
+		return 0, fmt.Errorf("delete indexed file %s: %w", path, err)
+	}
+
+	t.record(TokenChanges{Removed: len(old)}, nil, nil, old)
+
+	return len(old), nil
+}
+
+// PruneUnseen removes indexed tokens and file records whose path is not in
+// seen. It is used by reconciliation to drop rows left behind by older
+// indexers, renamed paths and now-ignored files. It returns the number of
+// tokens removed.
+func (t *IndexTx) PruneUnseen(seen map[string]bool, projectID string) (int, error) {
+	var paths []string
+	err := t.tx.Select(&paths, `
+		SELECT DISTINCT file_path FROM tokens
+		WHERE `+indexedRows+` AND COALESCE(project_id, '') = ?
+		UNION
+		SELECT path FROM indexed_files WHERE COALESCE(project_id, '') = ?
+	`, projectID, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("query indexed paths: %w", err)
+	}
+
+	total := 0
+	for _, p := range paths {
+		if seen[p] {
+			continue
+		}
+		n, err := t.RemoveFile(p, projectID)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+// record accumulates per-file changes into the pass totals
+func (t *IndexTx) record(c TokenChanges, matched []tokenPair, added, removed []*Token) {
+	t.changes.Changed += c.Changed
+	t.changes.Moved += c.Moved
+	t.changes.Moves = append(t.changes.Moves, c.Moves...)
+	t.matched = append(t.matched, matched...)
+	t.added = append(t.added, added...)
+	t.removed = append(t.removed, removed...)
+}
+
+func (t *IndexTx) deleteFileTokens(path, projectID string) error {
+	_, err := t.tx.Exec(`DELETE FROM tokens WHERE file_path = ? AND COALESCE(project_id, '') = ? AND `+indexedRows,
+		path, projectID)
+	if err != nil {
+		return fmt.Errorf("delete tokens for %s: %w", path, err)
+	}
+	return deleteFileEdges(t.tx, path, projectID, OriginToken)
+}
+
+// fileTokens loads the identity, content and tracked fields of the indexed
+// tokens for path
+func (t *IndexTx) fileTokens(path, projectID string) ([]*Token, error) {
+	rows, err := t.tx.Query(`
+		SELECT req_id, feature, aspect, file_path, line_number, raw_token,
+			status, COALESCE(owner, ''), COALESCE(priority, 0)
+		FROM tokens
+		WHERE file_path = ? AND COALESCE(project_id, '') = ? AND `+indexedRows+`
+		ORDER BY line_number
+	`, path, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query tokens for %s: %w", path, err)
+	}
+	defer rows.Close()
+
+	var tokens []*Token
+	for rows.Next() {
+		tok := &Token{ProjectID: projectID}
+		if err := rows.Scan(&tok.ReqID, &tok.Feature, &tok.Aspect, &tok.FilePath, &tok.LineNumber, &tok.RawToken,
+			&tok.Status, &tok.Owner, &tok.Priority); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+	}
+
+	return tokens, rows.Err()
+}
+
+// diffTokens compares the tokens of one file before and after an edit.
+// Tokens are matched on requirement, feature and aspect. A matched token
+// whose content differs is changed; one that only changed line is moved.
+// Matched tokens that moved or changed are returned as pairs, and unmatched
+// tokens are returned so moves across files can be detected.
+func diffTokens(before, after []*Token) (c TokenChanges, matched []tokenPair, added, removed []*Token) {
+	key := func(t *Token) string { return t.ReqID + "\x00" + t.Feature + "\x00" + t.Aspect }
+
+	old := map[string][]*Token{}
+	for _, t := range before {
+		old[key(t)] = append(old[key(t)], t)
+	}
+
+	var loose []*Token // matched on key only
+	for _, t := range after {
+		k := key(t)
+		cands := old[k]
+		// Prefer an identical token, then one with the same content.
+		idx := -1
+		for i, o := range cands {
+			if o.RawToken == t.RawToken && o.LineNumber == t.LineNumber {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			for i, o := range cands {
+				if o.RawToken == t.RawToken {
+					idx = i
+					break
+				}
+			}
+		}
+		if idx < 0 {
+			loose = append(loose, t)
+			continue
+		}
+
+		o := cands[idx]
+		old[k] = append(cands[:idx], cands[idx+1:]...)
+		if o.LineNumber != t.LineNumber {
+			matched = append(matched, tokenPair{before: o, after: t})
+			c.Moved++
+			c.Moves = append(c.Moves, TokenMove{
+				ReqID: t.ReqID, Feature: t.Feature,
+				FromFile: o.FilePath, FromLine: o.LineNumber,
+				ToFile: t.FilePath, ToLine: t.LineNumber,
+			})
+		}
+	}
+
+	for _, t := range loose {
+		k := key(t)
+		if len(old[k]) == 0 {
+			added = append(added, t)
+			continue
+		}
+		matched = append(matched, tokenPair{before: old[k][0], after: t})
+		old[k] = old[k][1:]
+		c.Changed++
+	}
+	for _, t := range before {
+		for _, o := range old[key(t)] {
+			if o == t {
+				removed = append(removed, t)
+			}
+		}
+	}
+
+	c.Added = len(added)
+	c.Removed = len(removed)
+
+	return c, matched, added, removed
+}
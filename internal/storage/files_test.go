@@ -0,0 +1,173 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openMigrated(t *testing.T) *DB {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	if err := MigrateDB(dbPath, "all"); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func fileToken(req, feature string, line int, raw string) *Token {
+	return &Token{
+		ReqID: req, Feature: feature, Aspect: "API", Status: "IMPL",
+		LineNumber: line, UpdatedAt: "2025-10-15", RawToken: raw, IndexedAt: "now",
+	}
+}
+
+func TestCANARY_CBIN_152_Storage_ReplaceFileTokens(t *testing.T) {
+	db := openMigrated(t)
+	file := &IndexedFile{Path: "a.go", ContentHash: "h1", ModTime: 1, Size: 10, IndexedAt: "now"}
+
+	tx, err := db.BeginIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := tx.ReplaceFile(file, []*Token{
+		fileToken("CBIN-001", "One", 1, "one"),
+		fileToken("CBIN-002", "Two", 2, "two"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if counts(c) != [4]int{2, 0, 0, 0} {
+		t.Errorf("first pass = %+v, want 2 added", c)
+	}
+
+	// Edit: One changes, Two moves down, Three is added
+	file.ContentHash = "h2"
+	tx, _ = db.BeginIndex()
+	c, err = tx.ReplaceFile(file, []*Token{
+		fileToken("CBIN-001", "One", 1, "one edited"),
+		fileToken("CBIN-002", "Two", 3, "two"),
+		fileToken("CBIN-003", "Three", 6, "three"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if counts(c) != [4]int{1, 1, 1, 0} {
+		t.Errorf("edit pass = %+v, want 1 added, 1 changed, 1 moved", c)
+	}
+	if len(c.Moves) != 1 || c.Moves[0].FromLine != 2 || c.Moves[0].ToLine != 3 {
+		t.Errorf("moves = %+v", c.Moves)
+	}
+
+	toks, err := db.ListTokens(nil, "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(toks) != 3 {
+		t.Fatalf("expected 3 tokens after edit, got %d", len(toks))
+	}
+
+	files, err := db.GetIndexedFiles("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := files["a.go"]; f == nil || f.ContentHash != "h2" || f.TokenCount != 3 {
+		t.Errorf("indexed file = %+v", f)
+	}
+
+	// Delete the file
+	tx, _ = db.BeginIndex()
+	removed, err := tx.RemoveFile("a.go", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if removed != 3 {
+		t.Errorf("removed = %d, want 3", removed)
+	}
+	if toks, _ := db.ListTokens(nil, "", "", 0); len(toks) != 0 {
+		t.Errorf("tokens remain after removal: %d", len(toks))
+	}
+	if files, _ := db.GetIndexedFiles(""); len(files) != 0 {
+		t.Errorf("indexed files remain after removal: %d", len(files))
+	}
+}
+
+func TestDiffTokens_Unchanged(t *testing.T) {
+	before := []*Token{fileToken("CBIN-001", "A", 1, "a"), fileToken("CBIN-001", "A", 2, "b")}
+	after := []*Token{fileToken("CBIN-001", "A", 2, "b"), fileToken("CBIN-001", "A", 1, "a")}
+	if c, _, _, _ := diffTokens(before, after); counts(c) != [4]int{} {
+		t.Errorf("reordered tokens reported as %+v", c)
+	}
+}
+
+func TestIndexTx_PruneUnseenAndCrossFileMoves(t *testing.T) {
+	db := openMigrated(t)
+
+	// Rows left by an older indexer under a different path spelling, plus a
+	// bug token that was never indexed from source.
+	legacy := fileToken("CBIN-001", "One", 1, "one")
+	legacy.FilePath = "./a.go"
+	if err := db.UpsertToken(legacy); err != nil {
+		t.Fatal(err)
+	}
+	bug := fileToken("BUG-API-001", "Crash", 1, "")
+	bug.FilePath = "a.go"
+	if err := db.UpsertToken(bug); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.BeginIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := &IndexedFile{Path: "a.go", ContentHash: "h", IndexedAt: "now"}
+	if _, err := tx.ReplaceFile(file, []*Token{fileToken("CBIN-001", "One", 1, "one")}); err != nil {
+		t.Fatal(err)
+	}
+	pruned, err := tx.PruneUnseen(map[string]bool{"a.go": true}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := tx.Changes()
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if pruned != 1 {
+		t.Errorf("pruned = %d, want 1", pruned)
+	}
+	if counts(c) != [4]int{0, 0, 1, 0} || c.Moves[0].FromFile != "./a.go" || c.Moves[0].ToFile != "a.go" {
+		t.Errorf("changes = %+v, want a single move from ./a.go", c)
+	}
+
+	toks, err := db.ListTokens(nil, "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(toks) != 2 {
+		t.Errorf("expected indexed token and bug token, got %d rows", len(toks))
+	}
+}
+
+// counts returns added, changed, moved and removed counts.
+func counts(c TokenChanges) [4]int {
+	return [4]int{c.Added, c.Changed, c.Moved, c.Removed}
+}
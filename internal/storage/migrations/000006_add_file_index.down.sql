@@ -0,0 +1,5 @@
+-- CANARY: REQ=CBIN-152; FEATURE="IncrementalIndex"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback per-file index tracking
+
+DROP INDEX IF EXISTS idx_tokens_file_path;
+DROP TABLE IF EXISTS indexed_files;
@@ -0,0 +1,16 @@
+-- CANARY: REQ=CBIN-152; FEATURE="IncrementalIndex"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Track per-file content hashes so re-indexing only parses changed files
+
+CREATE TABLE IF NOT EXISTS indexed_files (
+    path TEXT NOT NULL,
+    project_id TEXT DEFAULT '',
+    content_hash TEXT NOT NULL,   -- SHA256 of file contents (hex)
+    mtime INTEGER NOT NULL,       -- Modification time (Unix nanoseconds)
+    size INTEGER NOT NULL,
+    token_count INTEGER DEFAULT 0,
+    indexed_at TEXT NOT NULL,
+
+    PRIMARY KEY (path, project_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tokens_file_path ON tokens(file_path);
@@ -0,0 +1,4 @@
+-- CANARY: REQ=CBIN-160; FEATURE="TestResultStore"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback test result tracking
+
+DROP TABLE IF EXISTS test_results;
@@ -0,0 +1,12 @@
+-- CANARY: REQ=CBIN-160; FEATURE="TestResultStore"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Latest outcome of each test from ingested go test -json, JUnit or TAP reports
+
+CREATE TABLE IF NOT EXISTS test_results (
+    name TEXT NOT NULL,             -- test name as reported by the runner
+    project_id TEXT DEFAULT '',
+    outcome TEXT NOT NULL,          -- pass, fail or skip
+    source TEXT,                    -- report file the outcome came from
+    recorded_at TEXT NOT NULL,
+
+    PRIMARY KEY (name, project_id)
+);
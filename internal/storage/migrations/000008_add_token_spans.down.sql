@@ -0,0 +1,7 @@
+-- CANARY: REQ=CBIN-165; FEATURE="CodeSpans"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback token code spans
+
+ALTER TABLE tokens DROP COLUMN span_end;
+ALTER TABLE tokens DROP COLUMN span_start;
+ALTER TABLE tokens DROP COLUMN symbol_kind;
+ALTER TABLE tokens DROP COLUMN symbol;
@@ -0,0 +1,16 @@
+-- CANARY: REQ=CBIN-165; FEATURE="CodeSpans"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Record the declaration each token annotates
+
+ALTER TABLE tokens ADD COLUMN symbol TEXT DEFAULT '';
+ALTER TABLE tokens ADD COLUMN symbol_kind TEXT DEFAULT '';
+ALTER TABLE tokens ADD COLUMN span_start INTEGER DEFAULT 0;
+ALTER TABLE tokens ADD COLUMN span_end INTEGER DEFAULT 0;
+
+-- Forget file hashes so the next index pass parses every file and fills in
+-- the spans of tokens indexed before this migration
+DELETE FROM indexed_files;
+
+-- Comments:
+-- symbol: declaration name, e.g. Parse or Blamer.Lines ('' when the token annotates no declaration)
+-- symbol_kind: func, method, type, var, const, or the keyword matched in other languages (def, class, fn...)
+-- span_start, span_end: first and last line of the declaration
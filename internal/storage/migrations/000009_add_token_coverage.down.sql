@@ -0,0 +1,5 @@
+-- CANARY: REQ=CBIN-166; FEATURE="CoverageStore"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback token coverage
+
+DROP INDEX IF EXISTS idx_token_coverage_req_id;
+DROP TABLE IF EXISTS token_coverage;
@@ -0,0 +1,18 @@
+-- CANARY: REQ=CBIN-166; FEATURE="CoverageStore"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Line coverage of the code each token annotates, from the last ingested profile
+
+CREATE TABLE IF NOT EXISTS token_coverage (
+    req_id TEXT NOT NULL,
+    feature TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    line_number INTEGER NOT NULL,   -- token line, as in tokens
+    project_id TEXT DEFAULT '',
+    covered_lines INTEGER NOT NULL,
+    total_lines INTEGER NOT NULL,   -- instrumented lines of the token's span
+    source TEXT,                    -- profile the coverage came from
+    recorded_at TEXT NOT NULL,
+
+    PRIMARY KEY (req_id, feature, file_path, line_number, project_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_coverage_req_id ON token_coverage(req_id);
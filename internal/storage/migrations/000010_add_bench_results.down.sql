@@ -0,0 +1,5 @@
+-- CANARY: REQ=CBIN-167; FEATURE="BenchStore"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback benchmark results
+
+DROP INDEX IF EXISTS idx_bench_results_commit;
+DROP TABLE IF EXISTS bench_results;
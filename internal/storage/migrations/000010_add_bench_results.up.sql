@@ -0,0 +1,23 @@
+-- CANARY: REQ=CBIN-167; FEATURE="BenchStore"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Benchmark measurements per BENCH reference per commit, from ingested go test -bench output
+
+CREATE TABLE IF NOT EXISTS bench_results (
+    req_id TEXT NOT NULL,
+    feature TEXT NOT NULL,
+    bench TEXT NOT NULL,            -- BENCH= reference of the token
+    name TEXT NOT NULL,             -- benchmark as reported, e.g. BenchmarkParse/small
+    package TEXT DEFAULT '',
+    commit_hash TEXT NOT NULL,
+    project_id TEXT DEFAULT '',
+    runs INTEGER NOT NULL,          -- results averaged, from -count
+    ns_per_op REAL NOT NULL,
+    benchmem INTEGER NOT NULL DEFAULT 0, -- B/op and allocs/op were reported
+    bytes_per_op REAL NOT NULL DEFAULT 0,
+    allocs_per_op REAL NOT NULL DEFAULT 0,
+    source TEXT,                    -- report the results came from
+    recorded_at TEXT NOT NULL,
+
+    PRIMARY KEY (req_id, feature, name, commit_hash, project_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bench_results_commit ON bench_results(commit_hash);
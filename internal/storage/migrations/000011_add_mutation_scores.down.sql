@@ -0,0 +1,5 @@
+-- CANARY: REQ=CBIN-168; FEATURE="MutationStore"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback mutation scores
+
+DROP INDEX IF EXISTS idx_mutation_scores_req_id;
+DROP TABLE IF EXISTS mutation_scores;
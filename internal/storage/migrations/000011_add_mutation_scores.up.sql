@@ -0,0 +1,18 @@
+-- CANARY: REQ=CBIN-168; FEATURE="MutationStore"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Mutation score of the code each token annotates, from the last canary verify --mutate
+
+CREATE TABLE IF NOT EXISTS mutation_scores (
+    req_id TEXT NOT NULL,
+    feature TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    line_number INTEGER NOT NULL,   -- token line, as in tokens
+    project_id TEXT DEFAULT '',
+    killed INTEGER NOT NULL,        -- mutants a named test caught
+    survived INTEGER NOT NULL,      -- mutants every named test passed
+    invalid INTEGER NOT NULL,       -- mutants that didn't compile
+    recorded_at TEXT NOT NULL,
+
+    PRIMARY KEY (req_id, feature, file_path, line_number, project_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mutation_scores_req_id ON mutation_scores(req_id);
@@ -0,0 +1,7 @@
+-- CANARY: REQ=CBIN-169; FEATURE="RequirementGraph"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback requirement edges
+
+DROP INDEX IF EXISTS idx_requirement_edges_origin_path;
+DROP INDEX IF EXISTS idx_requirement_edges_target;
+DROP INDEX IF EXISTS idx_requirement_edges_source;
+DROP TABLE IF EXISTS requirement_edges;
@@ -0,0 +1,25 @@
+-- CANARY: REQ=CBIN-169; FEATURE="RequirementGraph"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Relationships between requirements, from token DEPENDS_ON/BLOCKS/RELATED_TO fields and spec.md Dependencies sections
+
+CREATE TABLE IF NOT EXISTS requirement_edges (
+    source TEXT NOT NULL,           -- requirement declaring the relationship
+    target TEXT NOT NULL,           -- requirement it points at
+    type TEXT NOT NULL,             -- depends_on, blocks or related_to
+    required_features TEXT NOT NULL DEFAULT '', -- comma-separated, for partial dependencies
+    required_aspect TEXT NOT NULL DEFAULT '',   -- for dependencies on one aspect
+    description TEXT NOT NULL DEFAULT '',
+    origin TEXT NOT NULL,           -- token or spec
+    origin_path TEXT NOT NULL,      -- file declaring the relationship
+    origin_line INTEGER NOT NULL DEFAULT 0, -- token line; 0 for specs
+    project_id TEXT DEFAULT '',
+
+    PRIMARY KEY (source, target, type, required_features, required_aspect, origin, origin_path, origin_line, project_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_requirement_edges_source ON requirement_edges(source);
+CREATE INDEX IF NOT EXISTS idx_requirement_edges_target ON requirement_edges(target);
+CREATE INDEX IF NOT EXISTS idx_requirement_edges_origin_path ON requirement_edges(origin_path);
+
+-- Forget file hashes so the next index pass re-parses every file and fills
+-- the table
+DELETE FROM indexed_files;
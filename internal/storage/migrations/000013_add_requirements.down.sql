@@ -0,0 +1,6 @@
+-- CANARY: REQ=CBIN-170; FEATURE="RequirementsTable"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback requirements
+
+DROP INDEX IF EXISTS idx_requirements_priority;
+DROP INDEX IF EXISTS idx_requirements_spec_status;
+DROP TABLE IF EXISTS requirements;
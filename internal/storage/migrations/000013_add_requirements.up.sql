@@ -0,0 +1,30 @@
+-- CANARY: REQ=CBIN-170; FEATURE="RequirementsTable"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- One row per requirement, so specs without tokens yet are tracked and priority/spec status live in one place
+
+CREATE TABLE IF NOT EXISTS requirements (
+    req_id TEXT NOT NULL,
+    project_id TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',       -- spec heading
+    aspect TEXT NOT NULL DEFAULT '',
+    spec_path TEXT NOT NULL DEFAULT '',   -- spec.md, when one exists
+    plan_path TEXT NOT NULL DEFAULT '',   -- plan.md, when one exists
+    spec_status TEXT,                     -- NULL follows the tokens' SPEC_STATUS
+    priority INTEGER,                     -- NULL follows the tokens' PRIORITY
+    owner TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT '',
+    closed_at TEXT NOT NULL DEFAULT '',   -- date every token reached a completed status
+    updated_at TEXT NOT NULL DEFAULT '',
+
+    PRIMARY KEY (req_id, project_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_requirements_spec_status ON requirements(spec_status);
+CREATE INDEX IF NOT EXISTS idx_requirements_priority ON requirements(priority);
+
+-- Seed a row for every requirement that already has tokens; the next index
+-- pass fills in specs and plans
+INSERT OR IGNORE INTO requirements (req_id, project_id, aspect, owner, created_at, updated_at)
+SELECT req_id, COALESCE(project_id, ''), MIN(aspect), COALESCE(MAX(owner), ''),
+    COALESCE(MIN(NULLIF(created_at, '')), ''), MAX(updated_at)
+FROM tokens
+GROUP BY req_id, COALESCE(project_id, '');
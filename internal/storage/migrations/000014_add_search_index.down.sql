@@ -0,0 +1,12 @@
+-- CANARY: REQ=CBIN-171; FEATURE="FullTextSearch"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback full-text search
+
+DROP TABLE IF EXISTS spec_fts;
+DROP TRIGGER IF EXISTS gap_fts_update;
+DROP TRIGGER IF EXISTS gap_fts_delete;
+DROP TRIGGER IF EXISTS gap_fts_insert;
+DROP TABLE IF EXISTS gap_fts;
+DROP TRIGGER IF EXISTS tokens_fts_update;
+DROP TRIGGER IF EXISTS tokens_fts_delete;
+DROP TRIGGER IF EXISTS tokens_fts_insert;
+DROP TABLE IF EXISTS tokens_fts;
@@ -0,0 +1,64 @@
+-- CANARY: REQ=CBIN-171; FEATURE="FullTextSearch"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- FTS5 indexes over tokens, spec/plan sections and gap entries, ranked with BM25
+
+-- Tokens, kept in sync with the tokens table by triggers
+CREATE VIRTUAL TABLE IF NOT EXISTS tokens_fts USING fts5(
+    req_id, feature, keywords, raw_token,
+    content = 'tokens', content_rowid = 'id',
+    tokenize = 'porter unicode61'
+);
+
+CREATE TRIGGER IF NOT EXISTS tokens_fts_insert AFTER INSERT ON tokens BEGIN
+    INSERT INTO tokens_fts(rowid, req_id, feature, keywords, raw_token)
+    VALUES (new.id, new.req_id, new.feature, new.keywords, new.raw_token);
+END;
+
+CREATE TRIGGER IF NOT EXISTS tokens_fts_delete AFTER DELETE ON tokens BEGIN
+    INSERT INTO tokens_fts(tokens_fts, rowid, req_id, feature, keywords, raw_token)
+    VALUES ('delete', old.id, old.req_id, old.feature, old.keywords, old.raw_token);
+END;
+
+CREATE TRIGGER IF NOT EXISTS tokens_fts_update AFTER UPDATE OF req_id, feature, keywords, raw_token ON tokens BEGIN
+    INSERT INTO tokens_fts(tokens_fts, rowid, req_id, feature, keywords, raw_token)
+    VALUES ('delete', old.id, old.req_id, old.feature, old.keywords, old.raw_token);
+    INSERT INTO tokens_fts(rowid, req_id, feature, keywords, raw_token)
+    VALUES (new.id, new.req_id, new.feature, new.keywords, new.raw_token);
+END;
+
+INSERT INTO tokens_fts(tokens_fts) VALUES ('rebuild');
+
+-- Gap analysis entries, kept in sync with gap_entries by triggers
+CREATE VIRTUAL TABLE IF NOT EXISTS gap_fts USING fts5(
+    req_id, feature, description, corrective_action,
+    content = 'gap_entries', content_rowid = 'id',
+    tokenize = 'porter unicode61'
+);
+
+CREATE TRIGGER IF NOT EXISTS gap_fts_insert AFTER INSERT ON gap_entries BEGIN
+    INSERT INTO gap_fts(rowid, req_id, feature, description, corrective_action)
+    VALUES (new.id, new.req_id, new.feature, new.description, new.corrective_action);
+END;
+
+CREATE TRIGGER IF NOT EXISTS gap_fts_delete AFTER DELETE ON gap_entries BEGIN
+    INSERT INTO gap_fts(gap_fts, rowid, req_id, feature, description, corrective_action)
+    VALUES ('delete', old.id, old.req_id, old.feature, old.description, old.corrective_action);
+END;
+
+CREATE TRIGGER IF NOT EXISTS gap_fts_update AFTER UPDATE OF req_id, feature, description, corrective_action ON gap_entries BEGIN
+    INSERT INTO gap_fts(gap_fts, rowid, req_id, feature, description, corrective_action)
+    VALUES ('delete', old.id, old.req_id, old.feature, old.description, old.corrective_action);
+    INSERT INTO gap_fts(rowid, req_id, feature, description, corrective_action)
+    VALUES (new.id, new.req_id, new.feature, new.description, new.corrective_action);
+END;
+
+INSERT INTO gap_fts(gap_fts) VALUES ('rebuild');
+
+-- Sections of spec.md and plan.md files, written by the indexer
+CREATE VIRTUAL TABLE IF NOT EXISTS spec_fts USING fts5(
+    req_id, heading, body,
+    kind UNINDEXED,       -- spec or plan
+    path UNINDEXED,
+    line UNINDEXED,       -- line of the section heading
+    project_id UNINDEXED,
+    tokenize = 'porter unicode61'
+);
@@ -0,0 +1,8 @@
+-- CANARY: REQ=CBIN-172; FEATURE="TokenEvents"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Rollback token events
+
+DROP TRIGGER IF EXISTS token_events_no_delete;
+DROP TRIGGER IF EXISTS token_events_no_update;
+DROP INDEX IF EXISTS idx_token_events_commit;
+DROP INDEX IF EXISTS idx_token_events_req_id;
+DROP TABLE IF EXISTS token_events;
@@ -0,0 +1,41 @@
+-- CANARY: REQ=CBIN-172; FEATURE="TokenEvents"; ASPECT=Storage; STATUS=IMPL; UPDATED=2026-10-17
+-- Append-only history of token changes seen by index passes
+
+CREATE TABLE IF NOT EXISTS token_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    req_id TEXT NOT NULL,
+    feature TEXT NOT NULL,
+    aspect TEXT NOT NULL,
+    kind TEXT NOT NULL,                 -- appear, disappear, move, status, owner or priority
+    old_value TEXT NOT NULL DEFAULT '', -- previous status, owner, priority or file:line
+    new_value TEXT NOT NULL DEFAULT '',
+    file_path TEXT NOT NULL,            -- where the token is, or was last seen
+    line_number INTEGER NOT NULL,
+    commit_hash TEXT NOT NULL DEFAULT '',
+    branch TEXT NOT NULL DEFAULT '',
+    recorded_at TEXT NOT NULL,
+    project_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_events_req_id ON token_events(req_id, project_id);
+CREATE INDEX IF NOT EXISTS idx_token_events_commit ON token_events(commit_hash);
+
+CREATE TRIGGER IF NOT EXISTS token_events_no_update BEFORE UPDATE ON token_events
+BEGIN
+    SELECT RAISE(ABORT, 'token_events is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS token_events_no_delete BEFORE DELETE ON token_events
+BEGIN
+    SELECT RAISE(ABORT, 'token_events is append-only');
+END;
+
+-- Tokens indexed before history was kept appear as of their last index pass
+INSERT INTO token_events (req_id, feature, aspect, kind, new_value, file_path, line_number,
+    commit_hash, branch, recorded_at, project_id)
+SELECT req_id, feature, aspect, 'appear', status, file_path, line_number,
+    COALESCE(commit_hash, ''), COALESCE(branch, ''),
+    COALESCE(NULLIF(indexed_at, ''), updated_at, ''), COALESCE(project_id, '')
+FROM tokens
+WHERE raw_token <> ''
+ORDER BY req_id, file_path, line_number;
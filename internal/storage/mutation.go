@@ -0,0 +1,71 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-168; FEATURE="MutationStore"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_168_Storage_RecordMutationScores; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// MutationScore is the outcome of mutating the code one token annotates
+type MutationScore struct {
+	ReqID      string
+	Feature    string
+	FilePath   string
+	LineNumber int
+	ProjectID  string
+	Killed     int
+	Survived   int
+	Invalid    int // mutants that didn't compile
+	RecordedAt string
+}
+
+// ReplaceMutationScores replaces every mutation score of a project with
+// rows, as each run mutates every eligible token.
+func (db *DB) ReplaceMutationScores(projectID string, rows []MutationScore) error {
+	return db.replaceTokenRows("mutation_scores", "mutation scores", projectID, len(rows), func(tx *sqlx.Tx, i int, now string) error {
+		r := rows[i]
+		if r.RecordedAt == "" {
+			r.RecordedAt = now
+		}
+		_, err := tx.Exec(`
+			INSERT INTO mutation_scores (req_id, feature, file_path, line_number, project_id,
+				killed, survived, invalid, recorded_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(req_id, feature, file_path, line_number, project_id) DO UPDATE SET
+				killed = excluded.killed,
+				survived = excluded.survived,
+				invalid = excluded.invalid,
+				recorded_at = excluded.recorded_at
+		`, r.ReqID, r.Feature, r.FilePath, r.LineNumber, projectID, r.Killed, r.Survived, r.Invalid, r.RecordedAt)
+		if err != nil {
+			return fmt.Errorf("record mutation score %s/%s: %w", r.ReqID, r.Feature, err)
+		}
+		return nil
+	})
+}
+
+// ApplyMutationScores sets MutantsKilled and MutantsSurvived on every token
+// with a recorded score, matched like ApplyCoverage.
+func (db *DB) ApplyMutationScores(projectID string, tokens []*Token) error {
+	byToken, err := db.tokenValues(`
+		SELECT req_id, feature, file_path, line_number, killed, survived
+		FROM mutation_scores
+		WHERE COALESCE(project_id, '') = ?
+	`, "mutation scores", projectID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tokens {
+		if s, ok := byToken[keyOf(t)]; ok {
+			t.MutantsKilled, t.MutantsSurvived = s[0], s[1]
+		}
+	}
+	return nil
+}
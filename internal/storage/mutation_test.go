@@ -0,0 +1,57 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"testing"
+)
+
+func TestCANARY_CBIN_168_Storage_RecordMutationScores(t *testing.T) {
+	db := openMigrated(t)
+
+	if err := db.ReplaceMutationScores("", []MutationScore{
+		{ReqID: "CBIN-410", Feature: "Tokenize", FilePath: "lex/lex.go", LineNumber: 12, Killed: 5, Survived: 2},
+		{ReqID: "CBIN-411", Feature: "Render", FilePath: "render.go", LineNumber: 40, Killed: 7},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ReplaceMutationScores("web", []MutationScore{
+		{ReqID: "CBIN-411", Feature: "Render", FilePath: "render.go", LineNumber: 40, Killed: 1, Survived: 6},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// A new run replaces the project's scores and leaves other projects be
+	if err := db.ReplaceMutationScores("", []MutationScore{
+		{ReqID: "CBIN-410", Feature: "Tokenize", FilePath: "lex/lex.go", LineNumber: 12, Killed: 6, Survived: 1, Invalid: 3},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	toks := []*Token{
+		{ReqID: "CBIN-410", Feature: "Tokenize", FilePath: "lex/lex.go", LineNumber: 12},
+		{ReqID: "CBIN-411", Feature: "Render", FilePath: "render.go", LineNumber: 40},
+		{ReqID: "CBIN-410", Feature: "Tokenize", FilePath: "lex.go", LineNumber: 12},
+	}
+	if err := db.ApplyMutationScores("", toks); err != nil {
+		t.Fatal(err)
+	}
+	if toks[0].MutantsKilled != 6 || toks[0].MutantsSurvived != 1 {
+		t.Errorf("Tokenize = %d killed, %d survived, want 6 and 1", toks[0].MutantsKilled, toks[0].MutantsSurvived)
+	}
+	for _, tok := range toks[1:] {
+		if tok.MutantsKilled != 0 || tok.MutantsSurvived != 0 {
+			t.Errorf("%s in %s has score %d/%d", tok.Feature, tok.FilePath, tok.MutantsKilled, tok.MutantsSurvived)
+		}
+	}
+
+	web := []*Token{{ReqID: "CBIN-411", Feature: "Render", FilePath: "render.go", LineNumber: 40}}
+	if err := db.ApplyMutationScores("web", web); err != nil {
+		t.Fatal(err)
+	}
+	if web[0].MutantsKilled != 1 || web[0].MutantsSurvived != 6 {
+		t.Errorf("web Render = %d killed, %d survived, want 1 and 6", web[0].MutantsKilled, web[0].MutantsSurvived)
+	}
+}
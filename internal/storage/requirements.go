@@ -0,0 +1,351 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-170; FEATURE="RequirementsTable"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_170_Storage_Requirements; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Requirement is one row of the requirements table: a requirement known
+// from its spec, its tokens, or both
+type Requirement struct {
+	ReqID     string
+	ProjectID string
+	Title     string
+	Aspect    string
+	SpecPath  string
+	PlanPath  string
+	Owner     string
+	CreatedAt string
+	ClosedAt  string // date every token reached a completed status
+	UpdatedAt string
+
+	// SpecStatus and Priority as set on the requirement, or else taken
+	// from its tokens when read
+	SpecStatus string
+	Priority   int
+
+	// Tokens counts the requirement's tokens outside specs, plans, tests
+	// and templates. Computed when read, never stored.
+	Tokens int
+}
+
+// createRequirementsTable mirrors migration 000013 for databases created by
+// ensureTokensTable.
+const createRequirementsTable = `
+	CREATE TABLE IF NOT EXISTS requirements (
+		req_id TEXT NOT NULL,
+		project_id TEXT NOT NULL DEFAULT '',
+		title TEXT NOT NULL DEFAULT '',
+		aspect TEXT NOT NULL DEFAULT '',
+		spec_path TEXT NOT NULL DEFAULT '',
+		plan_path TEXT NOT NULL DEFAULT '',
+		spec_status TEXT,
+		priority INTEGER,
+		owner TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL DEFAULT '',
+		closed_at TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL DEFAULT '',
+
+		PRIMARY KEY (req_id, project_id)
+	)
+`
+
+// tokenSource stands in for the tokens table in token queries: a priority
+// or spec status set on a requirement applies to all of its tokens. The
+// CAST keeps priority's integer affinity, which filters bound as strings
+// rely on.
+const tokenSource = `(
+	SELECT t.id, t.req_id, t.feature, t.aspect, t.status, t.file_path, t.line_number,
+		t.test, t.bench, t.owner,
+		CAST(COALESCE(r.priority, t.priority) AS INTEGER) AS priority, t.phase, t.keywords,
+		COALESCE(r.spec_status, t.spec_status) AS spec_status,
+		t.created_at, t.updated_at, t.started_at, t.completed_at,
+		t.commit_hash, t.branch, t.depends_on, t.blocks, t.related_to,
+		t.raw_token, t.indexed_at,
+		t.doc_path, t.doc_hash, t.doc_type, t.doc_checked_at, t.doc_status,
+		t.project_id, t.symbol, t.symbol_kind, t.span_start, t.span_end
+	FROM tokens t
+	LEFT JOIN requirements r ON r.req_id = t.req_id AND r.project_id = COALESCE(t.project_id, '')
+) AS tokens`
+
+// requirementToken reports whether a token of reqID at path counts toward
+// a requirement: tokens in specs, plans, tests and templates don't, nor do
+// documentation examples with placeholder IDs like CBIN-XXX.
+func requirementToken(reqID, path string) bool {
+	for _, placeholder := range []string{"XXX", "###", "{{"} {
+		if strings.Contains(reqID, placeholder) {
+			return false
+		}
+	}
+	return !isHiddenPath(path) && !shouldExcludeFile(path)
+}
+
+// SaveRequirement creates the requirement or updates the fields of r that
+// are set, e.g. when `canary specify` or `canary plan` writes a file.
+func (db *DB) SaveRequirement(r *Requirement) error {
+	if err := db.ensureTokensTable(); err != nil {
+		return fmt.Errorf("ensure tokens table: %w", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	created := r.CreatedAt
+	if created == "" {
+		created = time.Now().UTC().Format("2006-01-02")
+	}
+	_, err := db.conn.Exec(`
+		INSERT INTO requirements (req_id, project_id, title, aspect, spec_path, plan_path,
+			owner, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(req_id, project_id) DO UPDATE SET
+			title = COALESCE(NULLIF(excluded.title, ''), requirements.title),
+			aspect = COALESCE(NULLIF(excluded.aspect, ''), requirements.aspect),
+			spec_path = COALESCE(NULLIF(excluded.spec_path, ''), requirements.spec_path),
+			plan_path = COALESCE(NULLIF(excluded.plan_path, ''), requirements.plan_path),
+			owner = COALESCE(NULLIF(excluded.owner, ''), requirements.owner),
+			created_at = COALESCE(NULLIF(?, ''), requirements.created_at),
+			updated_at = excluded.updated_at
+	`, r.ReqID, r.ProjectID, r.Title, r.Aspect, r.SpecPath, r.PlanPath, r.Owner, created, now, r.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("save requirement %s: %w", r.ReqID, err)
+	}
+	return nil
+}
+
+// RequirementTokens returns the tokens that count toward requirements, as
+// requirementToken decides, ordered by requirement and location
+func (t *IndexTx) RequirementTokens(projectID string) ([]*Token, error) {
+	var rows []struct {
+		ReqID     string `db:"req_id"`
+		Feature   string `db:"feature"`
+		Aspect    string `db:"aspect"`
+		Status    string `db:"status"`
+		Owner     string `db:"owner"`
+		CreatedAt string `db:"created_at"`
+		FilePath  string `db:"file_path"`
+	}
+	err := t.tx.Select(&rows, `
+		SELECT req_id, feature, aspect, status, COALESCE(owner, '') AS owner,
+			COALESCE(created_at, '') AS created_at, file_path
+		FROM tokens
+		WHERE COALESCE(project_id, '') = ?
+		ORDER BY req_id, file_path, line_number
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query requirement tokens: %w", err)
+	}
+
+	var out []*Token
+	for _, r := range rows {
+		if !requirementToken(r.ReqID, r.FilePath) {
+			continue
+		}
+		out = append(out, &Token{
+			ReqID: r.ReqID, Feature: r.Feature, Aspect: r.Aspect, Status: r.Status,
+			Owner: r.Owner, CreatedAt: r.CreatedAt, FilePath: r.FilePath, ProjectID: projectID,
+		})
+	}
+	return out, nil
+}
+
+// SyncRequirements makes reqs the requirements of a project, as derived by
+// the indexer. Priority and spec status are kept, as is the date a
+// requirement closed while it stays closed; requirements not in reqs are
+// removed.
+func (t *IndexTx) SyncRequirements(projectID string, reqs []*Requirement) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	keep := make(map[string]bool, len(reqs))
+	for _, r := range reqs {
+		keep[r.ReqID] = true
+		created := r.CreatedAt
+		if created == "" {
+			created = time.Now().UTC().Format("2006-01-02")
+		}
+		_, err := t.tx.Exec(`
+			INSERT INTO requirements (req_id, project_id, title, aspect, spec_path, plan_path,
+				owner, created_at, closed_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(req_id, project_id) DO UPDATE SET
+				title = excluded.title,
+				aspect = excluded.aspect,
+				spec_path = excluded.spec_path,
+				plan_path = excluded.plan_path,
+				owner = excluded.owner,
+				created_at = COALESCE(NULLIF(?, ''), requirements.created_at),
+				closed_at = CASE
+					WHEN excluded.closed_at = '' OR requirements.closed_at = '' THEN excluded.closed_at
+					ELSE requirements.closed_at
+				END,
+				updated_at = excluded.updated_at
+			WHERE requirements.title IS NOT excluded.title
+				OR requirements.aspect IS NOT excluded.aspect
+				OR requirements.spec_path IS NOT excluded.spec_path
+				OR requirements.plan_path IS NOT excluded.plan_path
+				OR requirements.owner IS NOT excluded.owner
+				OR (? <> '' AND requirements.created_at IS NOT ?)
+				OR (requirements.closed_at = '') <> (excluded.closed_at = '')
+		`, r.ReqID, projectID, r.Title, r.Aspect, r.SpecPath, r.PlanPath,
+			r.Owner, created, r.ClosedAt, now,
+			r.CreatedAt, r.CreatedAt, r.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("sync requirement %s: %w", r.ReqID, err)
+		}
+	}
+
+	var ids []string
+	if err := t.tx.Select(&ids, `SELECT req_id FROM requirements WHERE project_id = ?`, projectID); err != nil {
+		return fmt.Errorf("query requirements: %w", err)
+	}
+	for _, id := range ids {
+		if keep[id] {
+			continue
+		}
+		if _, err := t.tx.Exec(`DELETE FROM requirements WHERE req_id = ? AND project_id = ?`, id, projectID); err != nil {
+			return fmt.Errorf("delete requirement %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// GetRequirement returns one requirement, or nil when there is none
+func (db *DB) GetRequirement(projectID, reqID string) (*Requirement, error) {
+	reqs, err := db.queryRequirements(projectID, reqID)
+	if err != nil || len(reqs) == 0 {
+		return nil, err
+	}
+	return reqs[0], nil
+}
+
+// ListRequirements returns every requirement of a project, including those
+// without tokens yet, ordered by priority then ID
+func (db *DB) ListRequirements(projectID string) ([]*Requirement, error) {
+	return db.queryRequirements(projectID, "")
+}
+
+// queryRequirements reads the requirements of a project, or only reqID
+// when set, filling in priority, spec status and token counts from tokens
+func (db *DB) queryRequirements(projectID, reqID string) ([]*Requirement, error) {
+	rows, err := db.conn.Query(`
+		SELECT req_id, project_id, title, aspect, spec_path, plan_path,
+			spec_status, priority, owner, created_at, closed_at, updated_at
+		FROM requirements
+		WHERE project_id = ? AND (? = '' OR req_id = ?)
+	`, projectID, reqID, reqID)
+	if err != nil {
+		return nil, fmt.Errorf("query requirements: %w", err)
+	}
+	defer rows.Close()
+
+	var reqs []*Requirement
+	byID := map[string]*Requirement{}
+	setStatus, setPriority := map[string]bool{}, map[string]bool{}
+	for rows.Next() {
+		r := &Requirement{}
+		var specStatus sql.NullString
+		var priority sql.NullInt64
+		err := rows.Scan(&r.ReqID, &r.ProjectID, &r.Title, &r.Aspect, &r.SpecPath, &r.PlanPath,
+			&specStatus, &priority, &r.Owner, &r.CreatedAt, &r.ClosedAt, &r.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		r.SpecStatus, setStatus[r.ReqID] = specStatus.String, specStatus.Valid
+		r.Priority, setPriority[r.ReqID] = int(priority.Int64), priority.Valid
+		reqs = append(reqs, r)
+		byID[r.ReqID] = r
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	toks, err := db.conn.Query(`
+		SELECT req_id, file_path, COALESCE(priority, 5), COALESCE(spec_status, '')
+		FROM tokens
+		WHERE COALESCE(project_id, '') = ? AND (? = '' OR req_id = ?)
+	`, projectID, reqID, reqID)
+	if err != nil {
+		return nil, fmt.Errorf("query requirement tokens: %w", err)
+	}
+	defer toks.Close()
+
+	for toks.Next() {
+		var id, path, specStatus string
+		var priority int
+		if err := toks.Scan(&id, &path, &priority, &specStatus); err != nil {
+			return nil, err
+		}
+		r := byID[id]
+		if r == nil || !requirementToken(id, path) {
+			continue
+		}
+		r.Tokens++
+		if !setPriority[id] && (r.Tokens == 1 || priority < r.Priority) {
+			r.Priority = priority
+		}
+		if !setStatus[id] && r.SpecStatus == "" {
+			r.SpecStatus = specStatus
+		}
+	}
+	if err := toks.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, r := range reqs {
+		if !setPriority[r.ReqID] && r.Tokens == 0 {
+			r.Priority = 5
+		}
+		if r.SpecStatus == "" {
+			r.SpecStatus = "draft"
+		}
+	}
+	sort.Slice(reqs, func(i, j int) bool {
+		if reqs[i].Priority != reqs[j].Priority {
+			return reqs[i].Priority < reqs[j].Priority
+		}
+		return reqs[i].ReqID < reqs[j].ReqID
+	})
+
+	return reqs, nil
+}
+
+// UpdatePriority sets the priority of a requirement, which applies to all
+// of its tokens
+func (db *DB) UpdatePriority(reqID string, priority int) error {
+	return db.setRequirementField(reqID, "priority", priority)
+}
+
+// UpdateSpecStatus sets the spec status of a requirement, which applies to
+// all of its tokens
+func (db *DB) UpdateSpecStatus(reqID, specStatus string) error {
+	return db.setRequirementField(reqID, "spec_status", specStatus)
+}
+
+// setRequirementField sets one column of a requirement of the default
+// project, creating the row when the requirement isn't known yet
+func (db *DB) setRequirementField(reqID, column string, value any) error {
+	if err := db.ensureTokensTable(); err != nil {
+		return fmt.Errorf("ensure tokens table: %w", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err := db.conn.Exec(`
+		INSERT INTO requirements (req_id, project_id, `+column+`, created_at, updated_at)
+		VALUES (?, '', ?, ?, ?)
+		ON CONFLICT(req_id, project_id) DO UPDATE SET
+			`+column+` = excluded.`+column+`,
+			updated_at = excluded.updated_at
+	`, reqID, value, time.Now().UTC().Format("2006-01-02"), now)
+	if err != nil {
+		return fmt.Errorf("update %s of %s: %w", column, reqID, err)
+	}
+	return nil
+}
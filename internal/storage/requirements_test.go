@@ -0,0 +1,126 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import "testing"
+
+func TestCANARY_CBIN_170_Storage_Requirements(t *testing.T) {
+	db := openMigrated(t)
+
+	for _, tok := range []*Token{
+		{ReqID: "CBIN-100", Feature: "A", Aspect: "API", Status: "IMPL", FilePath: "a.go", LineNumber: 1, Priority: 3, SpecStatus: "draft"},
+		{ReqID: "CBIN-100", Feature: "B", Aspect: "API", Status: "STUB", FilePath: "b.go", LineNumber: 1, Priority: 4, SpecStatus: "draft"},
+		{ReqID: "CBIN-100", Feature: "T", Aspect: "API", Status: "STUB", FilePath: "a_test.go", LineNumber: 1, Priority: 1},
+	} {
+		if err := db.UpsertToken(tok); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tx, err := db.BeginIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tx.SyncRequirements("", []*Requirement{
+		{ReqID: "CBIN-100", Aspect: "API", CreatedAt: "2026-10-01"},
+		{ReqID: "CBIN-101", Title: "Parser", Aspect: "Engine", SpecPath: ".canary/specs/CBIN-101-parser/spec.md"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs, err := db.ListRequirements("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("ListRequirements() returned %d requirements, want 2", len(reqs))
+	}
+	// CBIN-100 takes the lowest priority of its tokens outside tests
+	if r := reqs[0]; r.ReqID != "CBIN-100" || r.Priority != 3 || r.Tokens != 2 || r.SpecStatus != "draft" || r.CreatedAt != "2026-10-01" {
+		t.Errorf("CBIN-100 = %+v", r)
+	}
+	if r := reqs[1]; r.ReqID != "CBIN-101" || r.Title != "Parser" || r.Tokens != 0 || r.Priority != 5 || r.CreatedAt == "" {
+		t.Errorf("CBIN-101 = %+v", r)
+	}
+
+	// Priority and spec status are set once on the requirement and apply
+	// to every token without rewriting them
+	if err := db.UpdatePriority("CBIN-100", 9); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpdateSpecStatus("CBIN-100", "approved"); err != nil {
+		t.Fatal(err)
+	}
+	toks, err := db.GetTokensByReqID("CBIN-100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tok := range toks {
+		if tok.Priority != 9 || tok.SpecStatus != "approved" {
+			t.Errorf("token %s priority %d spec status %q, want 9 approved", tok.Feature, tok.Priority, tok.SpecStatus)
+		}
+	}
+	listed, err := db.ListTokens(map[string]string{"priority_min": "9"}, "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 2 {
+		t.Errorf("ListTokens(priority >= 9) returned %d tokens, want 2", len(listed))
+	}
+	var raw int
+	if err := db.conn.Get(&raw, `SELECT COUNT(*) FROM tokens WHERE priority = 9`); err != nil {
+		t.Fatal(err)
+	}
+	if raw != 0 {
+		t.Errorf("%d token rows rewritten with the new priority", raw)
+	}
+
+	// A later sync keeps the priority and closed date and drops
+	// requirements that are gone
+	sync := func(closed string) {
+		t.Helper()
+		tx, err := db.BeginIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.SyncRequirements("", []*Requirement{{ReqID: "CBIN-100", ClosedAt: closed}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sync("2026-10-05")
+	sync("2026-10-09")
+	r, err := db.GetRequirement("", "CBIN-100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Priority != 9 || r.SpecStatus != "approved" || r.ClosedAt != "2026-10-05" || r.CreatedAt != "2026-10-01" {
+		t.Errorf("CBIN-100 after sync = %+v", r)
+	}
+	if r, err := db.GetRequirement("", "CBIN-101"); err != nil || r != nil {
+		t.Errorf("GetRequirement(CBIN-101) = %+v, %v; want removed", r, err)
+	}
+
+	// Reopened requirements lose their closed date
+	sync("")
+	if r, _ := db.GetRequirement("", "CBIN-100"); r == nil || r.ClosedAt != "" {
+		t.Errorf("CBIN-100 after reopening = %+v", r)
+	}
+
+	// SaveRequirement only fills in the fields it is given
+	if err := db.SaveRequirement(&Requirement{ReqID: "CBIN-100", PlanPath: "plan.md"}); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := db.GetRequirement("", "CBIN-100"); r == nil || r.PlanPath != "plan.md" || r.CreatedAt != "2026-10-01" || r.Priority != 9 {
+		t.Errorf("CBIN-100 after save = %+v", r)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-160; FEATURE="TestResultStore"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_160_Storage_RecordTestResults; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// TestResult is the latest recorded outcome of one test
+type TestResult struct {
+	Name       string
+	ProjectID  string
+	Outcome    string // pass, fail or skip
+	Source     string
+	RecordedAt string
+}
+
+// RecordTestResults stores the outcome of each test, replacing any earlier
+// outcome for the same name. Tests missing from results keep their last
+// outcome, so partial runs only update what they ran.
+func (db *DB) RecordTestResults(results []TestResult) error {
+	tx, err := db.conn.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, r := range results {
+		if r.RecordedAt == "" {
+			r.RecordedAt = now
+		}
+		_, err := tx.Exec(`
+			INSERT INTO test_results (name, project_id, outcome, source, recorded_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(name, project_id) DO UPDATE SET
+				outcome = excluded.outcome,
+				source = excluded.source,
+				recorded_at = excluded.recorded_at
+		`, r.Name, r.ProjectID, r.Outcome, r.Source, r.RecordedAt)
+		if err != nil {
+			return fmt.Errorf("record result %s: %w", r.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// TestOutcomes returns the latest outcome of every recorded test for a
+// project keyed by test name. It is empty when no results were recorded.
+func (db *DB) TestOutcomes(projectID string) (map[string]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT name, outcome FROM test_results
+		WHERE COALESCE(project_id, '') = ?
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query test results: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string]string{}
+	for rows.Next() {
+		var name, outcome string
+		if err := rows.Scan(&name, &outcome); err != nil {
+			return nil, err
+		}
+		out[name] = outcome
+	}
+
+	return out, rows.Err()
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"testing"
+)
+
+func TestCANARY_CBIN_160_Storage_RecordTestResults(t *testing.T) {
+	db := openMigrated(t)
+
+	if err := db.RecordTestResults([]TestResult{
+		{Name: "TestA", Outcome: "pass", Source: "run1.json"},
+		{Name: "TestB", Outcome: "pass", Source: "run1.json"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// A partial run only replaces what it ran
+	if err := db.RecordTestResults([]TestResult{
+		{Name: "TestB", Outcome: "fail", Source: "run2.json"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := db.TestOutcomes("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got["TestA"] != "pass" || got["TestB"] != "fail" {
+		t.Errorf("outcomes = %v", got)
+	}
+}
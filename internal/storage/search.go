@@ -0,0 +1,201 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// CANARY: REQ=CBIN-171; FEATURE="FullTextSearch"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_CBIN_171_Storage_FullTextSearch; OWNER=canary; UPDATED=2026-10-17
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kinds of search results
+const (
+	SearchToken = "token"
+	SearchSpec  = "spec"
+	SearchPlan  = "plan"
+	SearchGap   = "gap"
+)
+
+// Markers around matched terms in SearchResult.Snippet
+const (
+	SnippetStart = "**"
+	SnippetEnd   = "**"
+)
+
+// SearchResult is one match of a full-text search
+type SearchResult struct {
+	Kind    string // SearchToken, SearchSpec, SearchPlan or SearchGap
+	ReqID   string
+	Title   string // token feature, section heading or gap feature
+	Snippet string // matching text, matches marked with SnippetStart/End
+	Path    string // token file or spec/plan file; empty for gaps
+	Line    int
+	Rank    float64 // BM25, lower is better
+}
+
+// SearchOptions narrows a full-text search
+type SearchOptions struct {
+	ProjectID string
+	Kinds     []string // all kinds when empty
+	Limit     int      // 20 when zero
+}
+
+// createSpecSearchTable mirrors the spec_fts table of migration 000014 for
+// databases created by ensureTokensTable, which the indexer writes to.
+const createSpecSearchTable = `
+	CREATE VIRTUAL TABLE IF NOT EXISTS spec_fts USING fts5(
+		req_id, heading, body,
+		kind UNINDEXED, path UNINDEXED, line UNINDEXED, project_id UNINDEXED,
+		tokenize = 'porter unicode61'
+	)
+`
+
+// SpecSection is one section of a spec.md or plan.md
+type SpecSection struct {
+	Heading string
+	Body    string
+	Line    int
+}
+
+// ReplaceSpecSections replaces the search index entries of the spec or plan
+// of reqID at path with sections. kind is SearchSpec or SearchPlan.
+func (t *IndexTx) ReplaceSpecSections(path, projectID, reqID, kind string, sections []SpecSection) error {
+	if _, err := t.tx.Exec(`DELETE FROM spec_fts WHERE path = ? AND project_id = ?`, path, projectID); err != nil {
+		return fmt.Errorf("delete search entries for %s: %w", path, err)
+	}
+	for _, s := range sections {
+		_, err := t.tx.Exec(`
+			INSERT INTO spec_fts (req_id, heading, body, kind, path, line, project_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, reqID, s.Heading, s.Body, kind, path, s.Line, projectID)
+		if err != nil {
+			return fmt.Errorf("index %s section %q: %w", path, s.Heading, err)
+		}
+	}
+	return nil
+}
+
+// ClearSpecSections removes every spec and plan section of projectID from
+// the search index
+func (t *IndexTx) ClearSpecSections(projectID string) error {
+	if _, err := t.tx.Exec(`DELETE FROM spec_fts WHERE project_id = ?`, projectID); err != nil {
+		return fmt.Errorf("clear spec search entries: %w", err)
+	}
+	return nil
+}
+
+// searchQueries selects each kind of result as kind, req_id, title,
+// snippet, path, line and rank. BM25 weights favor requirement IDs and
+// names over free text.
+var searchQueries = map[string]string{
+	SearchToken: `
+		SELECT 'token', t.req_id, t.feature,
+			snippet(tokens_fts, -1, :start, :end, '…', 12),
+			t.file_path, t.line_number, bm25(tokens_fts, 10.0, 5.0, 3.0, 1.0)
+		FROM tokens_fts JOIN tokens t ON t.id = tokens_fts.rowid
+		WHERE tokens_fts MATCH :query AND COALESCE(t.project_id, '') = :project`,
+	SearchSpec: specSearchQuery(SearchSpec),
+	SearchPlan: specSearchQuery(SearchPlan),
+	SearchGap: `
+		SELECT 'gap', g.req_id, g.feature,
+			snippet(gap_fts, -1, :start, :end, '…', 12),
+			'', 0, bm25(gap_fts, 10.0, 5.0, 1.0, 1.0)
+		FROM gap_fts JOIN gap_entries g ON g.id = gap_fts.rowid
+		WHERE gap_fts MATCH :query`,
+}
+
+func specSearchQuery(kind string) string {
+	return `
+		SELECT kind, req_id, heading,
+			snippet(spec_fts, 2, :start, :end, '…', 16),
+			path, CAST(line AS INTEGER), bm25(spec_fts, 10.0, 5.0, 1.0)
+		FROM spec_fts
+		WHERE spec_fts MATCH :query AND kind = '` + kind + `' AND project_id = :project`
+}
+
+// Search runs a full-text search over tokens, spec and plan sections and
+// gap entries, best matches first. Every word of query must match, as a
+// prefix; when nothing matches them all, results matching any word are
+// returned instead.
+func (db *DB) Search(query string, opts SearchOptions) ([]SearchResult, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	results, err := db.search(ftsQuery(terms, " "), opts)
+	if err != nil || len(results) > 0 || len(terms) == 1 {
+		return results, err
+	}
+	return db.search(ftsQuery(terms, " OR "), opts)
+}
+
+func (db *DB) search(match string, opts SearchOptions) ([]SearchResult, error) {
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = []string{SearchToken, SearchSpec, SearchPlan, SearchGap}
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	var parts []string
+	for _, k := range kinds {
+		q, ok := searchQueries[k]
+		if !ok {
+			return nil, fmt.Errorf("unknown search kind %q", k)
+		}
+		parts = append(parts, q)
+	}
+
+	rows, err := db.conn.NamedQuery(strings.Join(parts, "\n\t\tUNION ALL")+`
+		ORDER BY 7
+		LIMIT :limit
+	`, map[string]any{
+		"query": match, "project": opts.ProjectID, "limit": limit,
+		"start": SnippetStart, "end": SnippetEnd,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	defer rows.Close()
+
+	var out []SearchResult
+	for rows.Next() {
+		var r SearchResult
+		if err := rows.Scan(&r.Kind, &r.ReqID, &r.Title, &r.Snippet, &r.Path, &r.Line, &r.Rank); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+
+	return out, rows.Err()
+}
+
+// ftsQuery quotes each term so punctuation like the dash in CBIN-101 is
+// matched literally rather than read as FTS5 syntax, and makes it a prefix
+func ftsQuery(terms []string, sep string) string {
+	quoted := make([]string, 0, len(terms))
+	for _, t := range terms {
+		quoted = append(quoted, `"`+strings.ReplaceAll(t, `"`, `""`)+`"*`)
+	}
+	return strings.Join(quoted, sep)
+}
+
+// RecordSearch adds a query and its number of results to search_history.
+// filters is a JSON object of the options used, or empty.
+func (db *DB) RecordSearch(query, filters string, results int) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO search_history (query, filters, results_count, searched_at)
+		VALUES (?, ?, ?, ?)
+	`, query, filters, results, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("record search: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCANARY_CBIN_171_Storage_FullTextSearch(t *testing.T) {
+	db := openMigrated(t)
+
+	for _, tok := range []*Token{
+		{ReqID: "CBIN-100", Feature: "SessionStore", Aspect: "Storage", Status: "IMPL", FilePath: "session.go", LineNumber: 3,
+			Keywords: "authentication,cookies", RawToken: `// CANARY: REQ=CBIN-100; FEATURE="SessionStore"`},
+		{ReqID: "CBIN-101", Feature: "Parser", Aspect: "Engine", Status: "STUB", FilePath: "parse.go", LineNumber: 1,
+			RawToken: `// CANARY: REQ=CBIN-101; FEATURE="Parser"`},
+	} {
+		if err := db.UpsertToken(tok); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := NewGapRepository(db).CreateEntry(&GapEntry{
+		GapID: "GAP-CBIN-101-001", ReqID: "CBIN-101", Feature: "Parser", Category: "logic_error",
+		Description: "Nested brackets were tokenized as one expression",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := db.BeginIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tx.ReplaceSpecSections(".canary/specs/CBIN-102-login/spec.md", "", "CBIN-102", SearchSpec, []SpecSection{
+		{Heading: "Login", Line: 1},
+		{Heading: "Overview", Body: "Users sign in with a password; authentication failures are rate limited.", Line: 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(q string, opts SearchOptions) []SearchResult {
+		t.Helper()
+		results, err := db.Search(q, opts)
+		if err != nil {
+			t.Fatalf("Search(%q): %v", q, err)
+		}
+		return results
+	}
+
+	// Stemming and prefixes match a spec section and a token keyword
+	results := search("authenticate", SearchOptions{})
+	if len(results) != 2 {
+		t.Fatalf("Search(authenticate) = %+v, want 2 results", results)
+	}
+	kinds := map[string]SearchResult{}
+	for _, r := range results {
+		kinds[r.Kind] = r
+	}
+	if r := kinds[SearchSpec]; r.ReqID != "CBIN-102" || r.Title != "Overview" || r.Line != 5 ||
+		!strings.Contains(r.Snippet, SnippetStart+"authentication"+SnippetEnd) {
+		t.Errorf("spec result = %+v", r)
+	}
+	if r := kinds[SearchToken]; r.ReqID != "CBIN-100" || r.Path != "session.go" || r.Line != 3 {
+		t.Errorf("token result = %+v", r)
+	}
+
+	// Requirement IDs match despite the dash, and the requirement's own
+	// token outranks a gap mentioning it
+	results = search("CBIN-101", SearchOptions{})
+	if len(results) != 2 || results[0].Kind != SearchToken || results[1].Kind != SearchGap {
+		t.Errorf("Search(CBIN-101) = %+v", results)
+	}
+
+	// Kinds narrow the search; a word matching nothing falls back to any word
+	results = search("brackets nonexistent", SearchOptions{Kinds: []string{SearchGap}})
+	if len(results) != 1 || results[0].ReqID != "CBIN-101" {
+		t.Errorf("Search(brackets nonexistent) = %+v", results)
+	}
+
+	// Edits and removals reach the index
+	if err := db.UpsertToken(&Token{ReqID: "CBIN-100", Feature: "SessionStore", Aspect: "Storage", Status: "IMPL",
+		FilePath: "session.go", LineNumber: 3, RawToken: `// CANARY: REQ=CBIN-100; FEATURE="SessionStore"`}); err != nil {
+		t.Fatal(err)
+	}
+	tx, err = db.BeginIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.ClearSpecSections(""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if results := search("authentication", SearchOptions{}); len(results) != 0 {
+		t.Errorf("Search(authentication) after removal = %+v", results)
+	}
+
+	if err := db.RecordSearch("authentication", `{"kinds":["spec"]}`, 0); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := db.conn.Get(&count, `SELECT results_count FROM search_history WHERE query = 'authentication'`); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("recorded results_count = %d, want 0", count)
+	}
+}
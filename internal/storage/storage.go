@@ -8,6 +8,7 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -54,6 +55,26 @@ type Token struct {
 	// CANARY: REQ=CBIN-146; FEATURE="TokenNamespacing"; ASPECT=Storage; STATUS=IMPL; UPDATED=2025-10-18
 	// Multi-project support
 	ProjectID string // Project identifier for token isolation
+
+	// Code the token annotates: the declaration following or enclosing it
+	Symbol     string // e.g. Parse or Blamer.Lines; empty when there is none
+	SymbolKind string // func, method, type, var, const, def, class...
+	SpanStart  int    // first line of the declaration
+	SpanEnd    int    // last line of the declaration
+
+	// Status after recorded test results are applied (e.g. REGRESSED when a
+	// named test failed). Computed by callers, never stored.
+	EffectiveStatus string `json:",omitempty"`
+
+	// Line coverage of the token's span from the last ingested profile,
+	// loaded by ApplyCoverage. Never stored on the token row.
+	CoveredLines   int `json:",omitempty"`
+	CoverableLines int `json:",omitempty"`
+
+	// Mutants of the token's span its tests killed and missed in the last
+	// `canary verify --mutate`, loaded by ApplyMutationScores
+	MutantsKilled   int `json:",omitempty"`
+	MutantsSurvived int `json:",omitempty"`
 }
 
 // Checkpoint represents a state snapshot
@@ -107,46 +128,64 @@ func (db *DB) UpsertToken(token *Token) error {
 		return fmt.Errorf("ensure tokens table: %w", err)
 	}
 
-	query := `
-		INSERT INTO tokens (
-			req_id, feature, aspect, status, file_path, line_number,
-			test, bench, owner, priority, phase, keywords, spec_status,
-			created_at, updated_at, started_at, completed_at,
-			commit_hash, branch, depends_on, blocks, related_to,
-			raw_token, indexed_at,
-			doc_path, doc_hash, doc_type, doc_checked_at, doc_status,
-			project_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(req_id, feature, file_path, line_number, project_id)
-		DO UPDATE SET
-			aspect = excluded.aspect,
-			status = excluded.status,
-			test = excluded.test,
-			bench = excluded.bench,
-			owner = excluded.owner,
-			priority = excluded.priority,
-			phase = excluded.phase,
-			keywords = excluded.keywords,
-			spec_status = excluded.spec_status,
-			updated_at = excluded.updated_at,
-			started_at = excluded.started_at,
-			completed_at = excluded.completed_at,
-			commit_hash = excluded.commit_hash,
-			branch = excluded.branch,
-			depends_on = excluded.depends_on,
-			blocks = excluded.blocks,
-			related_to = excluded.related_to,
-			raw_token = excluded.raw_token,
-			indexed_at = excluded.indexed_at,
-			doc_path = excluded.doc_path,
-			doc_hash = excluded.doc_hash,
-			doc_type = excluded.doc_type,
-			doc_checked_at = excluded.doc_checked_at,
-			doc_status = excluded.doc_status,
-			project_id = excluded.project_id
-	`
+	if _, err := db.conn.Exec(upsertTokenQuery, tokenArgs(token)...); err != nil {
+		return err
+	}
+	return replaceTokenEdges(db.conn, token)
+}
 
-	_, err := db.conn.Exec(query,
+// upsertTokenQuery inserts a token or updates the row with the same identity.
+const upsertTokenQuery = `
+	INSERT INTO tokens (
+		req_id, feature, aspect, status, file_path, line_number,
+		test, bench, owner, priority, phase, keywords, spec_status,
+		created_at, updated_at, started_at, completed_at,
+		commit_hash, branch, depends_on, blocks, related_to,
+		raw_token, indexed_at,
+		doc_path, doc_hash, doc_type, doc_checked_at, doc_status,
+		project_id,
+		symbol, symbol_kind, span_start, span_end
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(req_id, feature, file_path, line_number, project_id)
+	DO UPDATE SET
+		aspect = excluded.aspect,
+		status = excluded.status,
+		test = excluded.test,
+		bench = excluded.bench,
+		owner = excluded.owner,
+		priority = excluded.priority,
+		phase = excluded.phase,
+		keywords = excluded.keywords,
+		spec_status = excluded.spec_status,
+		updated_at = excluded.updated_at,
+		started_at = excluded.started_at,
+		completed_at = excluded.completed_at,
+		commit_hash = excluded.commit_hash,
+		branch = excluded.branch,
+		depends_on = excluded.depends_on,
+		blocks = excluded.blocks,
+		related_to = excluded.related_to,
+		raw_token = excluded.raw_token,
+		indexed_at = excluded.indexed_at,
+		doc_path = excluded.doc_path,
+		doc_hash = excluded.doc_hash,
+		doc_type = excluded.doc_type,
+		doc_checked_at = excluded.doc_checked_at,
+		doc_status = excluded.doc_status,
+		project_id = excluded.project_id,
+		symbol = excluded.symbol,
+		symbol_kind = excluded.symbol_kind,
+		span_start = excluded.span_start,
+		span_end = excluded.span_end
+`
+
+// spanColumns selects the code span of a token, which rows indexed before
+// spans were recorded don't have.
+const spanColumns = `COALESCE(symbol, ''), COALESCE(symbol_kind, ''), COALESCE(span_start, 0), COALESCE(span_end, 0)`
+
+// tokenArgs returns the upsertTokenQuery arguments for token.
+func tokenArgs(token *Token) []any {
+	return []any{
 		token.ReqID, token.Feature, token.Aspect, token.Status,
 		token.FilePath, token.LineNumber,
 		token.Test, token.Bench, token.Owner,
@@ -157,9 +196,8 @@ func (db *DB) UpsertToken(token *Token) error {
 		token.RawToken, token.IndexedAt,
 		token.DocPath, token.DocHash, token.DocType, token.DocCheckedAt, token.DocStatus,
 		token.ProjectID,
-	)
-
-	return err
+		token.Symbol, token.SymbolKind, token.SpanStart, token.SpanEnd,
+	}
 }
 
 // GetTokensByReqID retrieves all tokens for a requirement
@@ -170,8 +208,9 @@ func (db *DB) GetTokensByReqID(reqID string) ([]*Token, error) {
 			created_at, updated_at, started_at, completed_at,
 			commit_hash, branch, depends_on, blocks, related_to,
 			raw_token, indexed_at,
-			doc_path, doc_hash, doc_type, doc_checked_at, doc_status
-		FROM tokens
+			doc_path, doc_hash, doc_type, doc_checked_at, doc_status,
+			` + spanColumns + `
+		FROM ` + tokenSource + `
 		WHERE req_id = ?
 		ORDER BY priority ASC, feature ASC
 	`
@@ -257,8 +296,9 @@ func (db *DB) ListTokens(filters map[string]string, idPattern string, orderBy st
 			created_at, updated_at, started_at, completed_at,
 			commit_hash, branch, depends_on, blocks, related_to,
 			raw_token, indexed_at,
-			doc_path, doc_hash, doc_type, doc_checked_at, doc_status
-		FROM tokens
+			doc_path, doc_hash, doc_type, doc_checked_at, doc_status,
+			` + spanColumns + `
+		FROM ` + tokenSource + `
 		WHERE 1=1
 	`
 	args := []interface{}{}
@@ -378,8 +418,9 @@ func (db *DB) SearchTokens(keywords string) ([]*Token, error) {
 			created_at, updated_at, started_at, completed_at,
 			commit_hash, branch, depends_on, blocks, related_to,
 			raw_token, indexed_at,
-			doc_path, doc_hash, doc_type, doc_checked_at, doc_status
-		FROM tokens
+			doc_path, doc_hash, doc_type, doc_checked_at, doc_status,
+			` + spanColumns + `
+		FROM ` + tokenSource + `
 		WHERE keywords LIKE ? OR feature LIKE ? OR req_id LIKE ?
 		ORDER BY priority ASC
 	`
@@ -432,20 +473,6 @@ func shouldExcludeFile(path string) bool {
 	return false
 }
 
-// UpdatePriority updates the priority of a token
-func (db *DB) UpdatePriority(reqID, feature string, priority int) error {
-	query := `UPDATE tokens SET priority = ? WHERE req_id = ? AND feature = ?`
-	_, err := db.conn.Exec(query, priority, reqID, feature)
-	return err
-}
-
-// UpdateSpecStatus updates the spec status
-func (db *DB) UpdateSpecStatus(reqID, specStatus string) error {
-	query := `UPDATE tokens SET spec_status = ? WHERE req_id = ?`
-	_, err := db.conn.Exec(query, specStatus, reqID)
-	return err
-}
-
 // CreateCheckpoint creates a state snapshot
 func (db *DB) CreateCheckpoint(name, description, commitHash, snapshotJSON string) error {
 	// Get current counts
@@ -453,10 +480,10 @@ func (db *DB) CreateCheckpoint(name, description, commitHash, snapshotJSON strin
 	err := db.conn.QueryRow(`
 		SELECT
 			COUNT(*),
-			SUM(CASE WHEN status = 'STUB' THEN 1 ELSE 0 END),
-			SUM(CASE WHEN status = 'IMPL' THEN 1 ELSE 0 END),
-			SUM(CASE WHEN status = 'TESTED' THEN 1 ELSE 0 END),
-			SUM(CASE WHEN status = 'BENCHED' THEN 1 ELSE 0 END)
+			COALESCE(SUM(CASE WHEN status = 'STUB' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = 'IMPL' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = 'TESTED' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = 'BENCHED' THEN 1 ELSE 0 END), 0)
 		FROM tokens
 	`).Scan(&total, &stub, &impl, &tested, &benched)
 	if err != nil {
@@ -475,21 +502,38 @@ func (db *DB) CreateCheckpoint(name, description, commitHash, snapshotJSON strin
 	return err
 }
 
+// checkpointColumns are the columns scanCheckpoints reads
+const checkpointColumns = `
+	id, name, description, commit_hash, created_at,
+	total_tokens, stub_count, impl_count, tested_count, benched_count,
+	snapshot_json`
+
 // GetCheckpoints retrieves all checkpoints
 func (db *DB) GetCheckpoints() ([]*Checkpoint, error) {
-	query := `
-		SELECT id, name, description, commit_hash, created_at,
-			total_tokens, stub_count, impl_count, tested_count, benched_count,
-			snapshot_json
-		FROM checkpoints
-		ORDER BY created_at DESC
-	`
+	rows, err := db.conn.Query(`SELECT ` + checkpointColumns + ` FROM checkpoints ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := db.conn.Query(query)
+	return scanCheckpoints(rows)
+}
+
+// GetCheckpoint retrieves the checkpoint called name, or nil when there is
+// none
+func (db *DB) GetCheckpoint(name string) (*Checkpoint, error) {
+	rows, err := db.conn.Query(`SELECT `+checkpointColumns+` FROM checkpoints WHERE name = ?`, name)
 	if err != nil {
+		return nil, fmt.Errorf("query checkpoint %s: %w", name, err)
+	}
+
+	checkpoints, err := scanCheckpoints(rows)
+	if err != nil || len(checkpoints) == 0 {
 		return nil, err
 	}
+	return checkpoints[0], nil
+}
 
+func scanCheckpoints(rows *sql.Rows) ([]*Checkpoint, error) {
 	defer rows.Close()
 
 	var checkpoints []*Checkpoint
@@ -507,6 +551,18 @@ func (db *DB) GetCheckpoints() ([]*Checkpoint, error) {
 	return checkpoints, rows.Err()
 }
 
+// Tokens decodes the tokens captured in the checkpoint's snapshot
+func (cp *Checkpoint) Tokens() ([]*Token, error) {
+	var tokens []*Token
+	if cp.SnapshotJSON == "" {
+		return tokens, nil
+	}
+	if err := json.Unmarshal([]byte(cp.SnapshotJSON), &tokens); err != nil {
+		return nil, fmt.Errorf("decode checkpoint %s: %w", cp.Name, err)
+	}
+	return tokens, nil
+}
+
 // Helper function to scan token rows
 func scanTokens(rows *sql.Rows) ([]*Token, error) {
 	var tokens []*Token
@@ -522,6 +578,7 @@ func scanTokens(rows *sql.Rows) ([]*Token, error) {
 			&t.DependsOn, &t.Blocks, &t.RelatedTo,
 			&t.RawToken, &t.IndexedAt,
 			&t.DocPath, &t.DocHash, &t.DocType, &t.DocCheckedAt, &t.DocStatus,
+			&t.Symbol, &t.SymbolKind, &t.SpanStart, &t.SpanEnd,
 		)
 		if err != nil {
 			return nil, err
@@ -589,6 +646,12 @@ func (db *DB) ensureTokensTable() error {
 			-- Multi-project support
 			project_id TEXT DEFAULT '',
 
+			-- Code span
+			symbol TEXT DEFAULT '',
+			symbol_kind TEXT DEFAULT '',
+			span_start INTEGER DEFAULT 0,
+			span_end INTEGER DEFAULT 0,
+
 			UNIQUE(req_id, feature, file_path, line_number, project_id)
 		)
 	`
@@ -616,6 +679,19 @@ func (db *DB) ensureTokensTable() error {
 		}
 	}
 
+	if _, err := db.conn.Exec(createEdgesTable); err != nil {
+		return fmt.Errorf("create requirement edges table: %w", err)
+	}
+	if _, err := db.conn.Exec(createRequirementsTable); err != nil {
+		return fmt.Errorf("create requirements table: %w", err)
+	}
+	if _, err := db.conn.Exec(createSpecSearchTable); err != nil {
+		return fmt.Errorf("create spec search table: %w", err)
+	}
+	if _, err := db.conn.Exec(createTokenEventsTable); err != nil {
+		return fmt.Errorf("create token events table: %w", err)
+	}
+
 	return nil
 }
 
@@ -633,6 +709,7 @@ func (db *DB) GetTokensByProject(projectID string) ([]*Token, error) {
 			commit_hash, branch, depends_on, blocks, related_to,
 			raw_token, indexed_at,
 			doc_path, doc_hash, doc_type, doc_checked_at, doc_status,
+			` + spanColumns + `,
 			COALESCE(project_id, '') as project_id
 		FROM tokens
 		WHERE COALESCE(project_id, '') = ?
@@ -661,6 +738,7 @@ func (db *DB) GetAllTokens() ([]*Token, error) {
 			commit_hash, branch, depends_on, blocks, related_to,
 			raw_token, indexed_at,
 			doc_path, doc_hash, doc_type, doc_checked_at, doc_status,
+			` + spanColumns + `,
 			COALESCE(project_id, '') as project_id
 		FROM tokens
 		ORDER BY priority ASC, updated_at DESC
@@ -688,6 +766,7 @@ func (db *DB) GetTokensByReqIDAndProject(reqID, projectID string) ([]*Token, err
 			commit_hash, branch, depends_on, blocks, related_to,
 			raw_token, indexed_at,
 			doc_path, doc_hash, doc_type, doc_checked_at, doc_status,
+			` + spanColumns + `,
 			COALESCE(project_id, '') as project_id
 		FROM tokens
 		WHERE req_id = ? AND COALESCE(project_id, '') = ?
@@ -718,6 +797,7 @@ func scanTokensWithProject(rows *sql.Rows) ([]*Token, error) {
 			&t.DependsOn, &t.Blocks, &t.RelatedTo,
 			&t.RawToken, &t.IndexedAt,
 			&t.DocPath, &t.DocHash, &t.DocType, &t.DocCheckedAt, &t.DocStatus,
+			&t.Symbol, &t.SymbolKind, &t.SpanStart, &t.SpanEnd,
 			&t.ProjectID,
 		)
 		if err != nil {
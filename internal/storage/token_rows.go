@@ -0,0 +1,72 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// tokenKey identifies the token a per-token row was recorded for. Rows are
+// matched on requirement, feature, file and line, so tokens that moved
+// since the row was recorded match none.
+type tokenKey struct {
+	req, feature, file string
+	line               int
+}
+
+// replaceTokenRows deletes every row of table for a project and calls
+// insert for each of n new rows, in one transaction. now is the time to
+// record rows without their own. what names the rows in errors.
+func (db *DB) replaceTokenRows(table, what, projectID string, n int, insert func(tx *sqlx.Tx, i int, now string) error) error {
+	tx, err := db.conn.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	if _, err := tx.Exec(`DELETE FROM `+table+` WHERE COALESCE(project_id, '') = ?`, projectID); err != nil {
+		return fmt.Errorf("clear %s: %w", what, err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for i := 0; i < n; i++ {
+		if err := insert(tx, i, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// tokenValues runs query, which selects the requirement, feature, file and
+// line of each row followed by two values, for a project and returns the
+// values keyed by token.
+func (db *DB) tokenValues(query, what, projectID string) (map[tokenKey][2]int, error) {
+	rows, err := db.conn.Query(query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	out := map[tokenKey][2]int{}
+	for rows.Next() {
+		var k tokenKey
+		var v [2]int
+		if err := rows.Scan(&k.req, &k.feature, &k.file, &k.line, &v[0], &v[1]); err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+
+	return out, rows.Err()
+}
+
+func keyOf(t *Token) tokenKey {
+	return tokenKey{t.ReqID, t.Feature, t.FilePath, t.LineNumber}
+}
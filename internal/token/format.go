@@ -0,0 +1,225 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package token
+
+// CANARY: REQ=CBIN-157; FEATURE="TokenFormatter"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_157_Engine_FormatSource
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/reqid"
+)
+
+// FieldOrder is the canonical order of token fields. Fields not listed are
+// kept in their written order after DOC_TYPE and before the dates.
+var FieldOrder = []string{
+	"REQ", "FEATURE", "ASPECT", "STATUS",
+	"TEST", "BENCH", "OWNER", "PRIORITY", "PHASE", "KEYWORDS", "SPEC_STATUS",
+	"DEPENDS_ON", "BLOCKS", "RELATED_TO",
+	"DOC", "DOC_HASH", "DOC_TYPE",
+	"CREATED", "STARTED", "COMPLETED", "UPDATED",
+}
+
+// continuedFields groups the fields that get their own CANARY+: line when a
+// token spans several lines. Everything else stays on the first line.
+var continuedFields = [][]string{
+	{"TEST"},
+	{"BENCH"},
+	{"KEYWORDS"},
+	{"DEPENDS_ON", "BLOCKS", "RELATED_TO"},
+	{"DOC", "DOC_HASH", "DOC_TYPE"},
+}
+
+// canonical returns the token's "KEY=value" fields in canonical order with
+// canonical values: REQ with plain hyphens, FEATURE double-quoted, ASPECT in its
+// canonical casing and STATUS upper-cased. Other values are unquoted unless
+// they contain a semicolon.
+func (t *Token) canonical() []string {
+	keys := canonicalKeys(t.Keys)
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, k+"="+t.canonicalValue(k))
+	}
+	return out
+}
+
+func (t *Token) canonicalValue(k string) string {
+	v := t.Get(k)
+	switch k {
+	case "REQ":
+		return t.ReqID()
+	case "FEATURE":
+		if strings.Contains(v, `"`) {
+			return t.Fields[k]
+		}
+		return `"` + v + `"`
+	case "ASPECT":
+		return reqid.NormalizeAspect(v)
+	case "STATUS":
+		return strings.ToUpper(v)
+	}
+	if strings.ContainsRune(v, ';') {
+		if strings.Contains(v, `"`) {
+			return t.Fields[k]
+		}
+		return `"` + v + `"`
+	}
+	return v
+}
+
+// canonicalKeys orders keys by FieldOrder, keeping unknown keys in place
+// between DOC_TYPE and the dates.
+func canonicalKeys(keys []string) []string {
+	rank := map[string]int{}
+	for i, k := range FieldOrder {
+		rank[k] = i
+	}
+	have := map[string]bool{}
+	var unknown []string
+	for _, k := range keys {
+		have[k] = true
+		if _, ok := rank[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+
+	var out []string
+	for _, k := range FieldOrder {
+		if k == "CREATED" {
+			out = append(out, unknown...)
+		}
+		if have[k] {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// Format returns the canonical text of tok after "CANARY: " on one line.
+func Format(tok *Token) string {
+	return strings.Join(tok.canonical(), "; ")
+}
+
+// formattable reports whether a token can be rewritten safely. Only a
+// missing UPDATED field is repaired; any other error leaves it untouched.
+func formattable(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity != SeverityError {
+			continue
+		}
+		if d.Code != CodeMissingField || !strings.Contains(d.Message, "UPDATED") {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatSource rewrites every token in src to its canonical form, keeping
+// each line's indentation, comment marker and any closing delimiter. Tokens
+// written on one line stay on one line; tokens continued over several lines
+// are rewritten with one CANARY+: line per field group. A missing UPDATED
+// field is filled in with today. Tokens with other errors, such as a STATUS
+// that isn't a state of lc, are left as they are and their diagnostics
+// returned.
+//
+// It returns the new source and the tokens that changed.
+func FormatSource(file string, src []byte, today string, lc *lifecycle.Lifecycle) ([]byte, []*Token, []Diagnostic) {
+	if !bytes.Contains(src, []byte(marker)) {
+		return src, nil, nil
+	}
+
+	out := strings.Split(string(src), "\n")
+	var (
+		changed []*Token
+		diags   []Diagnostic
+		next    = len(out) + 1 // first line already rewritten
+	)
+	all := parseAll(file, sourceLines(file, src), lc)
+	for i := len(all) - 1; i >= 0; i-- {
+		p := all[i]
+		tok := p.tok
+		if !formattable(p.diags) {
+			diags = append(diags, p.diags...)
+			continue
+		}
+		if tok.EndLine >= next {
+			continue // shares a line with a token already rewritten
+		}
+		if !tok.Has("UPDATED") {
+			tok.Keys = append(tok.Keys, "UPDATED")
+			tok.Fields["UPDATED"] = today
+		}
+
+		repl := tok.render()
+		old := out[tok.Line-1 : tok.EndLine]
+		cr := strings.HasSuffix(old[len(old)-1], "\r")
+		if cr {
+			for j := range repl {
+				repl[j] += "\r"
+			}
+		}
+		next = tok.Line
+		if slices.Equal(old, repl) {
+			continue
+		}
+
+		out = append(out[:tok.Line-1], append(repl, out[tok.EndLine:]...)...)
+		changed = append(changed, tok)
+	}
+
+	if len(changed) == 0 {
+		return src, nil, diags
+	}
+
+	// Report changes in source order
+	for i, j := 0, len(changed)-1; i < j; i, j = i+1, j-1 {
+		changed[i], changed[j] = changed[j], changed[i]
+	}
+	return []byte(strings.Join(out, "\n")), changed, diags
+}
+
+// render returns the source lines of the canonical token.
+func (t *Token) render() []string {
+	first := t.lines[0]
+	last := t.lines[len(t.lines)-1]
+	lead := first.raw[:first.at]
+	tail := last.raw[last.end():]
+
+	fields := t.canonical()
+	if len(t.lines) == 1 {
+		return []string{lead + marker + " " + strings.Join(fields, "; ") + tail}
+	}
+
+	// Continuation lines reuse the lead of the token's second line, which
+	// already sits in the right comment.
+	second := t.lines[1]
+	contLead := second.raw[:second.at+len(second.text)-len(strings.TrimLeft(second.text, decoration))]
+
+	group := map[string]int{}
+	for i, g := range continuedFields {
+		for _, k := range g {
+			group[k] = i + 1
+		}
+	}
+	groups := make([][]string, len(continuedFields)+1)
+	for _, f := range fields {
+		k, _, _ := strings.Cut(f, "=")
+		groups[group[k]] = append(groups[group[k]], f)
+	}
+
+	out := []string{lead + marker + " " + strings.Join(groups[0], "; ")}
+	for _, g := range groups[1:] {
+		if len(g) > 0 {
+			out = append(out, contLead+continuation+" "+strings.Join(g, "; "))
+		}
+	}
+	out[len(out)-1] += tail
+	return out
+}
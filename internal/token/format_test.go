@@ -0,0 +1,93 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package token
+
+import (
+	"testing"
+)
+
+// TestCANARY_CBIN_157_Engine_FormatSource verifies tokens are rewritten to
+// canonical order and quoting while keeping their comment layout.
+func TestCANARY_CBIN_157_Engine_FormatSource(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		src  string
+		want string
+	}{
+		{
+			name: "order quoting and casing",
+			file: "a.go",
+			src:  "package a\n\n\t// CANARY: UPDATED=2025-10-15; STATUS=impl; REQ=CBIN-200; OWNER='team'; ASPECT=engine; FEATURE=Parser\nfunc A() {}\n",
+			want: "package a\n\n\t// CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=Engine; STATUS=IMPL; OWNER=team; UPDATED=2025-10-15\nfunc A() {}\n",
+		},
+		{
+			name: "missing updated",
+			file: "a.py",
+			src:  "x = 1  # CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=API; STATUS=STUB\n",
+			want: "x = 1  # CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=API; STATUS=STUB; UPDATED=2026-01-02\n",
+		},
+		{
+			name: "block comment tail",
+			file: "a.c",
+			src:  "/* CANARY: FEATURE=\"Parser\"; REQ=CBIN-200; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15 */\r\nint x;\r\n",
+			want: "/* CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15 */\r\nint x;\r\n",
+		},
+		{
+			name: "unknown keys and semicolons",
+			file: "notes.txt",
+			src:  "<!-- CANARY: TITLE=Some; REQ=CBIN-200; KEYWORDS='a;b'; FEATURE=\"Parser\"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15 -->\n",
+			want: "<!-- CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=API; STATUS=IMPL; KEYWORDS=\"a;b\"; TITLE=Some; UPDATED=2025-10-15 -->\n",
+		},
+		{
+			name: "continued token",
+			file: "a.go",
+			src: "// CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; TEST=TestA, \\\n" +
+				"//   TestB; DOC_HASH=abc; ASPECT=API; STATUS=TESTED; DOC=user:docs/a.md; UPDATED=2025-10-15\nfunc A() {}\n",
+			want: "// CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=API; STATUS=TESTED; UPDATED=2025-10-15\n" +
+				"//   CANARY+: TEST=TestA, TestB\n" +
+				"//   CANARY+: DOC=user:docs/a.md; DOC_HASH=abc\nfunc A() {}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, diags := FormatSource(tt.file, []byte(tt.src), "2026-01-02", nil)
+			if len(diags) != 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if len(changed) != 1 {
+				t.Errorf("changed %d tokens, want 1", len(changed))
+			}
+
+			// Formatting is idempotent and keeps the token's meaning
+			again, changed, _ := FormatSource(tt.file, got, "2026-01-02", nil)
+			if string(again) != string(got) || len(changed) != 0 {
+				t.Errorf("second pass changed the source:\n%s", again)
+			}
+			before, _ := Parse(tt.file, []byte(tt.src), nil)
+			after, _ := Parse(tt.file, got, nil)
+			if len(after) != 1 || len(before) == 1 && Format(before[0]) != Format(after[0]) {
+				t.Errorf("token changed meaning: %v", after)
+			}
+		})
+	}
+}
+
+func TestFormatSource_LeavesBrokenTokens(t *testing.T) {
+	src := "// CANARY: REQ=CBIN-200; junk; FEATURE=\"X\"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15\n" +
+		"// CANARY: REQ=CBIN-201; FEATURE=\"Y\"; ASPECT=Nope; STATUS=IMPL; UPDATED=2025-10-15\n"
+	got, changed, diags := FormatSource("a.go", []byte(src), "2026-01-02", nil)
+	if string(got) != src || len(changed) != 0 {
+		t.Errorf("broken tokens were rewritten:\n%s", got)
+	}
+	if len(diags) != 2 {
+		t.Errorf("diags = %v, want 2", diags)
+	}
+}
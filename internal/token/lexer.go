@@ -0,0 +1,380 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package token
+
+// CANARY: REQ=CBIN-155; FEATURE="CommentLexer"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_155_Engine_CommentLexer; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Delim is a pair of opening and closing delimiters.
+type Delim struct {
+	Open, Close string
+
+	// Nested allows the delimiters to nest, as in Haskell and Rust block
+	// comments.
+	Nested bool
+	// Raw disables backslash escapes inside a string.
+	Raw bool
+	// Multiline lets a string span lines. Other strings end at a newline
+	// so a stray quote can't hide the rest of the file.
+	Multiline bool
+}
+
+// Syntax describes where comments and string literals are in a language.
+type Syntax struct {
+	Line    []string // line comment markers
+	Block   []Delim  // block comments
+	Strings []Delim  // string literals, skipped when looking for comments
+	// Docstrings are string literals that count as comments when they are
+	// the first thing on their line, like Python docstrings.
+	Docstrings []Delim
+}
+
+var (
+	cLike = Syntax{
+		Line:    []string{"//"},
+		Block:   []Delim{{Open: "/*", Close: "*/"}},
+		Strings: []Delim{{Open: `"`, Close: `"`}, {Open: "'", Close: "'"}},
+	}
+	nestedCLike = Syntax{
+		Line:    []string{"//"},
+		Block:   []Delim{{Open: "/*", Close: "*/", Nested: true}},
+		Strings: []Delim{{Open: `"""`, Close: `"""`, Multiline: true}, {Open: `"`, Close: `"`}},
+	}
+	goSyntax = Syntax{
+		Line:  []string{"//"},
+		Block: []Delim{{Open: "/*", Close: "*/"}},
+		Strings: []Delim{
+			{Open: "`", Close: "`", Raw: true, Multiline: true},
+			{Open: `"`, Close: `"`}, {Open: "'", Close: "'"},
+		},
+	}
+	jsSyntax = Syntax{
+		Line:  []string{"//"},
+		Block: []Delim{{Open: "/*", Close: "*/"}},
+		Strings: []Delim{
+			{Open: "`", Close: "`", Multiline: true},
+			{Open: `"`, Close: `"`}, {Open: "'", Close: "'"},
+		},
+	}
+	cssSyntax = Syntax{
+		Block:   []Delim{{Open: "/*", Close: "*/"}},
+		Strings: []Delim{{Open: `"`, Close: `"`}, {Open: "'", Close: "'"}},
+	}
+	pythonSyntax = Syntax{
+		Line: []string{"#"},
+		Docstrings: []Delim{
+			{Open: `"""`, Close: `"""`, Multiline: true},
+			{Open: "'''", Close: "'''", Multiline: true},
+		},
+		Strings: []Delim{{Open: `"`, Close: `"`}, {Open: "'", Close: "'"}},
+	}
+	hashSyntax = Syntax{
+		Line:    []string{"#"},
+		Strings: []Delim{{Open: `"`, Close: `"`}, {Open: "'", Close: "'"}},
+	}
+	hclSyntax = Syntax{
+		Line:    []string{"#", "//"},
+		Block:   []Delim{{Open: "/*", Close: "*/"}},
+		Strings: []Delim{{Open: `"`, Close: `"`}},
+	}
+	sqlSyntax = Syntax{
+		Line:    []string{"--"},
+		Block:   []Delim{{Open: "/*", Close: "*/"}},
+		Strings: []Delim{{Open: "'", Close: "'"}, {Open: `"`, Close: `"`}},
+	}
+	luaSyntax = Syntax{
+		Line:  []string{"--"},
+		Block: []Delim{{Open: "--[[", Close: "]]"}},
+		Strings: []Delim{
+			{Open: "[[", Close: "]]", Raw: true, Multiline: true},
+			{Open: `"`, Close: `"`}, {Open: "'", Close: "'"},
+		},
+	}
+	haskellSyntax = Syntax{
+		Line:    []string{"--"},
+		Block:   []Delim{{Open: "{-", Close: "-}", Nested: true}},
+		Strings: []Delim{{Open: `"`, Close: `"`}},
+	}
+	ocamlSyntax = Syntax{
+		Block:   []Delim{{Open: "(*", Close: "*)", Nested: true}},
+		Strings: []Delim{{Open: `"`, Close: `"`}},
+	}
+	fsharpSyntax = Syntax{
+		Line:    []string{"//"},
+		Block:   []Delim{{Open: "(*", Close: "*)", Nested: true}},
+		Strings: []Delim{{Open: `"`, Close: `"`}},
+	}
+	lispSyntax = Syntax{
+		Line:    []string{";"},
+		Strings: []Delim{{Open: `"`, Close: `"`}},
+	}
+	erlangSyntax = Syntax{
+		Line:    []string{"%"},
+		Strings: []Delim{{Open: `"`, Close: `"`}},
+	}
+	markupSyntax = Syntax{
+		Block: []Delim{{Open: "<!--", Close: "-->"}},
+	}
+	jinjaSyntax = Syntax{
+		Block: []Delim{{Open: "{#", Close: "#}"}, {Open: "<!--", Close: "-->"}},
+	}
+)
+
+// syntaxes maps lower-cased file extensions, and a few well-known file
+// names, to their comment syntax. Files not listed here (Markdown, plain
+// text, unknown languages) fall back to matching tokens at the start of a
+// line.
+var syntaxes = map[string]*Syntax{
+	".go": &goSyntax,
+
+	".c": &cLike, ".h": &cLike, ".cc": &cLike, ".cpp": &cLike, ".cxx": &cLike,
+	".hpp": &cLike, ".hh": &cLike, ".m": &cLike, ".mm": &cLike, ".cs": &cLike,
+	".java": &cLike, ".groovy": &cLike, ".gradle": &cLike, ".php": &cLike,
+	".proto": &cLike, ".zig": &cLike, ".scss": &cLike, ".less": &cLike,
+
+	".rs": &nestedCLike, ".swift": &nestedCLike, ".kt": &nestedCLike,
+	".kts": &nestedCLike, ".scala": &nestedCLike, ".dart": &nestedCLike,
+
+	".js": &jsSyntax, ".jsx": &jsSyntax, ".mjs": &jsSyntax, ".cjs": &jsSyntax,
+	".ts": &jsSyntax, ".tsx": &jsSyntax, ".mts": &jsSyntax, ".cts": &jsSyntax,
+
+	".css": &cssSyntax,
+
+	".py": &pythonSyntax, ".pyi": &pythonSyntax,
+
+	".sh": &hashSyntax, ".bash": &hashSyntax, ".zsh": &hashSyntax, ".fish": &hashSyntax,
+	".rb": &hashSyntax, ".pl": &hashSyntax, ".pm": &hashSyntax, ".r": &hashSyntax,
+	".yaml": &hashSyntax, ".yml": &hashSyntax, ".toml": &hashSyntax,
+	".ps1": &hashSyntax, ".cmake": &hashSyntax, ".ex": &hashSyntax, ".exs": &hashSyntax,
+	".nim": &hashSyntax, ".jl": &hashSyntax, ".cfg": &hashSyntax, ".conf": &hashSyntax,
+	"makefile": &hashSyntax, "dockerfile": &hashSyntax, "gnumakefile": &hashSyntax,
+
+	".tf": &hclSyntax, ".hcl": &hclSyntax,
+
+	".sql": &sqlSyntax,
+
+	".lua": &luaSyntax,
+
+	".hs": &haskellSyntax, ".elm": &haskellSyntax, ".purs": &haskellSyntax,
+
+	".ml": &ocamlSyntax, ".mli": &ocamlSyntax,
+
+	".fs": &fsharpSyntax, ".fsi": &fsharpSyntax, ".fsx": &fsharpSyntax,
+
+	".clj": &lispSyntax, ".cljs": &lispSyntax, ".el": &lispSyntax,
+	".lisp": &lispSyntax, ".scm": &lispSyntax,
+
+	".erl": &erlangSyntax, ".hrl": &erlangSyntax,
+
+	".html": &markupSyntax, ".htm": &markupSyntax, ".xml": &markupSyntax,
+	".svg": &markupSyntax, ".vue": &markupSyntax, ".svelte": &markupSyntax,
+
+	".j2": &jinjaSyntax, ".jinja": &jinjaSyntax, ".jinja2": &jinjaSyntax,
+	".njk": &jinjaSyntax, ".twig": &jinjaSyntax,
+}
+
+// SyntaxFor returns the comment syntax for file, or nil when its language
+// is unknown.
+func SyntaxFor(file string) *Syntax {
+	base := strings.ToLower(filepath.Base(file))
+	if s, ok := syntaxes[base]; ok {
+		return s
+	}
+	return syntaxes[filepath.Ext(base)]
+}
+
+// span is the text of one comment, excluding its delimiters, as byte
+// offsets into the source.
+type span struct{ start, end int }
+
+type opener struct {
+	Delim
+	kind int
+}
+
+const (
+	kindLine = iota
+	kindBlock
+	kindString
+	kindDoc
+)
+
+// openers returns every delimiter of s, longest first so that "--[[" wins
+// over "--" and `"""` over `"`.
+func (s *Syntax) openers() []opener {
+	var ops []opener
+	for _, l := range s.Line {
+		ops = append(ops, opener{Delim{Open: l}, kindLine})
+	}
+	for _, d := range s.Block {
+		ops = append(ops, opener{d, kindBlock})
+	}
+	for _, d := range s.Docstrings {
+		ops = append(ops, opener{d, kindDoc})
+	}
+	for _, d := range s.Strings {
+		ops = append(ops, opener{d, kindString})
+	}
+	sort.SliceStable(ops, func(i, j int) bool { return len(ops[i].Open) > len(ops[j].Open) })
+	return ops
+}
+
+// comments lexes src and returns the span of every comment, skipping the
+// contents of string literals.
+func (s *Syntax) comments(src []byte) []span {
+	ops := s.openers()
+
+	var (
+		out       []span
+		lineStart = 0
+	)
+	for i := 0; i < len(src); {
+		if src[i] == '\n' {
+			i++
+			lineStart = i
+			continue
+		}
+
+		var op *opener
+		for k := range ops {
+			if bytes.HasPrefix(src[i:], []byte(ops[k].Open)) {
+				op = &ops[k]
+				break
+			}
+		}
+		if op == nil {
+			i++
+			continue
+		}
+
+		at, start := i, i+len(op.Open)
+		switch op.kind {
+		case kindLine:
+			end := start + lineEnd(src[start:])
+			out = append(out, span{start, end})
+			i = end
+
+		case kindBlock:
+			end, next := closeBlock(src, start, op.Delim)
+			out = append(out, span{start, end})
+			i = next
+
+		case kindDoc:
+			end, next := closeString(src, start, op.Delim)
+			if len(bytes.TrimSpace(src[lineStart:i])) == 0 {
+				out = append(out, span{start, end})
+			}
+			i = next
+
+		case kindString:
+			_, i = closeString(src, start, op.Delim)
+		}
+
+		if nl := bytes.LastIndexByte(src[at:i], '\n'); nl >= 0 {
+			lineStart = at + nl + 1
+		}
+	}
+
+	return out
+}
+
+// lineEnd returns the offset of the first newline in b, or len(b).
+func lineEnd(b []byte) int {
+	if n := bytes.IndexByte(b, '\n'); n >= 0 {
+		return n
+	}
+	return len(b)
+}
+
+// closeBlock finds the end of a block comment whose body starts at start.
+// It returns the end of the body and the offset just past the closing
+// delimiter. An unterminated comment runs to the end of src.
+func closeBlock(src []byte, start int, d Delim) (end, next int) {
+	depth := 1
+	for i := start; i < len(src); {
+		switch {
+		case bytes.HasPrefix(src[i:], []byte(d.Close)):
+			depth--
+			if depth == 0 || !d.Nested {
+				return i, i + len(d.Close)
+			}
+			i += len(d.Close)
+		case d.Nested && bytes.HasPrefix(src[i:], []byte(d.Open)):
+			depth++
+			i += len(d.Open)
+		default:
+			i++
+		}
+	}
+	return len(src), len(src)
+}
+
+// closeString finds the end of a string literal whose body starts at start.
+// Single-line strings stop at the end of the line.
+func closeString(src []byte, start int, d Delim) (end, next int) {
+	for i := start; i < len(src); i++ {
+		switch {
+		case !d.Raw && src[i] == '\\':
+			i++
+		case src[i] == '\n' && !d.Multiline:
+			return i, i
+		case bytes.HasPrefix(src[i:], []byte(d.Close)):
+			return i, i + len(d.Close)
+		}
+	}
+	return len(src), len(src)
+}
+
+// decoration is the comment furniture allowed between the start of a
+// comment line and "CANARY:", such as the "*" of a JavaDoc block.
+const decoration = " \t*/#!-;%{"
+
+// commentLines splits every comment in src into lines of comment text with
+// leading decoration removed.
+func (s *Syntax) commentLines(src []byte) []commentLine {
+	var out []commentLine
+
+	offs := lineOffsets(src)
+	for _, c := range s.comments(src) {
+		for pos := c.start; pos < c.end; {
+			end := pos + lineEnd(src[pos:c.end])
+
+			ln := sort.Search(len(offs), func(i int) bool { return offs[i] > pos }) // 1-based
+			lineStart := offs[ln-1]
+			raw := string(bytes.TrimRight(src[lineStart:lineStart+lineEnd(src[lineStart:])], "\r"))
+
+			seg := src[pos:end]
+			text := bytes.TrimLeft(seg, decoration)
+			out = append(out, commentLine{
+				line: ln,
+				raw:  raw,
+				at:   pos + len(seg) - len(text) - lineStart,
+				text: string(bytes.TrimRight(text, " \t\r")),
+			})
+
+			pos = end + 1
+		}
+	}
+
+	return out
+}
+
+// lineOffsets returns the byte offset at which each line of src starts.
+func lineOffsets(src []byte) []int {
+	offs := []int{0}
+	for i, b := range src {
+		if b == '\n' {
+			offs = append(offs, i+1)
+		}
+	}
+	return offs
+}
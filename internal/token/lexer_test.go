@@ -0,0 +1,98 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package token
+
+import (
+	"testing"
+)
+
+// TestCANARY_CBIN_155_Engine_CommentLexer verifies tokens are found inside
+// each language's comments, including block comments and docstrings.
+func TestCANARY_CBIN_155_Engine_CommentLexer(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		src    string
+		line   int
+		column int
+		prefix string
+	}{
+		{"go line", "a.go", "package a\n\n// CANARY: " + body + "\n", 3, 4, "//"},
+		{"go trailing", "a.go", "x := 1 // CANARY: " + body + "\n", 1, 11, "x := 1 //"},
+		{"go block", "a.go", "/*\n * Package a.\n *\n * CANARY: " + body + "\n */\n", 4, 4, "*"},
+		{"c one-line block", "a.c", "int x; /* CANARY: " + body + " */ int y;\n", 1, 11, "int x; /*"},
+		{"python docstring", "a.py", "def f():\n    \"\"\"Do f.\n\n    CANARY: " + body + "\n    \"\"\"\n", 4, 5, ""},
+		{"python docstring first line", "a.py", "'''CANARY: " + body + "'''\n", 1, 4, "'''"},
+		{"python hash", "a.py", "x = 1  # CANARY: " + body + "\n", 1, 10, "x = 1  #"},
+		{"lua block", "a.lua", "--[[\nCANARY: " + body + "\n]]\n", 2, 1, ""},
+		{"lua line", "a.lua", "-- CANARY: " + body + "\n", 1, 4, "--"},
+		{"haskell nested", "a.hs", "{- outer {- inner -}\n   CANARY: " + body + " -}\n", 2, 4, ""},
+		{"ocaml", "a.ml", "(* CANARY: " + body + " *)\nlet x = 1\n", 1, 4, "(*"},
+		{"jsx", "a.tsx", "return (\n  <div>\n    {/* CANARY: " + body + " */}\n  </div>\n)\n", 3, 9, "{/*"},
+		{"jinja", "a.j2", "{# CANARY: " + body + " #}\n<p>{{ x }}</p>\n", 1, 4, "{#"},
+		{"html", "a.html", "<!--\n  CANARY: " + body + "\n-->\n", 2, 3, ""},
+		{"sql", "a.sql", "SELECT 1; -- CANARY: " + body + "\n", 1, 14, "SELECT 1; --"},
+		{"makefile", "Makefile", "# CANARY: " + body + "\nall:\n", 1, 3, "#"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks, diags := Parse(tt.file, []byte(tt.src), nil)
+			if len(diags) != 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if len(toks) != 1 {
+				t.Fatalf("got %d tokens, want 1", len(toks))
+			}
+			tok := toks[0]
+			if tok.Line != tt.line || tok.Column != tt.column {
+				t.Errorf("position = %d:%d, want %d:%d", tok.Line, tok.Column, tt.line, tt.column)
+			}
+			if tok.Prefix != tt.prefix {
+				t.Errorf("prefix = %q, want %q", tok.Prefix, tt.prefix)
+			}
+			if tok.Get("UPDATED") != "2025-10-15" || tok.Feature() != "Parser" {
+				t.Errorf("unexpected fields: %v", tok.Fields)
+			}
+		})
+	}
+}
+
+func TestLexer_IgnoresStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		src  string
+	}{
+		{"go raw string", "a.go", "var s = `\n// CANARY: " + body + "\n`\n"},
+		{"go string", "a.go", "var s = \"// CANARY: " + body + "\"\n"},
+		{"go escaped quote", "a.go", "var s = \"\\\" // CANARY: " + body + "\"\n"},
+		{"js template", "a.js", "const s = `\n/* CANARY: " + body + " */\n`;\n"},
+		{"python assigned triple quote", "a.py", "s = \"\"\"\nCANARY: " + body + "\n\"\"\"\n"},
+		{"python string", "a.py", "s = '# CANARY: " + body + "'\n"},
+		{"lua long string", "a.lua", "s = [[\n-- CANARY: " + body + "\n]]\n"},
+		{"bare line in code", "a.go", "CANARY: " + body + "\n"},
+		{"prose mention", "a.go", "// The CANARY: marker starts a token\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks, diags := Parse(tt.file, []byte(tt.src), nil)
+			if len(toks) != 0 || len(diags) != 0 {
+				t.Errorf("expected nothing, got toks=%d diags=%v", len(toks), diags)
+			}
+		})
+	}
+}
+
+func TestLexer_UnknownExtensionFallsBack(t *testing.T) {
+	if SyntaxFor("README.md") != nil || SyntaxFor("notes") != nil {
+		t.Fatal("markdown and extensionless files should use line matching")
+	}
+	if SyntaxFor("src/Main.JAVA") == nil || SyntaxFor("build/Dockerfile") == nil {
+		t.Fatal("expected known syntax")
+	}
+}
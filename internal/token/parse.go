@@ -0,0 +1,279 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package token
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/reqid"
+)
+
+var (
+	// markerRe matches the optional comment marker at the start of a line in
+	// files without a known comment syntax.
+	markerRe = regexp.MustCompile(`^[ \t]*(//|#|--|/\*|<!--|\[//\]:[ \t]*#)?[ \t]*`)
+	keyRe    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// CANARY: REQ=CBIN-156; FEATURE="TokenContinuation"; ASPECT=Engine; STATUS=TESTED; OWNER=canary; UPDATED=2026-10-17
+// CANARY+: TEST=TestCANARY_CBIN_156_Engine_ContinuationLines
+const (
+	marker = "CANARY:"
+	// continuation starts a comment line that adds fields to the token on
+	// the line above it.
+	continuation = "CANARY+:"
+)
+
+// commentLine is one line of comment text that may hold a token or continue
+// the token above it.
+type commentLine struct {
+	line int    // 1-based line number
+	raw  string // the full source line
+	at   int    // byte offset of text within raw
+	text string // comment text, without markers or closing delimiters
+}
+
+// end returns the byte offset in raw just past the comment text.
+func (l commentLine) end() int { return l.at + len(l.text) }
+
+// Parse extracts every CANARY token from src. For languages known to
+// SyntaxFor, only tokens that start a line of a real comment are found, and
+// text inside string literals is ignored. Other files are matched line by
+// line.
+//
+// A token continues onto the next comment line when it ends with a
+// backslash, or when the next line starts with "CANARY+:". The continued
+// token is parsed as one and keeps the line it started on.
+//
+// STATUS must be a state of lc, the project's lifecycle. Tokens with error
+// diagnostics are not returned, so callers that count tokens and callers
+// that store them always see the same set.
+func Parse(file string, src []byte, lc *lifecycle.Lifecycle) ([]*Token, []Diagnostic) {
+	if !bytes.Contains(src, []byte(marker)) {
+		return nil, nil
+	}
+	return assemble(file, sourceLines(file, src), lc)
+}
+
+// ParseAll is like Parse but also returns tokens that have error
+// diagnostics, for tools such as lint that report on every token.
+func ParseAll(file string, src []byte, lc *lifecycle.Lifecycle) ([]*Token, []Diagnostic) {
+	if !bytes.Contains(src, []byte(marker)) {
+		return nil, nil
+	}
+
+	var (
+		toks  []*Token
+		diags []Diagnostic
+	)
+	for _, p := range parseAll(file, sourceLines(file, src), lc) {
+		toks = append(toks, p.tok)
+		diags = append(diags, p.diags...)
+	}
+	return toks, diags
+}
+
+// sourceLines returns the comment lines of src that may hold tokens: the
+// lines of real comments for known languages, otherwise every line.
+func sourceLines(file string, src []byte) []commentLine {
+	if s := SyntaxFor(file); s != nil {
+		return s.commentLines(src)
+	}
+
+	var lines []commentLine
+	for i, l := range strings.Split(string(src), "\n") {
+		lines = append(lines, plainLine(i+1, strings.TrimRight(l, "\r")))
+	}
+	return lines
+}
+
+// ParseLine parses a single source line without regard to its language or
+// continuation lines. It returns a nil token when the line doesn't start
+// with a CANARY token.
+func ParseLine(file string, line int, text string, lc *lifecycle.Lifecycle) (*Token, []Diagnostic) {
+	l := plainLine(line, text)
+	if !strings.HasPrefix(l.text, marker) {
+		return nil, nil
+	}
+	return newToken(file, l, l.text[len(marker):], l.line, l.raw, lc)
+}
+
+// plainLine treats a whole source line as comment text behind an optional
+// marker.
+func plainLine(line int, text string) commentLine {
+	at := len(markerRe.FindString(text))
+	body := strings.TrimSpace(text[at:])
+	body = strings.TrimSpace(strings.TrimSuffix(body, "-->"))
+	body = strings.TrimSpace(strings.TrimSuffix(body, "*/"))
+	return commentLine{line: line, raw: text, at: at, text: body}
+}
+
+// assemble joins continued lines into logical tokens and parses them.
+// Tokens with error diagnostics are dropped.
+func assemble(file string, lines []commentLine, lc *lifecycle.Lifecycle) ([]*Token, []Diagnostic) {
+	var (
+		toks  []*Token
+		diags []Diagnostic
+	)
+
+	for _, p := range parseAll(file, lines, lc) {
+		diags = append(diags, p.diags...)
+		if !HasErrors(p.diags) {
+			toks = append(toks, p.tok)
+		}
+	}
+
+	return toks, diags
+}
+
+// parsed is a token with the diagnostics found while parsing it.
+type parsed struct {
+	tok   *Token
+	diags []Diagnostic
+}
+
+// parseAll joins continued lines into logical tokens and parses them,
+// keeping invalid tokens.
+func parseAll(file string, lines []commentLine, lc *lifecycle.Lifecycle) []parsed {
+	var out []parsed
+
+	for i := 0; i < len(lines); i++ {
+		start := i
+		first := lines[i]
+		if !strings.HasPrefix(first.text, marker) {
+			continue
+		}
+
+		body := first.text[len(marker):]
+		raw := []string{first.raw}
+		for i+1 < len(lines) && lines[i+1].line == lines[i].line+1 {
+			next := strings.TrimLeft(lines[i+1].text, decoration)
+			if trimmed := strings.TrimRight(body, " \t"); strings.HasSuffix(trimmed, `\`) {
+				body = strings.TrimRight(strings.TrimSuffix(trimmed, `\`), " \t") + " " + next
+			} else if strings.HasPrefix(next, continuation) {
+				body += "; " + next[len(continuation):]
+			} else {
+				break
+			}
+			i++
+			raw = append(raw, lines[i].raw)
+		}
+
+		tok, diags := newToken(file, first, body, lines[i].line, strings.Join(raw, "\n"), lc)
+		tok.lines = lines[start : i+1]
+		out = append(out, parsed{tok, diags})
+	}
+
+	return out
+}
+
+// newToken parses body, the text after "CANARY:", of a token starting on l.
+func newToken(file string, l commentLine, body string, endLine int, raw string, lc *lifecycle.Lifecycle) (*Token, []Diagnostic) {
+	indent := l.raw[:len(l.raw)-len(strings.TrimLeft(l.raw, " \t"))]
+	if len(indent) > l.at {
+		indent = indent[:l.at]
+	}
+	prefix := strings.TrimSpace(l.raw[len(indent):l.at])
+
+	tok, diags := parseBody(file, l.line, l.at+1, raw, indent, prefix, strings.TrimSpace(body), lc)
+	tok.EndLine = endLine
+	return tok, diags
+}
+
+// parseBody parses the fields that follow "CANARY:" on a source line.
+func parseBody(file string, line, column int, raw, indent, prefix, body string, lc *lifecycle.Lifecycle) (*Token, []Diagnostic) {
+	tok := &Token{
+		File:   file,
+		Line:   line,
+		Column: column,
+		Indent: indent,
+		Prefix: prefix,
+		Raw:    raw,
+		Fields: map[string]string{},
+	}
+
+	diag := func(code, format string, args ...any) Diagnostic {
+		return Diagnostic{
+			File:     file,
+			Line:     line,
+			Column:   tok.Column,
+			Severity: SeverityError,
+			Code:     code,
+			Message:  fmt.Sprintf(format, args...),
+		}
+	}
+
+	var diags []Diagnostic
+	for _, seg := range splitSegments(body) {
+		seg = strings.TrimSpace(seg)
+		if seg == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(seg, "=")
+		k = strings.TrimSpace(k)
+		if !ok || !keyRe.MatchString(k) {
+			diags = append(diags, diag(CodeBadSegment, "bad kv segment %q", seg))
+			continue
+		}
+		k = strings.ToUpper(k)
+		if _, dup := tok.Fields[k]; !dup {
+			tok.Keys = append(tok.Keys, k)
+		}
+		tok.Fields[k] = strings.TrimSpace(v)
+	}
+
+	for _, k := range Required {
+		if tok.Get(k) == "" {
+			diags = append(diags, diag(CodeMissingField, "missing %s in token", k))
+		}
+	}
+	if s := tok.Status(); s != "" && !lc.Has(s) {
+		diags = append(diags, diag(CodeInvalidStatus, "invalid STATUS %s", tok.Get("STATUS")))
+	}
+	if a := tok.Get("ASPECT"); a != "" {
+		if err := reqid.ValidateAspect(a); err != nil {
+			msg := fmt.Sprintf("invalid ASPECT %s", a)
+			if hint := reqid.SuggestAspect(a); hint != "" {
+				msg += fmt.Sprintf(" (did you mean %s?)", hint)
+			}
+			diags = append(diags, diag(CodeInvalidAspect, "%s", msg))
+		}
+	}
+
+	return tok, diags
+}
+
+// splitSegments splits a token body on semicolons that aren't inside quotes.
+func splitSegments(s string) []string {
+	var (
+		out   []string
+		start int
+		quote byte
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			// Only treat quotes that open a value as delimiters so that
+			// apostrophes inside unquoted text don't swallow the line.
+			if strings.HasSuffix(strings.TrimSpace(s[start:i]), "=") {
+				quote = c
+			}
+		case c == ';':
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+package token
+
+import (
+	"testing"
+
+	"go.devnw.com/canary/internal/lifecycle"
+)
+
+const body = `REQ=CBIN-200; FEATURE="Parser"; ASPECT=Engine; STATUS=IMPL; UPDATED=2025-10-15`
+
+// TestCANARY_CBIN_150_Engine_ParseCommentStyles verifies every supported
+// comment style yields the same token.
+func TestCANARY_CBIN_150_Engine_ParseCommentStyles(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		prefix string
+		column int
+	}{
+		{"go", "// CANARY: " + body, "//", 4},
+		{"indented go", "\t// CANARY: " + body, "//", 5},
+		{"hash", "# CANARY: " + body, "#", 3},
+		{"sql", "-- CANARY: " + body, "--", 4},
+		{"block", "/* CANARY: " + body + " */", "/*", 4},
+		{"html", "<!-- CANARY: " + body + " -->", "<!--", 6},
+		{"markdown", "[//]: # CANARY: " + body, "[//]: #", 9},
+		{"bare", "CANARY: " + body, "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks, diags := Parse("f.txt", []byte("first line\n"+tt.line+"\n"), nil)
+			if len(diags) != 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if len(toks) != 1 {
+				t.Fatalf("got %d tokens, want 1", len(toks))
+			}
+			tok := toks[0]
+			if tok.Line != 2 || tok.Column != tt.column {
+				t.Errorf("position = %d:%d, want 2:%d", tok.Line, tok.Column, tt.column)
+			}
+			if tok.Prefix != tt.prefix {
+				t.Errorf("prefix = %q, want %q", tok.Prefix, tt.prefix)
+			}
+			if tok.ReqID() != "CBIN-200" || tok.Feature() != "Parser" || tok.Aspect() != "Engine" ||
+				tok.Status() != "IMPL" || tok.Get("UPDATED") != "2025-10-15" {
+				t.Errorf("unexpected fields: %v", tok.Fields)
+			}
+		})
+	}
+}
+
+func TestParse_Diagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		code string
+	}{
+		{"missing field", `// CANARY: REQ=CBIN-200; FEATURE="X"; ASPECT=API; STATUS=IMPL`, CodeMissingField},
+		{"invalid status", `// CANARY: REQ=CBIN-200; FEATURE="X"; ASPECT=API; STATUS=DONE; UPDATED=2025-10-15`, CodeInvalidStatus},
+		{"invalid aspect", `// CANARY: REQ=CBIN-200; FEATURE="X"; ASPECT=Nope; STATUS=IMPL; UPDATED=2025-10-15`, CodeInvalidAspect},
+		{"bad segment", `// CANARY: REQ=CBIN-200; junk; FEATURE="X"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15`, CodeBadSegment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks, diags := Parse("f.go", []byte(tt.line), nil)
+			if len(toks) != 0 {
+				t.Errorf("invalid token was returned: %+v", toks[0])
+			}
+			if len(diags) != 1 || diags[0].Code != tt.code {
+				t.Fatalf("diags = %v, want one %s", diags, tt.code)
+			}
+			if diags[0].Line != 1 || diags[0].Column != 4 {
+				t.Errorf("diag position = %d:%d, want 1:4", diags[0].Line, diags[0].Column)
+			}
+		})
+	}
+}
+
+// TestParse_LifecycleStatus verifies STATUS is checked against the lifecycle
+// passed in rather than the default one.
+func TestParse_LifecycleStatus(t *testing.T) {
+	lc := &lifecycle.Lifecycle{States: []lifecycle.State{{Name: "DRAFT", Pending: true}, {Name: "DONE", Satisfies: true}}}
+	line := `// CANARY: REQ=CBIN-200; FEATURE="X"; ASPECT=API; STATUS=DONE; UPDATED=2025-10-15`
+	if toks, diags := Parse("f.go", []byte(line), lc); len(toks) != 1 || len(diags) != 0 {
+		t.Errorf("STATUS=DONE under a lifecycle with DONE: toks = %d, diags = %v", len(toks), diags)
+	}
+	line = `// CANARY: REQ=CBIN-200; FEATURE="X"; ASPECT=API; STATUS=IMPL; UPDATED=2025-10-15`
+	if _, diags := Parse("f.go", []byte(line), lc); len(diags) != 1 || diags[0].Code != CodeInvalidStatus {
+		t.Errorf("STATUS=IMPL under a lifecycle without IMPL: diags = %v", diags)
+	}
+}
+
+func TestParse_QuotedSemicolonAndNormalization(t *testing.T) {
+	line := `// CANARY: REQ=CBIN‑201; FEATURE="a;b"; ASPECT=engine; STATUS=tested; TEST=TestA, TestB; UPDATED=2025-10-15`
+	toks, diags := Parse("f.go", []byte(line), nil)
+	if len(diags) != 0 || len(toks) != 1 {
+		t.Fatalf("toks=%d diags=%v", len(toks), diags)
+	}
+	tok := toks[0]
+	if tok.Feature() != "a;b" {
+		t.Errorf("feature = %q", tok.Feature())
+	}
+	if tok.ReqID() != "CBIN-201" || tok.Aspect() != "Engine" || tok.Status() != "TESTED" {
+		t.Errorf("normalization failed: %s %s %s", tok.ReqID(), tok.Aspect(), tok.Status())
+	}
+	if got := tok.Tests(); len(got) != 2 || got[1] != "TestB" {
+		t.Errorf("tests = %v", got)
+	}
+	want := []string{"REQ", "FEATURE", "ASPECT", "STATUS", "TEST", "UPDATED"}
+	for i, k := range want {
+		if tok.Keys[i] != k {
+			t.Fatalf("keys = %v, want %v", tok.Keys, want)
+		}
+	}
+}
+
+func TestParse_IgnoresMidLineMentions(t *testing.T) {
+	src := `fmt.Println("// CANARY: REQ=CBIN-1")
+x := 1 // see CANARY: docs`
+	toks, diags := Parse("f.go", []byte(src), nil)
+	if len(toks) != 0 || len(diags) != 0 {
+		t.Errorf("expected nothing, got toks=%d diags=%v", len(toks), diags)
+	}
+}
+
+// TestCANARY_CBIN_156_Engine_ContinuationLines verifies tokens continued
+// with a trailing backslash or CANARY+: lines parse as one token.
+func TestCANARY_CBIN_156_Engine_ContinuationLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		src   string
+		start int
+		end   int
+	}{
+		{"backslash", "a.go", "package a\n// CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=Engine; \\\n//   STATUS=TESTED; TEST=TestA, \\\n//   TestB; UPDATED=2025-10-15\nfunc A() {}\n", 2, 4},
+		{"plus lines", "a.go", "package a\n// CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=Engine; STATUS=TESTED\n// CANARY+: TEST=TestA, TestB\n// CANARY+: UPDATED=2025-10-15\n", 2, 4},
+		{"block comment", "a.go", "package a\n/*\n * CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=Engine; STATUS=TESTED;\n * CANARY+: TEST=TestA, TestB; UPDATED=2025-10-15\n */\n", 3, 4},
+		{"python", "a.py", "x = 1\n# CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=Engine; \\\n# STATUS=TESTED; TEST=TestA, TestB; UPDATED=2025-10-15\n", 2, 3},
+		{"plain text", "a.md", "x\n<!-- CANARY: REQ=CBIN-200; FEATURE=\"Parser\"; ASPECT=Engine; STATUS=TESTED -->\n<!-- CANARY+: TEST=TestA, TestB; UPDATED=2025-10-15 -->\n", 2, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks, diags := Parse(tt.file, []byte(tt.src), nil)
+			if len(diags) != 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if len(toks) != 1 {
+				t.Fatalf("got %d tokens, want 1", len(toks))
+			}
+			tok := toks[0]
+			if tok.Line != tt.start || tok.EndLine != tt.end {
+				t.Errorf("lines = %d-%d, want %d-%d", tok.Line, tok.EndLine, tt.start, tt.end)
+			}
+			if tok.ReqID() != "CBIN-200" || tok.Status() != "TESTED" || tok.Get("UPDATED") != "2025-10-15" {
+				t.Errorf("unexpected fields: %v", tok.Fields)
+			}
+			if got := tok.Tests(); len(got) != 2 || got[0] != "TestA" || got[1] != "TestB" {
+				t.Errorf("tests = %v", got)
+			}
+		})
+	}
+}
+
+func TestParse_ContinuationNeedsAdjacentLine(t *testing.T) {
+	src := "// CANARY: " + body + "\n\n// CANARY+: TEST=TestA\n"
+	toks, _ := Parse("a.go", []byte(src), nil)
+	if len(toks) != 1 || toks[0].Has("TEST") || toks[0].EndLine != 1 {
+		t.Fatalf("continuation after a blank line was joined: %+v", toks)
+	}
+}
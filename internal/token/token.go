@@ -0,0 +1,157 @@
+// Copyright (c) 2025 by Developer Network.
+//
+// For more details, see the LICENSE file in the root directory of this
+// source code repository or contact Developer Network at info@devnw.com.
+
+// Package token is the single parser for CANARY tokens. Every command that
+// reads tokens from source (scan, index, next, implement and the legacy root
+// scanner) goes through Parse so they all agree on which lines are tokens
+// and which tokens are valid.
+package token
+
+// CANARY: REQ=CBIN-150; FEATURE="SharedTokenParser"; ASPECT=Engine; STATUS=TESTED; TEST=TestCANARY_CBIN_150_Engine_ParseCommentStyles; OWNER=canary; UPDATED=2026-10-17
+
+import (
+	"fmt"
+	"strings"
+
+	"go.devnw.com/canary/internal/reqid"
+)
+
+// Required lists the fields every token must carry.
+var Required = []string{"REQ", "FEATURE", "ASPECT", "STATUS", "UPDATED"}
+
+// Token is a single CANARY token found in a source file.
+type Token struct {
+	File    string
+	Line    int // 1-based line of the token
+	EndLine int // last line of a token continued over several lines
+	Column  int // 1-based byte column of "CANARY:"
+
+	Indent string // leading whitespace of the line
+	// Prefix is the text between Indent and "CANARY:", usually the comment
+	// marker, e.g. "//", "#", "<!--", " *" trimmed to "*" inside a block, or
+	// code followed by a marker for trailing comments. Empty for bare tokens.
+	Prefix string
+	Raw    string // the full source lines, joined by newlines
+
+	// Keys holds field names (upper-cased) in the order they were written.
+	Keys []string
+	// Fields maps upper-cased field names to their raw (possibly quoted) values.
+	Fields map[string]string
+
+	lines []commentLine // source lines the token was read from
+}
+
+// Get returns the unquoted value of field key, or "" if absent.
+func (t *Token) Get(key string) string {
+	return Unquote(t.Fields[strings.ToUpper(key)])
+}
+
+// Has reports whether the token carries field key.
+func (t *Token) Has(key string) bool {
+	_, ok := t.Fields[strings.ToUpper(key)]
+	return ok
+}
+
+// ReqID returns the REQ field with typographic hyphens normalized.
+func (t *Token) ReqID() string {
+	id := strings.TrimSpace(t.Get("REQ"))
+	id = strings.ReplaceAll(id, "‑", "-")
+	id = strings.ReplaceAll(id, "–", "-")
+	return id
+}
+
+// Feature returns the unquoted FEATURE field.
+func (t *Token) Feature() string { return t.Get("FEATURE") }
+
+// Aspect returns the ASPECT field in its canonical casing.
+func (t *Token) Aspect() string { return reqid.NormalizeAspect(t.Get("ASPECT")) }
+
+// Status returns the upper-cased STATUS field.
+func (t *Token) Status() string { return strings.ToUpper(t.Get("STATUS")) }
+
+// Tests returns the comma separated TEST references.
+func (t *Token) Tests() []string { return SplitList(t.Get("TEST")) }
+
+// Benches returns the comma separated BENCH references.
+func (t *Token) Benches() []string { return SplitList(t.Get("BENCH")) }
+
+// Pos returns the "file:line:col" position of the token.
+func (t *Token) Pos() string {
+	return fmt.Sprintf("%s:%d:%d", t.File, t.Line, t.Column)
+}
+
+// Severity classifies a Diagnostic.
+type Severity int
+
+const (
+	// SeverityError marks a token that must not be counted.
+	SeverityError Severity = iota
+	// SeverityWarning marks a suspicious but usable token.
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Diagnostic codes reported by Parse.
+const (
+	CodeBadSegment    = "bad-segment"
+	CodeMissingField  = "missing-field"
+	CodeInvalidStatus = "invalid-status"
+	CodeInvalidAspect = "invalid-aspect"
+)
+
+// Diagnostic is a problem found while parsing a token.
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int
+	Severity Severity
+	Code     string
+	Message  string
+}
+
+func (d Diagnostic) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+}
+
+// HasErrors reports whether any diagnostic is an error.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Unquote strips one level of matching single or double quotes.
+func Unquote(v string) string {
+	v = strings.TrimSpace(v)
+	if len(v) >= 2 && ((v[0] == '"' && v[len(v)-1] == '"') || (v[0] == '\'' && v[len(v)-1] == '\'')) {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
+
+// SplitList splits a comma separated field value, dropping empty entries.
+func SplitList(v string) []string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil
+	}
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
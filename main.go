@@ -13,6 +13,9 @@ import (
 	"log"
 	"os"
 	"time"
+
+	"go.devnw.com/canary/internal/config"
+	"go.devnw.com/canary/internal/scanner"
 )
 
 type report struct {
@@ -49,10 +52,23 @@ func main() {
 	flag.StringVar(&out, "out", "status.json", "output JSON file")
 	flag.StringVar(&csv, "csv", "", "optional CSV output file")
 	flag.StringVar(&verify, "verify", "", "verify claims in GAP_ANALYSIS.md")
-	flag.BoolVar(&strict, "strict", false, "strict mode: fail on stale UPDATED (>30 days) for TESTED/BENCHED")
+	flag.BoolVar(&strict, "strict", false, "strict mode: fail on stale UPDATED (>30 days) for satisfying statuses")
 	flag.Parse()
 
-	rep, err := Scan(root)
+	// The project's lifecycle decides how tokens are promoted and which
+	// statuses count as evidence
+	cfg, err := config.Load(root)
+	if err != nil {
+		log.Printf("ERROR config: %v", err)
+		os.Exit(3)
+	}
+	lc, err := cfg.StatusLifecycle()
+	if err != nil {
+		log.Printf("ERROR config: %v", err)
+		os.Exit(3)
+	}
+
+	rep, err := Scan(root, lc)
 	if err != nil {
 		log.Printf("ERROR scan: %v", err)
 		os.Exit(3)
@@ -61,7 +77,7 @@ func main() {
 
 	// Strict staleness check
 	if strict {
-		if err := CheckStaleness(rep, 30*24*time.Hour); err != nil {
+		if err := CheckStaleness(rep, 30*24*time.Hour, lc); err != nil {
 			log.Printf("CANARY_STALE %v", err)
 			// still write outputs for inspection
 			//nolint:errcheck // Error doesn't matter here, we're exiting anyway
@@ -72,14 +88,14 @@ func main() {
 
 	// Verify GAP/claims
 	if verify != "" {
-		claims, err := ParseGAPClaims(verify)
+		claims, err := ParseGAPClaims(verify, scanner.ClaimOptionsFor(cfg))
 		if err != nil {
 			log.Printf("ERROR verify-parse: %v", err)
 			//nolint:errcheck // Error doesn't matter here, we're exiting anyway
 			writeOutputs(rep, out, csv)
 			os.Exit(3)
 		}
-		if err := VerifyClaims(rep, claims); err != nil {
+		if err := VerifyClaims(rep, claims, lc); err != nil {
 			log.Printf("CANARY_VERIFY_FAIL %v", err)
 			//nolint:errcheck // Error doesn't matter here, we're exiting anyway
 			writeOutputs(rep, out, csv)
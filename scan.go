@@ -6,21 +6,20 @@
 package main
 
 import (
-	"bufio"
 	"encoding/csv"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"time"
-)
 
-var canaryRe = regexp.MustCompile(`^\s*(?://|#|--|\[//\]:\s*#|<!--)\s*CANARY:\s*(.*)$`)
-var kvRe = regexp.MustCompile(`\s*([^=;\s]+)\s*=\s*([^;]+)\s*`)
+	"go.devnw.com/canary/internal/indexer"
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/token"
+)
 
 // directories to skip during scan
 var skipDirs = map[string]bool{
@@ -28,7 +27,7 @@ var skipDirs = map[string]bool{
 	".crush": true, "data": true, "certs": true,
 }
 
-func Scan(root string) (report, error) {
+func Scan(root string, lc *lifecycle.Lifecycle) (report, error) {
 	type key struct {
 		id, feature, aspect, status, owner, updated string
 	}
@@ -49,43 +48,24 @@ func Scan(root string) (report, error) {
 			}
 			return nil
 		}
-		// only scan likely text files (simple heuristic)
-		if isBinary(path) {
-			return nil
-		}
-		f, err := os.Open(path)
-		if err != nil {
-			return nil // skip unreadable files
+		b, err := os.ReadFile(path)
+		if err != nil || indexer.IsBinary(b) {
+			return nil // skip unreadable and binary files
 		}
-
-		defer f.Close()
-		sc := bufio.NewScanner(f)
-		// Set max scanner buffer to 1MB for large lines
-		const maxScanTokenSize = 1024 * 1024
-		buf := make([]byte, maxScanTokenSize)
-		sc.Buffer(buf, maxScanTokenSize)
-		ln := 0
-		for sc.Scan() {
-			ln++
-			line := sc.Text()
-			m := canaryRe.FindStringSubmatch(line)
-			if len(m) < 2 {
-				continue
-			}
-			rec, err := parseCanaryKV(m[1])
-			if err != nil {
-				return fmt.Errorf("parse %s:%d: %w", path, ln, err)
+		toks, diags := token.Parse(path, b, lc)
+		for _, d := range diags {
+			if d.Severity == token.SeverityError {
+				return fmt.Errorf("parse %w", d)
 			}
+		}
+		for _, tok := range toks {
 			k := key{
-				id:      rec["REQ"],
-				feature: unquote(rec["FEATURE"]),
-				aspect:  rec["ASPECT"],
-				status:  rec["STATUS"],
-				owner:   rec["OWNER"],
-				updated: rec["UPDATED"],
-			}
-			if k.id == "" || k.aspect == "" || k.status == "" {
-				return fmt.Errorf("missing required fields in %s:%d", path, ln)
+				id:      tok.ReqID(),
+				feature: tok.Feature(),
+				aspect:  tok.Aspect(),
+				status:  tok.Status(),
+				owner:   tok.Get("OWNER"),
+				updated: tok.Get("UPDATED"),
 			}
 			if _, ok := agg[k]; !ok {
 				agg[k] = &val{
@@ -95,15 +75,11 @@ func Scan(root string) (report, error) {
 				}
 			}
 			agg[k].files[path] = struct{}{}
-			for _, t := range splitList(rec["TEST"]) {
-				if t != "" {
-					agg[k].tests[t] = struct{}{}
-				}
+			for _, t := range tok.Tests() {
+				agg[k].tests[t] = struct{}{}
 			}
-			for _, b := range splitList(rec["BENCH"]) {
-				if b != "" {
-					agg[k].benches[b] = struct{}{}
-				}
+			for _, b := range tok.Benches() {
+				agg[k].benches[b] = struct{}{}
 			}
 		}
 		return nil
@@ -118,19 +94,12 @@ func Scan(root string) (report, error) {
 	sumAspect := map[string]int{}
 
 	for k, v := range agg {
-		// Auto-promotion: if status is IMPL and at least one test present -> TESTED
-		// If at least one bench present and status IMPL or TESTED -> BENCHED (bench is stronger evidence)
-		promoted_status := k.status
-		if promoted_status == "IMPL" && len(v.tests) > 0 {
-			promoted_status = "TESTED"
-		}
-		if (promoted_status == "IMPL" || promoted_status == "TESTED") && len(v.benches) > 0 {
-			promoted_status = "BENCHED"
-		}
+		// Auto-promotion follows the lifecycle: by default IMPL with a test
+		// becomes TESTED, and IMPL or TESTED with a bench becomes BENCHED
 		ent := featureEntry{
 			Feature: k.feature,
 			Aspect:  k.aspect,
-			Status:  promoted_status,
+			Status:  lc.Promote(k.status, k.aspect, len(v.tests) > 0, len(v.benches) > 0),
 			Files:   keys(v.files),
 			Tests:   keys(v.tests),
 			Benches: keys(v.benches),
@@ -161,62 +130,6 @@ func Scan(root string) (report, error) {
 	}, nil
 }
 
-func parseCanaryKV(s string) (map[string]string, error) {
-	// Strip HTML comment closing marker if present
-	s = strings.TrimSuffix(strings.TrimSpace(s), "-->")
-	s = strings.TrimSpace(s)
-
-	out := map[string]string{}
-	for _, seg := range strings.Split(s, ";") {
-		seg = strings.TrimSpace(seg)
-		if seg == "" {
-			continue
-		}
-		m := kvRe.FindStringSubmatch(seg)
-		if len(m) != 3 {
-			return nil, fmt.Errorf("bad kv segment %q", seg)
-		}
-		key := strings.ToUpper(strings.TrimSpace(m[1]))
-		val := strings.TrimSpace(m[2])
-		out[key] = strings.TrimSpace(val)
-	}
-	// Validate enums (best‑effort)
-	status := strings.ToUpper(out["STATUS"])
-	switch status {
-	case "MISSING", "STUB", "IMPL", "TESTED", "BENCHED", "REMOVED":
-	default:
-		if status != "" {
-			return nil, fmt.Errorf("invalid STATUS %q", status)
-		}
-	}
-	return out, nil
-}
-
-func unquote(v string) string {
-	v = strings.TrimSpace(v)
-	if len(v) >= 2 && ((v[0] == '"' && v[len(v)-1] == '"') || (v[0] == '\'' && v[len(v)-1] == '\'')) {
-		return v[1 : len(v)-1]
-	}
-	return v
-}
-
-func splitList(v string) []string {
-	v = strings.TrimSpace(v)
-	if v == "" {
-		return nil
-	}
-	// allow comma or space
-	parts := strings.FieldsFunc(v, func(r rune) bool { return r == ',' })
-	var out []string
-	for _, p := range parts {
-		p = strings.TrimSpace(p)
-		if p != "" {
-			out = append(out, p)
-		}
-	}
-	return out
-}
-
 func keys(m map[string]struct{}) []string {
 	out := make([]string, 0, len(m))
 	for k := range m {
@@ -226,27 +139,13 @@ func keys(m map[string]struct{}) []string {
 	return out
 }
 
-func isBinary(path string) bool {
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".png", ".jpg", ".jpeg", ".gif", ".pdf", ".zip", ".gz", ".tgz", ".jar", ".wasm",
-		".exe", ".dll", ".so", ".dylib", ".db", ".db-wal", ".db-shm":
-		return true
-	}
-	// Skip files with no extension that look binary
-	if ext == "" && strings.Contains(path, "zig-out") {
-		return true
-	}
-	return false
-}
-
-// CheckStaleness: fail if any TESTED/BENCHED has UPDATED older than dur
-func CheckStaleness(rep report, dur time.Duration) error {
+// CheckStaleness: fail if any satisfying feature has UPDATED older than dur
+func CheckStaleness(rep report, dur time.Duration, lc *lifecycle.Lifecycle) error {
 	cut := time.Now().UTC().Add(-dur)
 	var errs []string
 	for _, r := range rep.Requirements {
 		for _, f := range r.Features {
-			if f.Status == "TESTED" || f.Status == "BENCHED" {
+			if lc.Satisfies(f.Status, f.Aspect) {
 				if f.Updated == "" {
 					errs = append(errs, fmt.Sprintf("%s %s missing UPDATED", r.ID, f.Feature))
 					continue
@@ -9,6 +9,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"go.devnw.com/canary/internal/scanner"
 )
 
 func TestAcceptance_ParseAndSummarizeFixture_WithPromotion(t *testing.T) {
@@ -16,7 +18,7 @@ func TestAcceptance_ParseAndSummarizeFixture_WithPromotion(t *testing.T) {
 	mustWrite(t, filepath.Join(dir, "file1.zig"), `// CANARY: REQ=REQ-GQL-042; FEATURE="CDC/Streaming"; ASPECT=API; STATUS=STUB; TEST=tests/e2e_cdc.zig:TestCANARY_REQ_GQL_042_StartStop; OWNER=streaming; UPDATED=2025-10-15`)
 	mustWrite(t, filepath.Join(dir, "file2.go"), `// CANARY: REQ=REQ-GQL-046; FEATURE="TDE"; ASPECT=Storage; STATUS=IMPL; TEST=TestCANARY_REQ_GQL_046_KeyRotate; OWNER=security; UPDATED=2025-10-15`)
 
-	rep, err := Scan(dir)
+	rep, err := Scan(dir, nil)
 	if err != nil {
 		t.Fatalf("scan: %v", err)
 	}
@@ -36,7 +38,7 @@ func TestAcceptance_ParseAndSummarizeFixture_WithPromotion(t *testing.T) {
 func TestAcceptance_PromotionToBenched(t *testing.T) {
 	dir := t.TempDir()
 	mustWrite(t, filepath.Join(dir, "file3.zig"), `// CANARY: REQ=REQ-GQL-050; FEATURE="RecursiveQuery"; ASPECT=Planner; STATUS=IMPL; BENCH=BenchmarkCANARY_REQ_GQL_050_RecursivePerf; UPDATED=2025-10-15`)
-	rep, err := Scan(dir)
+	rep, err := Scan(dir, nil)
 	if err != nil {
 		t.Fatalf("scan: %v", err)
 	}
@@ -55,9 +57,9 @@ func TestAcceptance_VerifyFailsOnOverclaim(t *testing.T) {
 	dir := t.TempDir()
 	mustWrite(t, filepath.Join(dir, "cdc.zig"), `// CANARY: REQ=REQ-GQL-042; FEATURE="CDC"; ASPECT=API; STATUS=STUB; UPDATED=2025-10-15`)
 
-	rep, _ := Scan(dir)
-	claims, _ := ParseGAPClaims(p)
-	if err := VerifyClaims(rep, claims); err == nil {
+	rep, _ := Scan(dir, nil)
+	claims, _ := ParseGAPClaims(p, scanner.ClaimOptions{})
+	if err := VerifyClaims(rep, claims, nil); err == nil {
 		t.Fatalf("expected verify error, got nil")
 	}
 }
@@ -66,8 +68,8 @@ func TestAcceptance_StrictStaleness(t *testing.T) {
 	dir := t.TempDir()
 	// 90 days old
 	mustWrite(t, filepath.Join(dir, "tde.go"), `// CANARY: REQ=REQ-GQL-046; FEATURE="TDE"; ASPECT=Storage; STATUS=TESTED; TEST=TestCANARY_REQ_GQL_046_KeyRotate; UPDATED=2025-06-01`)
-	rep, _ := Scan(dir)
-	if err := CheckStaleness(rep, 60*24*60*60*1e9); err == nil {
+	rep, _ := Scan(dir, nil)
+	if err := CheckStaleness(rep, 60*24*60*60*1e9, nil); err == nil {
 		t.Fatalf("expected staleness error")
 	}
 }
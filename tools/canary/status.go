@@ -1,8 +0,0 @@
-// Copyright (c) 2025 by Developer Network.
-//
-// For more details, see the LICENSE file in the root directory of this
-// source code repository or contact Developer Network at info@devnw.com.
-
-package main
-
-// CANARY: REQ=CBIN-103; FEATURE="StatusJSON"; ASPECT=API; STATUS=BENCHED; TEST=TestCANARY_CBIN_103_API_StatusSchema; BENCH=BenchmarkCANARY_CBIN_103_API_Emit; OWNER=canary; UPDATED=2025-10-15
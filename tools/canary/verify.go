@@ -1,8 +0,0 @@
-// Copyright (c) 2025 by Developer Network.
-//
-// For more details, see the LICENSE file in the root directory of this
-// source code repository or contact Developer Network at info@devnw.com.
-
-package main
-
-// CANARY: REQ=CBIN-102; FEATURE="VerifyGate"; ASPECT=CLI; STATUS=BENCHED; TEST=TestCANARY_CBIN_102_CLI_Verify; BENCH=BenchmarkCANARY_CBIN_102_CLI_Verify; OWNER=canary; UPDATED=2025-10-15
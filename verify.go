@@ -7,11 +7,12 @@ package main
 
 // CANARY: REQ=CBIN-102; FEATURE="VerifyGate"; ASPECT=CLI; STATUS=TESTED; TEST=TestCANARY_CBIN_102_CLI_Verify; BENCH=BenchmarkCANARY_CBIN_102_CLI_Verify; OWNER=canary; UPDATED=2025-10-15
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"regexp"
 	"strings"
+
+	"go.devnw.com/canary/internal/lifecycle"
+	"go.devnw.com/canary/internal/scanner"
 )
 
 // claim means "this REQ is claimed Implemented/Complete in GAP"
@@ -21,47 +22,38 @@ type claim struct {
 	RawLine     string
 }
 
-var reqRe = regexp.MustCompile(`REQ[\-‑]GQL[\-‑](\d{3,})`)
-var implementedRe = regexp.MustCompile(`\b(Implemented|Complete|✅)\b`)
-var notImplRe = regexp.MustCompile(`\b(STUB|NOT IMPLEMENTED|❌|◻)\b`)
-
-func ParseGAPClaims(path string) (map[string]claim, error) {
-	f, err := os.Open(path)
+// ParseGAPClaims reads the claims in the GAP file at path. IDs and markers
+// are recognized as configured by opts; see scanner.ParseClaims.
+func ParseGAPClaims(path string, opts scanner.ClaimOptions) (map[string]claim, error) {
+	src, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	parsed, err := scanner.ParseClaims(src, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 
-	defer f.Close()
 	claims := map[string]claim{}
-	sc := bufio.NewScanner(f)
-	for sc.Scan() {
-		line := sc.Text()
-		ids := reqRe.FindAllStringSubmatch(line, -1)
-		if len(ids) == 0 {
-			continue
-		}
-		for _, m := range ids {
-			id := "REQ-GQL-" + m[1]
-			c := claims[id]
-			c.REQ = id
-			c.RawLine = line
-			// A very conservative read: mark Implemented if line suggests it and no obvious NOT markers.
-			if implementedRe.MatchString(line) && !notImplRe.MatchString(line) {
-				c.Implemented = true
-			}
-			claims[id] = c
+	for _, p := range parsed {
+		c := claims[p.REQ]
+		c.REQ = p.REQ
+		c.RawLine = p.Text
+		if p.Implemented {
+			c.Implemented = true
 		}
+		claims[p.REQ] = c
 	}
-	return claims, sc.Err()
+	return claims, nil
 }
 
-func VerifyClaims(rep report, claims map[string]claim) error {
+func VerifyClaims(rep report, claims map[string]claim, lc *lifecycle.Lifecycle) error {
 	var errs []string
-	evidence := map[string]bool{} // REQ -> has TESTED/BENCHED
+	evidence := map[string]bool{} // REQ -> has a satisfying feature
 	for _, r := range rep.Requirements {
 		ok := false
 		for _, f := range r.Features {
-			if f.Status == "TESTED" || f.Status == "BENCHED" {
+			if lc.Satisfies(f.Status, f.Aspect) {
 				ok = true
 				break
 			}